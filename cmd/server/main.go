@@ -8,12 +8,15 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/config"
 	"github.com/arshamroshannejad/squidshop-backend/internal/database"
+	"github.com/arshamroshannejad/squidshop-backend/internal/repository"
 	"github.com/arshamroshannejad/squidshop-backend/internal/router"
+	"github.com/arshamroshannejad/squidshop-backend/internal/service"
 )
 
 //	@title						squidshop-backend
@@ -57,9 +60,26 @@ func main() {
 		"host", cfg.Redis.Host,
 		"port", cfg.Redis.Port,
 	)
+	repositories := repository.NewRepository(db)
+	services := service.NewService(repositories, redisDB, logger, cfg)
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	workers.Add(3)
+	go func() {
+		defer workers.Done()
+		services.Notification().Run(workersCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		services.Trash().Run(workersCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		services.ProductScheduler().Run(workersCtx)
+	}()
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.App.Port),
-		Handler:      router.SetupRoutes(db, redisDB, logger, cfg),
+		Handler:      router.SetupRoutes(services, cfg),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
@@ -86,4 +106,6 @@ func main() {
 	} else {
 		logger.Info("server is shutdown successfully")
 	}
+	stopWorkers()
+	workers.Wait()
 }
@@ -12,9 +12,10 @@ import (
 var configurations []byte
 
 type App struct {
-	Port    int    `yaml:"port"`
-	Debug   bool   `yaml:"debug"`
-	BaseAPI string `yaml:"base_api"`
+	Port       int    `yaml:"port"`
+	Debug      bool   `yaml:"debug"`
+	BaseAPI    string `yaml:"base_api"`
+	TrustProxy bool   `yaml:"trust_proxy"`
 }
 
 type Postgres struct {
@@ -35,8 +36,9 @@ type Redis struct {
 }
 
 type Jwt struct {
-	Secret        string        `yaml:"secret"`
-	AccessHourTTL time.Duration `yaml:"access_hour_ttl"`
+	Secret          string        `yaml:"secret"`
+	AccessHourTTL   time.Duration `yaml:"access_hour_ttl"`
+	SessionCacheTTL time.Duration `yaml:"session_cache_ttl"`
 }
 
 type Sms struct {
@@ -45,6 +47,14 @@ type Sms struct {
 	ApiKey  string `yaml:"sms_api_key"`
 }
 
+type Email struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
 type S3 struct {
 	Bucket    string `yaml:"bucket"`
 	Region    string `yaml:"region"`
@@ -54,13 +64,70 @@ type S3 struct {
 	Domain    string `yaml:"domain"`
 }
 
+type Notification struct {
+	Workers         int           `yaml:"workers"`
+	PriorityWorkers int           `yaml:"priority_workers"`
+	BatchSize       int           `yaml:"batch_size"`
+	PollInterval    time.Duration `yaml:"poll_interval"`
+	ProcessingLease time.Duration `yaml:"processing_lease"`
+	MaxAttempts     int           `yaml:"max_attempts"`
+	BaseBackoff     time.Duration `yaml:"base_backoff"`
+	MaxBackoff      time.Duration `yaml:"max_backoff"`
+}
+
+type Trash struct {
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+type Publication struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+type Inventory struct {
+	LowStockThreshold int `yaml:"low_stock_threshold"`
+	RestockBatchSize  int `yaml:"restock_batch_size"`
+}
+
+type Recommendation struct {
+	RecentLimit  int           `yaml:"recent_limit"`
+	CoViewWindow int           `yaml:"co_view_window"`
+	CoViewLimit  int           `yaml:"co_view_limit"`
+	ViewTTL      time.Duration `yaml:"view_ttl"`
+	RelatedSize  int           `yaml:"related_size"`
+	RelatedTTL   time.Duration `yaml:"related_ttl"`
+}
+
+type Home struct {
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
+type Cache struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// HTTPCache maps a route name to the Cache-Control of its public responses. Routes without an entry get no-cache,
+// so clients still revalidate with the ETag.
+type HTTPCache struct {
+	Routes map[string]string `yaml:"routes"`
+}
+
 type Config struct {
-	App      *App      `yaml:"app"`
-	Postgres *Postgres `yaml:"postgres"`
-	Redis    *Redis    `yaml:"redis"`
-	Jwt      *Jwt      `yaml:"jwt"`
-	Sms      *Sms      `yaml:"sms"`
-	S3       *S3       `yaml:"s3"`
+	App            *App            `yaml:"app"`
+	Postgres       *Postgres       `yaml:"postgres"`
+	Redis          *Redis          `yaml:"redis"`
+	Jwt            *Jwt            `yaml:"jwt"`
+	Sms            *Sms            `yaml:"sms"`
+	Email          *Email          `yaml:"email"`
+	S3             *S3             `yaml:"s3"`
+	Notification   *Notification   `yaml:"notification"`
+	Trash          *Trash          `yaml:"trash"`
+	Publication    *Publication    `yaml:"publication"`
+	Inventory      *Inventory      `yaml:"inventory"`
+	Recommendation *Recommendation `yaml:"recommendation"`
+	Home           *Home           `yaml:"home"`
+	Cache          *Cache          `yaml:"cache"`
+	HTTPCache      *HTTPCache      `yaml:"http_cache"`
 }
 
 func New() (*Config, error) {
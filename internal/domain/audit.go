@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+// AuditSnapshot loads the current state of an audited entity, it returns sql.ErrNoRows when the entity is gone.
+type AuditSnapshot func(ctx context.Context, entityID string) (any, error)
+
+// AuditMutation performs an audited change and returns the id of the changed entity.
+type AuditMutation func(ctx context.Context) (string, error)
+
+type AuditRepository interface {
+	GetAll(ctx context.Context, params *entity.AuditLogQueryParamRequest) ([]model.AuditLog, error)
+	Create(ctx context.Context, entry *entity.AuditLogCreateRequest) error
+}
+
+type AuditService interface {
+	GetAllAuditLogs(ctx context.Context, params *entity.AuditLogQueryParamRequest) ([]model.AuditLog, error)
+	Record(ctx context.Context, entry *entity.AuditLogCreateRequest) error
+	Track(ctx context.Context, action, entityType, entityID string, snapshot AuditSnapshot, mutate AuditMutation) error
+}
+
+type AuditHandler interface {
+	GetAllAuditLogsHandler(w http.ResponseWriter, r *http.Request)
+}
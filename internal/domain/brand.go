@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type BrandRepository interface {
+	GetAll(ctx context.Context) ([]model.Brand, error)
+	GetByID(ctx context.Context, brandID string) (*model.Brand, error)
+	GetBySlug(ctx context.Context, brandSlug string) (*model.Brand, error)
+	Create(ctx context.Context, brand *entity.BrandRequest) (string, error)
+	Update(ctx context.Context, brandID string, brand *entity.BrandRequest) error
+	Delete(ctx context.Context, brandID string) error
+}
+
+type BrandService interface {
+	GetAllBrands(ctx context.Context) ([]model.Brand, error)
+	GetBrandBySlug(ctx context.Context, brandSlug string, limit, offset int) (*model.BrandDetail, error)
+	CreateBrand(ctx context.Context, brand *entity.BrandRequest) error
+	UpdateBrand(ctx context.Context, brandID string, brand *entity.BrandRequest) error
+	DeleteBrand(ctx context.Context, brandID string) error
+}
+
+type BrandHandler interface {
+	GetAllBrandsHandler(w http.ResponseWriter, r *http.Request)
+	GetBrandBySlugHandler(w http.ResponseWriter, r *http.Request)
+	CreateBrandHandler(w http.ResponseWriter, r *http.Request)
+	UpdateBrandHandler(w http.ResponseWriter, r *http.Request)
+	DeleteBrandHandler(w http.ResponseWriter, r *http.Request)
+}
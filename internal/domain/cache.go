@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+// CacheLoader loads a value on a cache miss and names the tags that invalidate it.
+type CacheLoader func(ctx context.Context) (value any, tags []string, err error)
+
+type CacheService interface {
+	Remember(ctx context.Context, key string, dst any, load CacheLoader) error
+	InvalidateTags(ctx context.Context, tags ...string)
+	Stats() []model.CacheStats
+}
+
+type CacheHandler interface {
+	GetCacheStatsHandler(w http.ResponseWriter, r *http.Request)
+}
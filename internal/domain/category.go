@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
@@ -10,24 +11,48 @@ import (
 
 type CategoryRepository interface {
 	GetAll(ctx context.Context) ([]model.Category, error)
-	Create(ctx context.Context, category *entity.CategoryCreateRequest) error
+	GetByID(ctx context.Context, categoryID string) (*model.Category, error)
+	GetBySlug(ctx context.Context, categorySlug string) (*model.CategoryDetail, error)
+	GetChildren(ctx context.Context, categoryID string) ([]model.Category, error)
+	GetByIDs(ctx context.Context, categoryIDs []string) ([]model.Category, error)
+	GetBreadcrumb(ctx context.Context, categoryID string) ([]model.Breadcrumb, error)
+	GetImpact(ctx context.Context, categoryID string) (*model.CategoryImpact, error)
+	GetAllDeleted(ctx context.Context, limit, offset int) ([]model.Category, error)
+	Create(ctx context.Context, category *entity.CategoryCreateRequest) (string, error)
 	Update(ctx context.Context, categoryID string, category *entity.CategoryUpdateRequest) error
 	Delete(ctx context.Context, categoryID string) error
+	Merge(ctx context.Context, categoryID, targetID string) error
+	Restore(ctx context.Context, categoryID string) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
 	Exists(ctx context.Context, category *entity.CategoryQueryParamRequest) (bool, error)
 }
 
 type CategoryService interface {
 	GetAllCategories(ctx context.Context) ([]model.Category, error)
+	GetDeletedCategories(ctx context.Context, limit, offset int) ([]model.Category, error)
+	GetCategoryBySlug(ctx context.Context, categorySlug string, limit, offset int) (*model.CategoryDetail, error)
+	GetCategoryBreadcrumb(ctx context.Context, categoryID string) ([]model.Breadcrumb, error)
+	GetProductBreadcrumb(ctx context.Context, productID string) ([]model.Breadcrumb, error)
 	CreateCategory(ctx context.Context, category *entity.CategoryCreateRequest) error
 	UpdateCategory(ctx context.Context, categoryID string, category *entity.CategoryUpdateRequest) error
 	DeleteCategory(ctx context.Context, categoryID string) error
+	GetCategoryImpact(ctx context.Context, categoryID string) (*model.CategoryImpact, error)
+	MergeCategory(ctx context.Context, categoryID string, merge *entity.CategoryMergeRequest) error
+	RestoreCategory(ctx context.Context, categoryID string) error
 	ExistsCategory(ctx context.Context, category *entity.CategoryQueryParamRequest) (bool, error)
 }
 
 type CategoryHandler interface {
 	GetAllCategoriesHandler(w http.ResponseWriter, r *http.Request)
+	GetCategoryBySlugHandler(w http.ResponseWriter, r *http.Request)
+	GetCategoryBreadcrumbHandler(w http.ResponseWriter, r *http.Request)
+	GetProductBreadcrumbHandler(w http.ResponseWriter, r *http.Request)
 	CreateCategoryHandler(w http.ResponseWriter, r *http.Request)
 	UpdateCategoryHandler(w http.ResponseWriter, r *http.Request)
 	DeleteCategoryHandler(w http.ResponseWriter, r *http.Request)
+	GetCategoryImpactHandler(w http.ResponseWriter, r *http.Request)
+	MergeCategoryHandler(w http.ResponseWriter, r *http.Request)
+	GetDeletedCategoriesHandler(w http.ResponseWriter, r *http.Request)
+	RestoreCategoryHandler(w http.ResponseWriter, r *http.Request)
 	ExistsCategoryHandler(w http.ResponseWriter, r *http.Request)
 }
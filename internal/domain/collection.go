@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type CollectionRepository interface {
+	GetAll(ctx context.Context) ([]model.Collection, error)
+	GetByID(ctx context.Context, collectionID string) (*model.Collection, error)
+	GetBySlug(ctx context.Context, collectionSlug string) (*model.Collection, error)
+	GetProductIDs(ctx context.Context, collectionID string) ([]string, error)
+	Create(ctx context.Context, collection *entity.CollectionRequest) (string, error)
+	Update(ctx context.Context, collectionID string, collection *entity.CollectionRequest) error
+	Delete(ctx context.Context, collectionID string) error
+	ReplaceProducts(ctx context.Context, collectionID string, productIDs []int) error
+}
+
+type CollectionService interface {
+	GetAllCollections(ctx context.Context) ([]model.Collection, error)
+	GetCollectionBySlug(ctx context.Context, collectionSlug string, limit, offset int) (*model.CollectionDetail, error)
+	CreateCollection(ctx context.Context, collection *entity.CollectionRequest) error
+	UpdateCollection(ctx context.Context, collectionID string, collection *entity.CollectionRequest) error
+	DeleteCollection(ctx context.Context, collectionID string) error
+	SetCollectionProducts(ctx context.Context, collectionID string, products *entity.CollectionProductRequest) error
+}
+
+type CollectionHandler interface {
+	GetAllCollectionsHandler(w http.ResponseWriter, r *http.Request)
+	GetCollectionBySlugHandler(w http.ResponseWriter, r *http.Request)
+	CreateCollectionHandler(w http.ResponseWriter, r *http.Request)
+	UpdateCollectionHandler(w http.ResponseWriter, r *http.Request)
+	DeleteCollectionHandler(w http.ResponseWriter, r *http.Request)
+	SetCollectionProductsHandler(w http.ResponseWriter, r *http.Request)
+}
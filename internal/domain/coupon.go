@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type CouponRepository interface {
+	GetAll(ctx context.Context, limit, offset int) ([]model.Coupon, error)
+	GetByID(ctx context.Context, couponID string) (*model.Coupon, error)
+	GetActiveByCode(ctx context.Context, code string) (*model.Coupon, error)
+	GetUsage(ctx context.Context, couponID, userID string) (*model.CouponUsage, error)
+	Create(ctx context.Context, coupon *entity.CouponRequest) (string, error)
+	Update(ctx context.Context, couponID string, coupon *entity.CouponRequest) error
+	Delete(ctx context.Context, couponID string) error
+	Redeem(ctx context.Context, couponID, userID string, discount money.Money) error
+}
+
+type CouponService interface {
+	GetAllCoupons(ctx context.Context, limit, offset int) ([]model.Coupon, error)
+	GetCouponByID(ctx context.Context, couponID string) (*model.Coupon, error)
+	CreateCoupon(ctx context.Context, coupon *entity.CouponRequest) error
+	UpdateCoupon(ctx context.Context, couponID string, coupon *entity.CouponRequest) error
+	DeleteCoupon(ctx context.Context, couponID string) error
+	PreviewCoupon(ctx context.Context, userID string, basket *entity.CouponBasketRequest) (*model.CouponPreview, error)
+	RedeemCoupon(ctx context.Context, userID string, basket *entity.CouponBasketRequest) (*model.CouponPreview, error)
+}
+
+type CouponHandler interface {
+	GetAllCouponsHandler(w http.ResponseWriter, r *http.Request)
+	GetCouponByIDHandler(w http.ResponseWriter, r *http.Request)
+	CreateCouponHandler(w http.ResponseWriter, r *http.Request)
+	UpdateCouponHandler(w http.ResponseWriter, r *http.Request)
+	DeleteCouponHandler(w http.ResponseWriter, r *http.Request)
+	PreviewCouponHandler(w http.ResponseWriter, r *http.Request)
+	RedeemCouponHandler(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type DiscountRepository interface {
+	GetAll(ctx context.Context, limit, offset int) ([]model.Discount, error)
+	GetByID(ctx context.Context, discountID string) (*model.Discount, error)
+	GetActive(ctx context.Context) ([]model.Discount, error)
+	Create(ctx context.Context, discount *entity.DiscountRequest) (string, error)
+	Update(ctx context.Context, discountID string, discount *entity.DiscountRequest) error
+	Delete(ctx context.Context, discountID string) error
+}
+
+type DiscountService interface {
+	GetAllDiscounts(ctx context.Context, limit, offset int) ([]model.Discount, error)
+	GetDiscountByID(ctx context.Context, discountID string) (*model.Discount, error)
+	CreateDiscount(ctx context.Context, discount *entity.DiscountRequest) error
+	UpdateDiscount(ctx context.Context, discountID string, discount *entity.DiscountRequest) error
+	DeleteDiscount(ctx context.Context, discountID string) error
+}
+
+// PricingService fills model.Pricing from the discounts that are running right now.
+type PricingService interface {
+	PriceProducts(ctx context.Context, products []model.Products) error
+	PriceProduct(ctx context.Context, product *model.Product) error
+}
+
+type DiscountHandler interface {
+	GetAllDiscountsHandler(w http.ResponseWriter, r *http.Request)
+	GetDiscountByIDHandler(w http.ResponseWriter, r *http.Request)
+	CreateDiscountHandler(w http.ResponseWriter, r *http.Request)
+	UpdateDiscountHandler(w http.ResponseWriter, r *http.Request)
+	DeleteDiscountHandler(w http.ResponseWriter, r *http.Request)
+}
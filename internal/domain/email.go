@@ -0,0 +1,7 @@
+package domain
+
+import "context"
+
+type EmailService interface {
+	Send(ctx context.Context, subject, body, to string) error
+}
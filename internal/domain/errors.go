@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+var (
+	ErrDuplicate           = errors.New("resource already exists")
+	ErrInvalidCode         = errors.New("invalid or expired verification code")
+	ErrUserBanned          = errors.New("user is banned")
+	ErrParentDeleted       = errors.New("parent is in the trash")
+	ErrOutOfStock          = errors.New("not enough stock")
+	ErrInStock             = errors.New("product is in stock")
+	ErrRelatedToSelf       = errors.New("product cannot be related to itself")
+	ErrCategoryCycle       = errors.New("category cannot be moved under itself or its descendants")
+	ErrCategoryInUse       = errors.New("category still has products")
+	ErrCollectionRuleBased = errors.New("collection is rule based, its products cannot be set by hand")
+	ErrHomeSectionKind     = errors.New("home section is not a banner section")
+	ErrLastAdmin           = errors.New("user is the last active admin")
+)
+
+var (
+	ErrCouponInvalid       = errors.New("coupon code is invalid or expired")
+	ErrCouponNotApplicable = errors.New("coupon does not apply to any product in the basket")
+	ErrCouponMinBasket     = errors.New("basket is below the coupon minimum")
+	ErrCouponUsedUp        = errors.New("coupon usage limit reached")
+	ErrCouponFirstOrder    = errors.New("coupon is only valid on a first order")
+)
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type EventHandler func(ctx context.Context, event *model.Event) error
+
+type EventBus interface {
+	Subscribe(name string, handler EventHandler)
+	Publish(ctx context.Context, event *model.Event)
+}
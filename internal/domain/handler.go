@@ -9,4 +9,20 @@ type Handler interface {
 	ProductImage() ProductImageHandler
 	ProductComment() ProductCommentHandler
 	ProductCommentLike() ProductCommentLikeHandler
+	Notification() NotificationHandler
+	UserAddress() UserAddressHandler
+	Audit() AuditHandler
+	ProductRevision() ProductRevisionHandler
+	Discount() DiscountHandler
+	Coupon() CouponHandler
+	StockMovement() StockMovementHandler
+	StockSubscription() StockSubscriptionHandler
+	Wishlist() WishlistHandler
+	ProductView() ProductViewHandler
+	ProductRelation() ProductRelationHandler
+	Brand() BrandHandler
+	Tag() TagHandler
+	Collection() CollectionHandler
+	Home() HomeHandler
+	Cache() CacheHandler
 }
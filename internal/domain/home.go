@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type HomeRepository interface {
+	GetSections(ctx context.Context) ([]model.HomeSection, error)
+	GetSectionByID(ctx context.Context, sectionID string) (*model.HomeSection, error)
+	GetBannerByID(ctx context.Context, bannerID string) (*model.HomeBanner, error)
+	CreateSection(ctx context.Context, section *entity.HomeSectionRequest) (string, error)
+	UpdateSection(ctx context.Context, sectionID string, section *entity.HomeSectionRequest) error
+	DeleteSection(ctx context.Context, sectionID string) error
+	CreateBanner(ctx context.Context, sectionID string, banner *entity.HomeBannerRequest) (string, error)
+	DeleteBanner(ctx context.Context, bannerID string) error
+}
+
+type HomeService interface {
+	GetHome(ctx context.Context) (*model.Home, error)
+	GetHomeSections(ctx context.Context) ([]model.HomeSection, error)
+	CreateHomeSection(ctx context.Context, section *entity.HomeSectionRequest) error
+	UpdateHomeSection(ctx context.Context, sectionID string, section *entity.HomeSectionRequest) error
+	DeleteHomeSection(ctx context.Context, sectionID string) error
+	CreateHomeBanner(ctx context.Context, sectionID string, banner *entity.HomeBannerRequest) error
+	DeleteHomeBanner(ctx context.Context, bannerID string) error
+	Invalidate(ctx context.Context)
+}
+
+type HomeHandler interface {
+	GetHomeHandler(w http.ResponseWriter, r *http.Request)
+	GetHomeSectionsHandler(w http.ResponseWriter, r *http.Request)
+	CreateHomeSectionHandler(w http.ResponseWriter, r *http.Request)
+	UpdateHomeSectionHandler(w http.ResponseWriter, r *http.Request)
+	DeleteHomeSectionHandler(w http.ResponseWriter, r *http.Request)
+	CreateHomeBannerHandler(w http.ResponseWriter, r *http.Request)
+	DeleteHomeBannerHandler(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type NotificationRepository interface {
+	GetAll(ctx context.Context, params *entity.NotificationQueryParamRequest) ([]model.Notification, error)
+	GetByID(ctx context.Context, notificationID string) (*model.Notification, error)
+	Create(ctx context.Context, notification *entity.NotificationCreateRequest, maxAttempts int) error
+	Claim(ctx context.Context, minPriority, limit int, lease time.Duration) ([]model.Notification, error)
+	MarkSent(ctx context.Context, notificationID string) error
+	MarkFailed(ctx context.Context, notificationID, lastError string, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, notificationID, lastError string) error
+	Requeue(ctx context.Context, notificationID string) error
+}
+
+type NotificationService interface {
+	Enqueue(ctx context.Context, notification *entity.NotificationCreateRequest) error
+	GetAllNotifications(ctx context.Context, params *entity.NotificationQueryParamRequest) ([]model.Notification, error)
+	GetNotificationByID(ctx context.Context, notificationID string) (*model.Notification, error)
+	RetryNotification(ctx context.Context, notificationID string) error
+	Run(ctx context.Context)
+}
+
+type NotificationHandler interface {
+	GetAllNotificationsHandler(w http.ResponseWriter, r *http.Request)
+	GetNotificationByIDHandler(w http.ResponseWriter, r *http.Request)
+	RetryNotificationHandler(w http.ResponseWriter, r *http.Request)
+}
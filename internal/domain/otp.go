@@ -1,6 +1,11 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+const OTPExpiration = 2 * time.Minute
 
 type OTPService interface {
 	Generate(ctx context.Context, phone string) (string, error)
@@ -3,28 +3,49 @@ package domain
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
 type ProductRepository interface {
-	GetAll(ctx context.Context) ([]model.Products, error)
+	GetAll(ctx context.Context, status, brandID string) ([]model.Products, error)
 	GetByID(ctx context.Context, productID string) (*model.Product, error)
+	GetByIDs(ctx context.Context, productIDs []string) ([]model.Products, error)
+	GetByCategoryPath(ctx context.Context, categoryPath string, limit, offset int) ([]model.Products, error)
+	GetByBrand(ctx context.Context, brandID string, limit, offset int) ([]model.Products, error)
+	GetByTag(ctx context.Context, tagID string, limit, offset int) ([]model.Products, error)
+	GetByCollection(ctx context.Context, collectionID string, limit, offset int) ([]model.Products, error)
+	GetByCollectionRule(ctx context.Context, rule *model.CollectionRule, limit, offset int) ([]model.Products, error)
+	GetNewest(ctx context.Context, limit int) ([]model.Products, error)
+	GetBestRated(ctx context.Context, limit int) ([]model.Products, error)
+	GetPopularByCategory(ctx context.Context, categoryID string, excludeIDs []string, limit int) ([]model.Products, error)
 	GetBySlug(ctx context.Context, productSlug string) (*model.Product, error)
-	Create(ctx context.Context, product *entity.ProductCreateRequest) error
-	Update(ctx context.Context, productID string, product *entity.ProductUpdateRequest) error
+	GetAllDeleted(ctx context.Context, limit, offset int) ([]model.Products, error)
+	Create(ctx context.Context, editorID string, product *entity.ProductCreateRequest) (string, error)
+	Update(ctx context.Context, productID, editorID string, product *entity.ProductUpdateRequest) error
+	UpdateStatus(ctx context.Context, productID string, status *entity.ProductStatusUpdateRequest) error
+	PublishDue(ctx context.Context) ([]string, error)
+	UnpublishDue(ctx context.Context) ([]string, error)
+	Rollback(ctx context.Context, productID, editorID string, revision int) error
+	GetPriceHistory(ctx context.Context, productID string, since time.Time) ([]model.PriceHistory, error)
 	Delete(ctx context.Context, productID string) error
+	Restore(ctx context.Context, productID string) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
 	Exists(ctx context.Context, productSlug string) (bool, error)
 }
 
 type ProductService interface {
-	GetAllProducts(ctx context.Context) ([]model.Products, error)
+	GetAllProducts(ctx context.Context, params *entity.ProductQueryParamRequest) ([]model.Products, error)
 	GetProductByID(ctx context.Context, productID string) (*model.Product, error)
 	GetProductBySlug(ctx context.Context, productSlug string) (*model.Product, error)
+	GetDeletedProducts(ctx context.Context, limit, offset int) ([]model.Products, error)
 	CreateProduct(ctx context.Context, product *entity.ProductCreateRequest) error
 	UpdateProduct(ctx context.Context, productID string, product *entity.ProductUpdateRequest) error
+	UpdateProductStatus(ctx context.Context, productID string, status *entity.ProductStatusUpdateRequest) error
 	DeleteProduct(ctx context.Context, productID string) error
+	RestoreProduct(ctx context.Context, productID string) error
 	ExistsProduct(ctx context.Context, productSlug string) (bool, error)
 }
 
@@ -34,6 +55,15 @@ type ProductHandler interface {
 	GetProductBySlugHandler(w http.ResponseWriter, r *http.Request)
 	CreateProductHandler(w http.ResponseWriter, r *http.Request)
 	UpdateProductHandler(w http.ResponseWriter, r *http.Request)
+	UpdateProductStatusHandler(w http.ResponseWriter, r *http.Request)
 	DeleteProductHandler(w http.ResponseWriter, r *http.Request)
+	GetDeletedProductsHandler(w http.ResponseWriter, r *http.Request)
+	RestoreProductHandler(w http.ResponseWriter, r *http.Request)
 	ExistsProductHandler(w http.ResponseWriter, r *http.Request)
 }
+
+// ProductSchedulerService applies publish_at and unpublish_at in the background.
+type ProductSchedulerService interface {
+	Tick(ctx context.Context) error
+	Run(ctx context.Context)
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
@@ -10,20 +11,29 @@ import (
 
 type ProductCommentRepository interface {
 	GetByID(ctx context.Context, productCommentID string) (*model.ProductComment, error)
+	GetAllByUser(ctx context.Context, userID string, limit, offset int) ([]model.ProductComment, error)
+	GetAllDeleted(ctx context.Context, limit, offset int) ([]model.ProductComment, error)
 	Create(ctx context.Context, productID, currentUserID string, comment *entity.ProductCommentCreateRequest) error
 	Update(ctx context.Context, productCommentID string, comment *entity.ProductCommentUpdateRequest) error
 	Delete(ctx context.Context, productCommentID string) error
+	Restore(ctx context.Context, productCommentID string) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
 }
 
 type ProductCommentService interface {
 	GetProductCommentByID(ctx context.Context, productCommentID string) (*model.ProductComment, error)
+	GetUserProductComments(ctx context.Context, userID string, limit, offset int) ([]model.ProductComment, error)
+	GetDeletedProductComments(ctx context.Context, limit, offset int) ([]model.ProductComment, error)
 	CreateProductComment(ctx context.Context, productID, currentUserID string, comment *entity.ProductCommentCreateRequest) error
 	UpdateProductComment(ctx context.Context, productCommentID string, comment *entity.ProductCommentUpdateRequest) error
 	DeleteProductComment(ctx context.Context, productCommentID string) error
+	RestoreProductComment(ctx context.Context, productCommentID string) error
 }
 
 type ProductCommentHandler interface {
 	CreateProductCommentHandler(w http.ResponseWriter, r *http.Request)
 	UpdateProductCommentHandler(w http.ResponseWriter, r *http.Request)
 	DeleteProductCommentHandler(w http.ResponseWriter, r *http.Request)
+	GetDeletedProductCommentsHandler(w http.ResponseWriter, r *http.Request)
+	RestoreProductCommentHandler(w http.ResponseWriter, r *http.Request)
 }
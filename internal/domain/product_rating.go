@@ -5,14 +5,17 @@ import (
 	"net/http"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
 type ProductRatingRepository interface {
+	GetAllByUser(ctx context.Context, userID string, limit, offset int) ([]model.ProductRating, error)
 	CreateOrUpdate(ctx context.Context, productID, userID string, rate *entity.ProductRatingRequest) error
 	Delete(ctx context.Context, productID, userID string) error
 }
 
 type ProductRatingService interface {
+	GetUserProductRatings(ctx context.Context, userID string, limit, offset int) ([]model.ProductRating, error)
 	CreateOrUpdateProductRating(ctx context.Context, productID, userID string, rate *entity.ProductRatingRequest) error
 	DeleteProductRating(ctx context.Context, productID, userID string) error
 }
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type ProductRelationRepository interface {
+	GetPinnedIDs(ctx context.Context, productID string) ([]string, error)
+	GetRankedIDs(ctx context.Context, productID string, limit int) ([]string, error)
+	Replace(ctx context.Context, productID string, relatedIDs []int) error
+}
+
+type ProductRelationService interface {
+	GetRelatedProducts(ctx context.Context, productID string, limit int) ([]model.Products, error)
+	SetRelatedProducts(ctx context.Context, productID string, relation *entity.ProductRelationRequest) error
+	Invalidate(ctx context.Context)
+}
+
+type ProductRelationHandler interface {
+	GetRelatedProductsHandler(w http.ResponseWriter, r *http.Request)
+	SetRelatedProductsHandler(w http.ResponseWriter, r *http.Request)
+}
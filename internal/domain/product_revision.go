@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type ProductRevisionRepository interface {
+	GetAll(ctx context.Context, productID string, limit, offset int) ([]model.ProductRevision, error)
+	GetByRevision(ctx context.Context, productID string, revision int) (*model.ProductRevision, error)
+	GetLatest(ctx context.Context, productID string) (*model.ProductRevision, error)
+}
+
+type ProductRevisionService interface {
+	GetProductRevisions(ctx context.Context, productID string, limit, offset int) ([]model.ProductRevision, error)
+	DiffProductRevisions(ctx context.Context, productID string, from, to int) (*model.ProductRevisionDiff, error)
+	RollbackProduct(ctx context.Context, productID string, revision int) error
+}
+
+type ProductRevisionHandler interface {
+	GetProductRevisionsHandler(w http.ResponseWriter, r *http.Request)
+	DiffProductRevisionsHandler(w http.ResponseWriter, r *http.Request)
+	RollbackProductHandler(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type ProductViewService interface {
+	Record(ctx context.Context, productID string)
+	GetRecentlyViewed(ctx context.Context, limit int) ([]model.Products, error)
+	GetAlsoViewed(ctx context.Context, productID string, limit int) ([]model.Products, error)
+}
+
+type ProductViewHandler interface {
+	GetRecentlyViewedHandler(w http.ResponseWriter, r *http.Request)
+	GetAlsoViewedHandler(w http.ResponseWriter, r *http.Request)
+}
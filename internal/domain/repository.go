@@ -8,4 +8,18 @@ type Repository interface {
 	ProductImage() ProductImageRepository
 	ProductComment() ProductCommentRepository
 	ProductCommentLike() ProductCommentLikeRepository
+	Notification() NotificationRepository
+	UserAddress() UserAddressRepository
+	Audit() AuditRepository
+	ProductRevision() ProductRevisionRepository
+	Discount() DiscountRepository
+	Coupon() CouponRepository
+	StockMovement() StockMovementRepository
+	StockSubscription() StockSubscriptionRepository
+	Wishlist() WishlistRepository
+	ProductRelation() ProductRelationRepository
+	Brand() BrandRepository
+	Tag() TagRepository
+	Collection() CollectionRepository
+	Home() HomeRepository
 }
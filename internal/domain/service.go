@@ -4,6 +4,7 @@ type Service interface {
 	User() UserService
 	OTP() OTPService
 	Sms() SmsService
+	Email() EmailService
 	Category() CategoryService
 	Product() ProductService
 	ProductRating() ProductRatingService
@@ -11,4 +12,26 @@ type Service interface {
 	ProductComment() ProductCommentService
 	ProductCommentLike() ProductCommentLikeService
 	S3() S3Service
+	Notification() NotificationService
+	UserNotification() UserNotificationService
+	Events() EventBus
+	UserAddress() UserAddressService
+	Session() SessionService
+	Audit() AuditService
+	Trash() TrashService
+	ProductRevision() ProductRevisionService
+	ProductScheduler() ProductSchedulerService
+	Discount() DiscountService
+	Pricing() PricingService
+	Coupon() CouponService
+	StockMovement() StockMovementService
+	StockSubscription() StockSubscriptionService
+	Wishlist() WishlistService
+	ProductView() ProductViewService
+	ProductRelation() ProductRelationService
+	Brand() BrandService
+	Tag() TagService
+	Collection() CollectionService
+	Home() HomeService
+	Cache() CacheService
 }
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type SessionService interface {
+	Version(ctx context.Context, userID string) (int, error)
+	Revoke(ctx context.Context, userID string) error
+	Refresh(ctx context.Context, userID string) error
+}
@@ -1,5 +1,8 @@
 package domain
 
+import "context"
+
 type SmsService interface {
-	Send(msg, phone string) error
+	Send(ctx context.Context, msg, phone string) error
+	SendBatch(ctx context.Context, msg string, phones []string) error
 }
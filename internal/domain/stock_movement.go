@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type StockMovementRepository interface {
+	GetAll(ctx context.Context, productID string, limit, offset int) ([]model.StockMovement, error)
+	GetLowStock(ctx context.Context, threshold, limit, offset int) ([]model.LowStockProduct, error)
+	Create(ctx context.Context, productID, actorID string, movement *entity.StockMovementRequest) (*model.StockMovement, error)
+}
+
+type StockMovementService interface {
+	GetStockMovements(ctx context.Context, productID string, limit, offset int) ([]model.StockMovement, error)
+	GetLowStockProducts(ctx context.Context, limit, offset int) ([]model.LowStockProduct, error)
+	MoveStock(ctx context.Context, productID string, movement *entity.StockMovementRequest) (*model.StockMovement, error)
+}
+
+type StockMovementHandler interface {
+	GetStockMovementsHandler(w http.ResponseWriter, r *http.Request)
+	GetLowStockProductsHandler(w http.ResponseWriter, r *http.Request)
+	MoveStockHandler(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type StockSubscriptionRepository interface {
+	Create(ctx context.Context, userID, productID string) error
+	Delete(ctx context.Context, userID, productID string) error
+	GetSubscribers(ctx context.Context, productID string, limit int) ([]model.StockSubscriber, error)
+	DeleteByIDs(ctx context.Context, subscriptionIDs []string) error
+}
+
+type StockSubscriptionService interface {
+	Subscribe(ctx context.Context, userID, productID string) error
+	Unsubscribe(ctx context.Context, userID, productID string) error
+	HandleEvent(ctx context.Context, event *model.Event) error
+}
+
+type StockSubscriptionHandler interface {
+	SubscribeHandler(w http.ResponseWriter, r *http.Request)
+	UnsubscribeHandler(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type TagRepository interface {
+	GetAll(ctx context.Context) ([]model.Tag, error)
+	GetByID(ctx context.Context, tagID string) (*model.Tag, error)
+	GetBySlug(ctx context.Context, tagSlug string) (*model.Tag, error)
+	GetProductTagIDs(ctx context.Context, productID string) ([]string, error)
+	Create(ctx context.Context, tag *entity.TagRequest) (string, error)
+	Update(ctx context.Context, tagID string, tag *entity.TagRequest) error
+	Delete(ctx context.Context, tagID string) error
+	ReplaceProductTags(ctx context.Context, productID string, tagIDs []int) error
+}
+
+type TagService interface {
+	GetAllTags(ctx context.Context) ([]model.Tag, error)
+	GetTagBySlug(ctx context.Context, tagSlug string, limit, offset int) (*model.TagDetail, error)
+	CreateTag(ctx context.Context, tag *entity.TagRequest) error
+	UpdateTag(ctx context.Context, tagID string, tag *entity.TagRequest) error
+	DeleteTag(ctx context.Context, tagID string) error
+	SetProductTags(ctx context.Context, productID string, tags *entity.ProductTagRequest) error
+}
+
+type TagHandler interface {
+	GetAllTagsHandler(w http.ResponseWriter, r *http.Request)
+	GetTagBySlugHandler(w http.ResponseWriter, r *http.Request)
+	CreateTagHandler(w http.ResponseWriter, r *http.Request)
+	UpdateTagHandler(w http.ResponseWriter, r *http.Request)
+	DeleteTagHandler(w http.ResponseWriter, r *http.Request)
+	SetProductTagsHandler(w http.ResponseWriter, r *http.Request)
+}
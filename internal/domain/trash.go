@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+type TrashService interface {
+	Purge(ctx context.Context) error
+	Run(ctx context.Context)
+}
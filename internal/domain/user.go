@@ -9,18 +9,57 @@ import (
 )
 
 type UserRepository interface {
+	GetAll(ctx context.Context, params *entity.UserQueryParamRequest) ([]model.User, error)
 	GetByID(ctx context.Context, userID string) (*model.User, error)
 	GetByPhone(ctx context.Context, phone string) (*model.User, error)
-	Create(ctx context.Context, user *entity.UserAuthRequest) error
+	GetAdminIDs(ctx context.Context) ([]string, error)
+	Create(ctx context.Context, user *entity.UserAuthRequest) (bool, error)
+	UpdateNotificationPreference(ctx context.Context, userID string, preference *entity.UserNotificationPreferenceRequest) error
+	UpdateProfile(ctx context.Context, userID string, profile *entity.UserProfileUpdateRequest) error
+	UpdateEmail(ctx context.Context, userID, email string) error
+	UpdatePhone(ctx context.Context, userID, oldPhone, newPhone string) error
+	IncrementSessionVersion(ctx context.Context, userID string) error
+	SetBanned(ctx context.Context, userID string, banned bool) error
+	SetAdmin(ctx context.Context, userID string, isAdmin bool) error
+	Delete(ctx context.Context, userID string) error
 }
 
 type UserService interface {
+	GetAllUsers(ctx context.Context, params *entity.UserQueryParamRequest) ([]model.User, error)
 	GetUserByID(ctx context.Context, userID string) (*model.User, error)
 	GetUserByPhone(ctx context.Context, phone string) (*model.User, error)
 	CreateUser(ctx context.Context, user *entity.UserAuthRequest) error
 	GenerateUserJwtToken(ctx context.Context, user *model.User) (string, error)
+	UpdateUserNotificationPreference(ctx context.Context, userID string, preference *entity.UserNotificationPreferenceRequest) error
+	UpdateUserProfile(ctx context.Context, userID string, profile *entity.UserProfileUpdateRequest) error
+	RequestUserEmailChange(ctx context.Context, userID, email string) error
+	VerifyUserEmailChange(ctx context.Context, userID, code string) error
+	RequestUserDeletion(ctx context.Context, userID string) error
+	DeleteUser(ctx context.Context, userID, code string) error
+	RequestUserPhoneChange(ctx context.Context, userID, phone string, override bool) error
+	VerifyUserPhoneChange(ctx context.Context, actorID, userID string, codes *entity.UserPhoneChangeVerifyRequest, override bool) error
+	SetUserBanned(ctx context.Context, userID string, banned bool) error
+	SetUserAdmin(ctx context.Context, userID string, isAdmin bool) error
 }
 
 type UserHandler interface {
 	UserProfileHandler(w http.ResponseWriter, r *http.Request)
+	UpdateNotificationPreferenceHandler(w http.ResponseWriter, r *http.Request)
+	UserNotificationsHandler(w http.ResponseWriter, r *http.Request)
+	UpdateUserProfileHandler(w http.ResponseWriter, r *http.Request)
+	RequestEmailChangeHandler(w http.ResponseWriter, r *http.Request)
+	VerifyEmailChangeHandler(w http.ResponseWriter, r *http.Request)
+	RequestUserDeletionHandler(w http.ResponseWriter, r *http.Request)
+	DeleteUserHandler(w http.ResponseWriter, r *http.Request)
+	RequestPhoneChangeHandler(w http.ResponseWriter, r *http.Request)
+	VerifyPhoneChangeHandler(w http.ResponseWriter, r *http.Request)
+	OverridePhoneChangeHandler(w http.ResponseWriter, r *http.Request)
+	VerifyOverridePhoneChangeHandler(w http.ResponseWriter, r *http.Request)
+	GetAllUsersHandler(w http.ResponseWriter, r *http.Request)
+	GetUserByIDHandler(w http.ResponseWriter, r *http.Request)
+	GetUserCommentsHandler(w http.ResponseWriter, r *http.Request)
+	GetUserRatingsHandler(w http.ResponseWriter, r *http.Request)
+	BanUserHandler(w http.ResponseWriter, r *http.Request)
+	UnbanUserHandler(w http.ResponseWriter, r *http.Request)
+	UpdateUserRoleHandler(w http.ResponseWriter, r *http.Request)
 }
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type UserAddressRepository interface {
+	GetAll(ctx context.Context, userID string) ([]model.UserAddress, error)
+	Create(ctx context.Context, userID string, address *entity.UserAddressRequest) error
+	Update(ctx context.Context, addressID, userID string, address *entity.UserAddressRequest) error
+	Delete(ctx context.Context, addressID, userID string) error
+}
+
+type UserAddressService interface {
+	GetAllUserAddresses(ctx context.Context, userID string) ([]model.UserAddress, error)
+	CreateUserAddress(ctx context.Context, userID string, address *entity.UserAddressRequest) error
+	UpdateUserAddress(ctx context.Context, addressID, userID string, address *entity.UserAddressRequest) error
+	DeleteUserAddress(ctx context.Context, addressID, userID string) error
+}
+
+type UserAddressHandler interface {
+	GetAllUserAddressesHandler(w http.ResponseWriter, r *http.Request)
+	CreateUserAddressHandler(w http.ResponseWriter, r *http.Request)
+	UpdateUserAddressHandler(w http.ResponseWriter, r *http.Request)
+	DeleteUserAddressHandler(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type UserNotificationService interface {
+	HandleEvent(ctx context.Context, event *model.Event) error
+	GetUserNotifications(ctx context.Context, userID string, limit, offset int) ([]model.Notification, error)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type WishlistRepository interface {
+	GetAll(ctx context.Context, userID string, limit, offset int) ([]model.Products, error)
+	GetShared(ctx context.Context, token string, limit, offset int) ([]model.Products, error)
+	GetWishlistedIDs(ctx context.Context, userID string, productIDs []string) ([]string, error)
+	GetUserIDs(ctx context.Context, productID string) ([]string, error)
+	Create(ctx context.Context, userID, productID string) error
+	Delete(ctx context.Context, userID, productID string) error
+	CreateShare(ctx context.Context, userID, token string) (*model.WishlistShare, error)
+	DeleteShare(ctx context.Context, userID string) error
+}
+
+type WishlistService interface {
+	GetWishlist(ctx context.Context, userID string, limit, offset int) ([]model.Products, error)
+	GetSharedWishlist(ctx context.Context, token string, limit, offset int) ([]model.Products, error)
+	AddToWishlist(ctx context.Context, userID, productID string) error
+	RemoveFromWishlist(ctx context.Context, userID, productID string) error
+	ShareWishlist(ctx context.Context, userID string) (*model.WishlistShare, error)
+	UnshareWishlist(ctx context.Context, userID string) error
+	MarkProducts(ctx context.Context, products []model.Products)
+	MarkProduct(ctx context.Context, product *model.Product)
+	NotifyPriceDrop(ctx context.Context, product *model.Product, oldPrice money.Money)
+}
+
+type WishlistHandler interface {
+	GetWishlistHandler(w http.ResponseWriter, r *http.Request)
+	GetSharedWishlistHandler(w http.ResponseWriter, r *http.Request)
+	AddToWishlistHandler(w http.ResponseWriter, r *http.Request)
+	RemoveFromWishlistHandler(w http.ResponseWriter, r *http.Request)
+	ShareWishlistHandler(w http.ResponseWriter, r *http.Request)
+	UnshareWishlistHandler(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,23 @@
+package entity
+
+type AuditLogCreateRequest struct {
+	ActorID    *string
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     any
+	After      any
+	IP         *string
+	RequestID  *string
+}
+
+type AuditLogQueryParamRequest struct {
+	ActorID    string `json:"actor_id" validate:"omitempty,numeric" example:"1"`
+	Action     string `json:"action" validate:"omitempty,max=100" example:"product.updated"`
+	EntityType string `json:"entity_type" validate:"omitempty,max=50" example:"product"`
+	EntityID   string `json:"entity_id" validate:"omitempty,max=50" example:"1"`
+	From       string `json:"from" validate:"omitempty,datetime=2006-01-02" example:"2025-01-01"`
+	To         string `json:"to" validate:"omitempty,datetime=2006-01-02" example:"2025-12-31"`
+	Limit      int    `json:"limit" validate:"min=1,max=100" example:"20"`
+	Offset     int    `json:"offset" validate:"min=0" example:"0"`
+}
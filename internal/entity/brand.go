@@ -0,0 +1,12 @@
+package entity
+
+// BrandRequest is sent as json, or as the data field of a multipart form whose logo field holds the logo image.
+// Logo is the uploaded key and is set by the handler. On update the logo is kept unless a new one is uploaded or
+// RemoveLogo is set.
+type BrandRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=100" example:"Activision"`
+	Slug        string  `json:"slug" validate:"required,min=1,max=100" example:"activision"`
+	Description string  `json:"description" validate:"max=5000" example:"Publisher of the Call of Duty series"`
+	RemoveLogo  bool    `json:"remove_logo" example:"false"`
+	Logo        *string `json:"-"`
+}
@@ -1,15 +1,34 @@
 package entity
 
+// CategoryCreateRequest is sent as json, or as the data field of a multipart form whose banner field holds the
+// banner image. BannerImage is the uploaded key and is set by the handler.
 type CategoryCreateRequest struct {
-	Name     string `json:"name" validate:"required,min=1,max=100" example:"Electronics"`
-	Slug     string `json:"slug" validate:"required,min=1,max=100" example:"electronics"`
-	ParentID *int   `json:"parent_id,omitempty" validate:"omitempty,min=1,numeric" example:"1"`
+	Name            string  `json:"name" validate:"required,min=1,max=100" example:"Electronics"`
+	Slug            string  `json:"slug" validate:"required,min=1,max=100" example:"electronics"`
+	ParentID        *int    `json:"parent_id,omitempty" validate:"omitempty,min=1,numeric" example:"1"`
+	Position        int     `json:"position" validate:"min=0" example:"0"`
+	Description     string  `json:"description" validate:"max=5000" example:"Everything with a plug"`
+	SeoTitle        string  `json:"seo_title" validate:"max=70" example:"Buy electronics online"`
+	MetaDescription string  `json:"meta_description" validate:"max=160" example:"Phones, laptops and more with fast delivery"`
+	BannerImage     *string `json:"-"`
 }
 
+// CategoryUpdateRequest is sent like CategoryCreateRequest. The banner is kept unless a new one is uploaded or
+// RemoveBanner is set.
 type CategoryUpdateRequest struct {
-	Name     string `json:"name" validate:"required,min=1,max=100" example:"Electronics"`
-	Slug     string `json:"slug" validate:"required,min=1,max=100" example:"electronics"`
-	ParentID *int   `json:"parent_id,omitempty" validate:"omitempty,min=1,numeric" example:"1"`
+	Name            string  `json:"name" validate:"required,min=1,max=100" example:"Electronics"`
+	Slug            string  `json:"slug" validate:"required,min=1,max=100" example:"electronics"`
+	ParentID        *int    `json:"parent_id,omitempty" validate:"omitempty,min=1,numeric" example:"1"`
+	Position        int     `json:"position" validate:"min=0" example:"0"`
+	Description     string  `json:"description" validate:"max=5000" example:"Everything with a plug"`
+	SeoTitle        string  `json:"seo_title" validate:"max=70" example:"Buy electronics online"`
+	MetaDescription string  `json:"meta_description" validate:"max=160" example:"Phones, laptops and more with fast delivery"`
+	RemoveBanner    bool    `json:"remove_banner" example:"false"`
+	BannerImage     *string `json:"-"`
+}
+
+type CategoryMergeRequest struct {
+	TargetID int `json:"target_id" validate:"required,min=1" example:"2"`
 }
 
 type CategoryQueryParamRequest struct {
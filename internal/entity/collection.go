@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/arshamroshannejad/squidshop-backend/internal/money"
+
+// CollectionRequest creates or updates a collection. Rule is required for and only allowed with the rule mode.
+type CollectionRequest struct {
+	Name        string                 `json:"name" validate:"required,min=1,max=100" example:"Black Friday picks"`
+	Slug        string                 `json:"slug" validate:"required,min=1,max=100" example:"black-friday-picks"`
+	Description string                 `json:"description" validate:"max=5000" example:"Our favourite deals of the week"`
+	Mode        string                 `json:"mode" validate:"required,oneof=manual rule" example:"manual"`
+	Rule        *CollectionRuleRequest `json:"rule,omitempty"`
+}
+
+type CollectionRuleRequest struct {
+	CategoryID *int         `json:"category_id,omitempty" validate:"omitempty,min=1" example:"1"`
+	BrandID    *int         `json:"brand_id,omitempty" validate:"omitempty,min=1" example:"1"`
+	TagID      *int         `json:"tag_id,omitempty" validate:"omitempty,min=1" example:"1"`
+	MinPrice   *money.Money `json:"min_price,omitempty" validate:"omitempty,min=0" example:"100000"`
+	MaxPrice   *money.Money `json:"max_price,omitempty" validate:"omitempty,min=0" example:"5000000"`
+}
+
+type CollectionProductRequest struct {
+	ProductIDs []int `json:"product_ids" validate:"max=200,unique,dive,min=1" example:"2,5,9"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type CouponRequest struct {
+	Code           string       `json:"code" validate:"required,min=3,max=32,alphanum" example:"WELCOME10"`
+	Kind           string       `json:"kind" validate:"required,oneof=percentage fixed" example:"percentage"`
+	Percent        *float64     `json:"percent" validate:"omitempty,gt=0,lte=100" example:"10"`
+	Amount         *money.Money `json:"amount" validate:"omitempty,min=1" example:"500000"`
+	MaxDiscount    *money.Money `json:"max_discount" validate:"omitempty,min=1" example:"500000"`
+	MinBasket      money.Money  `json:"min_basket" validate:"min=0" example:"2000000"`
+	Scope          string       `json:"scope" validate:"required,oneof=product category catalog" example:"catalog"`
+	ProductID      *int         `json:"product_id" validate:"omitempty,min=1" example:"1"`
+	CategoryID     *int         `json:"category_id" validate:"omitempty,min=1" example:"1"`
+	UsageLimit     *int         `json:"usage_limit" validate:"omitempty,min=1" example:"1000"`
+	PerUserLimit   *int         `json:"per_user_limit" validate:"omitempty,min=1" example:"1"`
+	FirstOrderOnly bool         `json:"first_order_only" example:"true"`
+	StartsAt       time.Time    `json:"starts_at" validate:"required" example:"2025-03-15T00:00:00Z"`
+	EndsAt         *time.Time   `json:"ends_at" example:"2025-04-02T00:00:00Z"`
+}
+
+type CouponBasketItemRequest struct {
+	ProductID int `json:"product_id" validate:"required,min=1" example:"1"`
+	Quantity  int `json:"quantity" validate:"required,min=1,max=100" example:"2"`
+}
+
+type CouponBasketRequest struct {
+	Code  string                    `json:"code" validate:"required,min=3,max=32" example:"WELCOME10"`
+	Items []CouponBasketItemRequest `json:"items" validate:"required,min=1,max=50,unique=ProductID,dive"`
+}
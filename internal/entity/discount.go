@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type DiscountRequest struct {
+	Name       string       `json:"name" validate:"required,min=1,max=100" example:"Nowruz sale"`
+	Kind       string       `json:"kind" validate:"required,oneof=percentage fixed price" example:"percentage"`
+	Percent    *float64     `json:"percent" validate:"omitempty,gt=0,lte=100" example:"15"`
+	Amount     *money.Money `json:"amount" validate:"omitempty,min=1" example:"150000"`
+	Scope      string       `json:"scope" validate:"required,oneof=product category catalog" example:"category"`
+	ProductID  *int         `json:"product_id" validate:"omitempty,min=1" example:"1"`
+	CategoryID *int         `json:"category_id" validate:"omitempty,min=1" example:"1"`
+	StartsAt   time.Time    `json:"starts_at" validate:"required" example:"2025-03-15T00:00:00Z"`
+	EndsAt     *time.Time   `json:"ends_at" example:"2025-04-02T00:00:00Z"`
+}
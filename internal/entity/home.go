@@ -0,0 +1,22 @@
+package entity
+
+// HomeSectionRequest creates or updates a homepage section. CollectionID belongs to collection sections and
+// CategoryIDs to category tiles. Limit caps the products of product sections, zero keeps the default.
+type HomeSectionRequest struct {
+	Kind         string `json:"kind" validate:"required,oneof=banner collection newest best_rated categories" example:"newest"`
+	Title        string `json:"title" validate:"max=100" example:"New arrivals"`
+	Position     int    `json:"position" validate:"min=0" example:"0"`
+	Hidden       bool   `json:"hidden" example:"false"`
+	CollectionID *int   `json:"collection_id,omitempty" validate:"omitempty,min=1" example:"1"`
+	CategoryIDs  []int  `json:"category_ids,omitempty" validate:"max=24,unique,dive,min=1" example:"1,4"`
+	Limit        int    `json:"limit" validate:"min=0,max=24" example:"8"`
+}
+
+// HomeBannerRequest is the data field of a multipart form whose image field holds the banner image. Image is the
+// uploaded key and is set by the handler.
+type HomeBannerRequest struct {
+	Link     string  `json:"link" validate:"max=500" example:"/collection/black-friday-picks"`
+	Alt      string  `json:"alt" validate:"max=255" example:"Black Friday sale"`
+	Position int     `json:"position" validate:"min=0" example:"0"`
+	Image    *string `json:"-"`
+}
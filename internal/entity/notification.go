@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type NotificationCreateRequest struct {
+	UserID    *string
+	Event     *string
+	Channel   string
+	Recipient string
+	Subject   *string
+	Message   string
+	Priority  int
+	ExpiresAt *time.Time
+	Sensitive bool
+}
+
+type NotificationQueryParamRequest struct {
+	Status    string `json:"status" validate:"omitempty,oneof=pending processing sent dead" example:"dead"`
+	Channel   string `json:"channel" validate:"omitempty,max=20" example:"sms"`
+	Recipient string `json:"recipient" validate:"omitempty,max=255" example:"+989029266635"`
+	UserID    string `json:"user_id" validate:"omitempty,numeric" example:"1"`
+	Event     string `json:"event" validate:"omitempty,max=50" example:"account_created"`
+	Limit     int    `json:"limit" validate:"min=1,max=100" example:"20"`
+	Offset    int    `json:"offset" validate:"min=0" example:"0"`
+}
@@ -1,21 +1,51 @@
 package entity
 
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
 type ProductCreateRequest struct {
-	Name             string  `json:"name" validate:"required,min=1,max=255" example:"call of duty black ops 4"`
-	Slug             string  `json:"slug" validate:"required,min=1,max=255" example:"call-of-duty-black-ops-4"`
-	Description      string  `json:"description" validate:"required,min=1" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
-	ShortDescription string  `json:"short_description" validate:"required,min=1,max=255" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
-	Price            float64 `json:"price" validate:"required,min=1" example:"23400.23"`
-	Quantity         int     `json:"quantity" validate:"required,numeric,min=1" example:"10"`
-	CategoryID       int     `json:"category_id" validate:"required,numeric,min=1" example:"1"`
+	Name             string      `json:"name" validate:"required,min=1,max=255" example:"call of duty black ops 4"`
+	Slug             string      `json:"slug" validate:"required,min=1,max=255" example:"call-of-duty-black-ops-4"`
+	Description      string      `json:"description" validate:"required,min=1" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
+	ShortDescription string      `json:"short_description" validate:"required,min=1,max=255" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
+	Price            money.Money `json:"price" validate:"required,min=1" example:"234000"`
+	Quantity         int         `json:"quantity" validate:"required,numeric,min=1" example:"10"`
+	CategoryID       int         `json:"category_id" validate:"required,numeric,min=1" example:"1"`
+	BrandID          *int        `json:"brand_id,omitempty" validate:"omitempty,min=1" example:"1"`
+	Status           string      `json:"status" validate:"omitempty,oneof=draft published archived" example:"draft"`
+	PublishAt        *time.Time  `json:"publish_at" example:"2025-09-12T00:12:12Z"`
+	UnpublishAt      *time.Time  `json:"unpublish_at" example:"2025-10-12T00:12:12Z"`
 }
 
 type ProductUpdateRequest struct {
-	Name             string  `json:"name" validate:"required,min=1,max=255" example:"call of duty black ops 4"`
-	Slug             string  `json:"slug" validate:"required,min=1,max=255" example:"call-of-duty-black-ops-4"`
-	Description      string  `json:"description" validate:"required,min=1" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
-	ShortDescription string  `json:"short_description" validate:"required,min=1,max=255" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
-	Price            float64 `json:"price" validate:"required,min=1" example:"23400.23"`
-	Quantity         int     `json:"quantity" validate:"required,numeric,min=1" example:"10"`
-	CategoryID       int     `json:"category_id" validate:"required,numeric,min=1" example:"1"`
+	Name             string      `json:"name" validate:"required,min=1,max=255" example:"call of duty black ops 4"`
+	Slug             string      `json:"slug" validate:"required,min=1,max=255" example:"call-of-duty-black-ops-4"`
+	Description      string      `json:"description" validate:"required,min=1" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
+	ShortDescription string      `json:"short_description" validate:"required,min=1,max=255" example:"lorem ipsum dolor sit amet, consectetur adipiscing elit"`
+	Price            money.Money `json:"price" validate:"required,min=1" example:"234000"`
+	CategoryID       int         `json:"category_id" validate:"required,numeric,min=1" example:"1"`
+	BrandID          *int        `json:"brand_id,omitempty" validate:"omitempty,min=1" example:"1"`
+}
+
+type ProductRevisionDiffQueryParamRequest struct {
+	From int `validate:"required,min=1" example:"1"`
+	To   int `validate:"omitempty,min=1" example:"3"`
+}
+
+type ProductRollbackRequest struct {
+	Revision int `json:"revision" validate:"required,min=1" example:"2"`
+}
+
+type ProductStatusUpdateRequest struct {
+	Status      string     `json:"status" validate:"required,oneof=draft published archived" example:"draft"`
+	PublishAt   *time.Time `json:"publish_at" example:"2025-09-12T00:12:12Z"`
+	UnpublishAt *time.Time `json:"unpublish_at" example:"2025-10-12T00:12:12Z"`
+}
+
+type ProductQueryParamRequest struct {
+	Status  string `validate:"omitempty,oneof=draft published archived" example:"draft"`
+	BrandID string `validate:"omitempty,numeric" example:"1"`
 }
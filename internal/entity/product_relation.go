@@ -0,0 +1,5 @@
+package entity
+
+type ProductRelationRequest struct {
+	ProductIDs []int `json:"product_ids" validate:"max=20,unique,dive,min=1" example:"2,5,9"`
+}
@@ -0,0 +1,7 @@
+package entity
+
+type StockMovementRequest struct {
+	Kind   string `json:"kind" validate:"required,oneof=restock sale return adjustment" example:"restock"`
+	Delta  int    `json:"delta" validate:"required" example:"20"`
+	Reason string `json:"reason" validate:"max=255" example:"supplier delivery"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+type TagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100" example:"Multiplayer"`
+	Slug string `json:"slug" validate:"required,min=1,max=100" example:"multiplayer"`
+}
+
+type ProductTagRequest struct {
+	TagIDs []int `json:"tag_ids" validate:"max=20,unique,dive,min=1" example:"1,4"`
+}
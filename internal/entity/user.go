@@ -8,3 +8,44 @@ type UserVerifyAuthRequest struct {
 	Phone string `json:"phone" validate:"required,irphone" example:"+989029266635"`
 	Code  string `json:"code" validate:"required,len=6,numeric" example:"123456"`
 }
+
+type UserNotificationPreferenceRequest struct {
+	NotifySms   *bool `json:"notify_sms" validate:"required" example:"true"`
+	NotifyEmail *bool `json:"notify_email" validate:"required" example:"false"`
+}
+
+type UserProfileUpdateRequest struct {
+	FirstName  *string `json:"first_name" validate:"omitempty,min=1,max=100" example:"Arsham"`
+	LastName   *string `json:"last_name" validate:"omitempty,min=1,max=100" example:"Roshannejad"`
+	Birthday   *string `json:"birthday" validate:"omitempty,datetime=2006-01-02" example:"2001-03-21"`
+	NationalID *string `json:"national_id" validate:"omitempty,irnationalid" example:"0499370899"`
+}
+
+type UserEmailChangeRequest struct {
+	Email string `json:"email" validate:"required,email,max=255" example:"user@squidshop.ir"`
+}
+
+type UserVerifyCodeRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric" example:"123456"`
+}
+
+type UserPhoneChangeRequest struct {
+	Phone string `json:"phone" validate:"required,min=1,max=13,irphone" example:"+989029266635"`
+}
+
+type UserPhoneChangeVerifyRequest struct {
+	NewCode string `json:"new_code" validate:"required,len=6,numeric" example:"123456"`
+	OldCode string `json:"old_code" validate:"omitempty,len=6,numeric" example:"654321"`
+}
+
+type UserQueryParamRequest struct {
+	Phone       string `json:"phone" validate:"omitempty,phoneprefix" example:"+98902"`
+	CreatedFrom string `json:"created_from" validate:"omitempty,datetime=2006-01-02" example:"2025-01-01"`
+	CreatedTo   string `json:"created_to" validate:"omitempty,datetime=2006-01-02" example:"2025-12-31"`
+	Limit       int    `json:"limit" validate:"min=1,max=100" example:"20"`
+	Offset      int    `json:"offset" validate:"min=0" example:"0"`
+}
+
+type UserRoleUpdateRequest struct {
+	IsAdmin *bool `json:"is_admin" validate:"required" example:"true"`
+}
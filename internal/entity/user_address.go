@@ -0,0 +1,12 @@
+package entity
+
+type UserAddressRequest struct {
+	Title         string `json:"title" validate:"required,min=1,max=100" example:"home"`
+	ReceiverName  string `json:"receiver_name" validate:"required,min=1,max=200" example:"Arsham Roshannejad"`
+	ReceiverPhone string `json:"receiver_phone" validate:"required,irphone" example:"+989029266635"`
+	Province      string `json:"province" validate:"required,min=1,max=100" example:"Tehran"`
+	City          string `json:"city" validate:"required,min=1,max=100" example:"Tehran"`
+	PostalCode    string `json:"postal_code" validate:"required,irpostalcode" example:"1439951314"`
+	Address       string `json:"address" validate:"required,min=1,max=1000" example:"No. 12, Azadi St."`
+	IsDefault     bool   `json:"is_default" example:"true"`
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type auditHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewAuditHandler(service domain.Service, validator *validator.Validate) domain.AuditHandler {
+	return &auditHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllAuditLogsHandler godoc
+//
+//	@Summary		get audit logs endpoint
+//	@Description	list recorded admin mutations, newest first. before and after only hold the fields that changed
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Audit
+//	@Param			actor_id	query	string	false	"user id of the actor"
+//	@Param			action		query	string	false	"action name"	example(product.updated)
+//	@Param			entity_type	query	string	false	"entity type"	example(product)
+//	@Param			entity_id	query	string	false	"entity id"
+//	@Param			from		query	string	false	"recorded on or after (YYYY-MM-DD)"
+//	@Param			to			query	string	false	"recorded on or before (YYYY-MM-DD)"
+//	@Param			limit		query	int		false	"page size"		default(20)
+//	@Param			offset		query	int		false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.AuditLog
+//	@Failure		400
+//	@Failure		500
+//	@Router			/audit [get]
+func (h *auditHandlerImpl) GetAllAuditLogsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var reqParam entity.AuditLogQueryParamRequest
+	reqParam.ActorID = query.Get("actor_id")
+	reqParam.Action = query.Get("action")
+	reqParam.EntityType = query.Get("entity_type")
+	reqParam.EntityID = query.Get("entity_id")
+	reqParam.From = query.Get("from")
+	reqParam.To = query.Get("to")
+	limit, err := helper.ParseQueryInt(query, "limit", 20)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	offset, err := helper.ParseQueryInt(query, "offset", 0)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	reqParam.Limit = limit
+	reqParam.Offset = offset
+	if err := h.validator.Struct(reqParam); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	auditLogs, err := h.service.Audit().GetAllAuditLogs(r.Context(), &reqParam)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(auditLogs)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -60,8 +62,16 @@ func (u *authHandlerImpl) AuthUserHandler(w http.ResponseWriter, r *http.Request
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	smsMsg := fmt.Sprintf("کد احراز هویت شما : %s\nفروشگاه اینترنتی اسکویید شاپ", otpCode)
-	if err := u.service.Sms().Send(smsMsg, reqBody.Phone); err != nil {
+	expiresAt := time.Now().Add(domain.OTPExpiration)
+	notification := entity.NotificationCreateRequest{
+		Channel:   model.NotificationChannelSms,
+		Recipient: reqBody.Phone,
+		Message:   fmt.Sprintf("کد احراز هویت شما : %s\nفروشگاه اینترنتی اسکویید شاپ", otpCode),
+		Priority:  model.NotificationPriorityHigh,
+		ExpiresAt: &expiresAt,
+		Sensitive: true,
+	}
+	if err := u.service.Notification().Enqueue(r.Context(), &notification); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -78,6 +88,8 @@ func (u *authHandlerImpl) AuthUserHandler(w http.ResponseWriter, r *http.Request
 //	@Param			request	body	entity.UserVerifyAuthRequest	true	"phone and code for register or login"
 //	@Success		200
 //	@Failure		400
+//	@Failure		401
+//	@Failure		403
 //	@Failure		500
 //	@Router			/auth/verify [post]
 func (u *authHandlerImpl) VerifyAuthUserHandler(w http.ResponseWriter, r *http.Request) {
@@ -112,6 +124,12 @@ func (u *authHandlerImpl) VerifyAuthUserHandler(w http.ResponseWriter, r *http.R
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	if user.IsBanned {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": "user is banned"}`))
+		return
+	}
 	token, err := u.service.User().GenerateUserJwtToken(r.Context(), user)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type brandHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewBrandHandler(service domain.Service, validator *validator.Validate) domain.BrandHandler {
+	return &brandHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllBrandsHandler godoc
+//
+//	@Summary		get brands endpoint
+//	@Description	get all brands ordered by name
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Brand
+//	@Success		200	{array}	model.Brand
+//	@Failure		500
+//	@Router			/brand [get]
+func (h *brandHandlerImpl) GetAllBrandsHandler(w http.ResponseWriter, r *http.Request) {
+	brands, err := h.service.Brand().GetAllBrands(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(brands)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetBrandBySlugHandler godoc
+//
+//	@Summary		get brand by slug endpoint
+//	@Description	get a brand with a page of its published products
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Brand
+//	@Param			slug	path		string	true	"brand slug"
+//	@Param			limit	query		int		false	"product page size"		default(20)
+//	@Param			offset	query		int		false	"product page offset"	default(0)
+//	@Success		200		{object}	model.BrandDetail
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/brand/{slug} [get]
+func (h *brandHandlerImpl) GetBrandBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	brand, err := h.service.Brand().GetBrandBySlug(r.Context(), r.PathValue("slug"), limit, offset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(brand)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// CreateBrandHandler godoc
+//
+//	@Summary		create brand endpoint
+//	@Description	create new brand. send multipart/form-data with the json in a data field to upload a logo in a logo field
+//	@Accept			json,mpfd
+//	@Produce		json
+//	@Tags			Brand
+//	@Param			request	body	entity.BrandRequest	true	"brand data for create"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		409
+//	@Failure		500
+//	@Router			/brand [post]
+func (h *brandHandlerImpl) CreateBrandHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.BrandRequest
+	if !h.decodeBrandRequest(w, r, &reqBody) {
+		return
+	}
+	if err := h.service.Brand().CreateBrand(r.Context(), &reqBody); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another brand"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UpdateBrandHandler godoc
+//
+//	@Summary		update brand endpoint
+//	@Description	update brand by id. send multipart/form-data with the json in a data field to replace the logo with a logo field
+//	@Accept			json,mpfd
+//	@Produce		json
+//	@Tags			Brand
+//	@Param			id		path	string				true	"brand id"
+//	@Param			request	body	entity.BrandRequest	true	"brand data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/brand/{id} [put]
+func (h *brandHandlerImpl) UpdateBrandHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.BrandRequest
+	if !h.decodeBrandRequest(w, r, &reqBody) {
+		return
+	}
+	if err := h.service.Brand().UpdateBrand(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another brand"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteBrandHandler godoc
+//
+//	@Summary		delete brand endpoint
+//	@Description	delete brand by id. its products are kept without a brand
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Brand
+//	@Param			id	path	string	true	"brand id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/brand/{id} [delete]
+func (h *brandHandlerImpl) DeleteBrandHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Brand().DeleteBrand(r.Context(), r.PathValue("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeBrandRequest decodes and validates the body and uploads the logo when one was sent. It writes the error
+// response itself and reports whether the handler may go on.
+func (h *brandHandlerImpl) decodeBrandRequest(w http.ResponseWriter, r *http.Request, reqBody *entity.BrandRequest) bool {
+	logo, err := decodeFormRequest(r, reqBody, "logo")
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return false
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return false
+	}
+	if logo != nil {
+		keys, err := h.service.S3().UploadFiles(r.Context(), []*multipart.FileHeader{logo}, "brands")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return false
+		}
+		reqBody.Logo = &keys[0]
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type cacheHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewCacheHandler(service domain.Service, validator *validator.Validate) domain.CacheHandler {
+	return &cacheHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetCacheStatsHandler godoc
+//
+//	@Summary		get cache stats endpoint
+//	@Description	get the hits, misses and shared loads of the catalog cache per key namespace. counters are kept per instance and reset on restart
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Cache
+//	@Security		Bearer
+//	@Success		200	{array}	model.CacheStats
+//	@Failure		500
+//	@Router			/cache/stats [get]
+func (h *cacheHandlerImpl) GetCacheStatsHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := json.Marshal(h.service.Cache().Stats())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
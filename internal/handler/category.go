@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"mime/multipart"
 	"net/http"
+	"strings"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
-	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -49,22 +53,117 @@ func (h *categoryHandlerImpl) GetAllCategoriesHandler(w http.ResponseWriter, r *
 	w.Write(resp)
 }
 
+// GetCategoryBySlugHandler godoc
+//
+//	@Summary		get category by slug endpoint
+//	@Description	get a category with its seo fields, direct sub categories and a page of the published products of it and its descendants
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Category
+//	@Param			slug	path		string	true	"category slug"
+//	@Param			limit	query		int		false	"product page size"		default(20)
+//	@Param			offset	query		int		false	"product page offset"	default(0)
+//	@Success		200		{object}	model.CategoryDetail
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/category/{slug} [get]
+func (h *categoryHandlerImpl) GetCategoryBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	category, err := h.service.Category().GetCategoryBySlug(r.Context(), r.PathValue("slug"), limit, offset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(category)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetCategoryBreadcrumbHandler godoc
+//
+//	@Summary		category breadcrumb endpoint
+//	@Description	list the categories from the top level down to a category
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Category
+//	@Param			id	path	string	true	"category id"
+//	@Success		200	{array}	model.Breadcrumb
+//	@Failure		404
+//	@Failure		500
+//	@Router			/category/breadcrumb/{id} [get]
+func (h *categoryHandlerImpl) GetCategoryBreadcrumbHandler(w http.ResponseWriter, r *http.Request) {
+	breadcrumb, err := h.service.Category().GetCategoryBreadcrumb(r.Context(), r.PathValue("id"))
+	h.writeBreadcrumb(w, breadcrumb, err)
+}
+
+// GetProductBreadcrumbHandler godoc
+//
+//	@Summary		product breadcrumb endpoint
+//	@Description	list the categories from the top level down to a product, followed by the product itself
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id	path	string	true	"product id"
+//	@Success		200	{array}	model.Breadcrumb
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/breadcrumb/{id} [get]
+func (h *categoryHandlerImpl) GetProductBreadcrumbHandler(w http.ResponseWriter, r *http.Request) {
+	breadcrumb, err := h.service.Category().GetProductBreadcrumb(r.Context(), r.PathValue("id"))
+	h.writeBreadcrumb(w, breadcrumb, err)
+}
+
+func (h *categoryHandlerImpl) writeBreadcrumb(w http.ResponseWriter, breadcrumb []model.Breadcrumb, err error) {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(breadcrumb)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
 // CreateCategoryHandler godoc
 //
 //	@Summary		create category endpoint
-//	@Description	create new category
-//	@Accept			json
+//	@Description	create new category. send multipart/form-data with the json in a data field to upload a banner image in a banner field
+//	@Accept			json,mpfd
 //	@Produce		json
 //	@Tags			Category
 //	@Param			request	body	entity.CategoryCreateRequest	true	"category data for create"
 //	@Security		Bearer
 //	@Success		201
 //	@Failure		400
+//	@Failure		404
+//	@Failure		409
 //	@Failure		500
 //	@Router			/category [post]
 func (h *categoryHandlerImpl) CreateCategoryHandler(w http.ResponseWriter, r *http.Request) {
 	var reqBody entity.CategoryCreateRequest
-	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+	banner, err := decodeFormRequest(r, &reqBody, "banner")
+	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		resp, _ := json.Marshal(helper.M{"error": err.Error()})
@@ -78,8 +177,27 @@ func (h *categoryHandlerImpl) CreateCategoryHandler(w http.ResponseWriter, r *ht
 		w.Write(resp)
 		return
 	}
+	if banner != nil {
+		keys, err := h.service.S3().UploadFiles(r.Context(), []*multipart.FileHeader{banner}, "categories")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		reqBody.BannerImage = &keys[0]
+	}
 	if err := h.service.Category().CreateCategory(r.Context(), &reqBody); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "parent category not found"}`))
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another category"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -88,8 +206,8 @@ func (h *categoryHandlerImpl) CreateCategoryHandler(w http.ResponseWriter, r *ht
 // UpdateCategoryHandler godoc
 //
 //	@Summary		update category endpoint
-//	@Description	update category by id
-//	@Accept			json
+//	@Description	update category by id. changing parent_id moves the whole subtree, a category cannot move under its own descendants. send multipart/form-data with the json in a data field to replace the banner image with a banner field
+//	@Accept			json,mpfd
 //	@Produce		json
 //	@Tags			Category
 //	@Param			id		path	string							true	"category id"
@@ -97,12 +215,15 @@ func (h *categoryHandlerImpl) CreateCategoryHandler(w http.ResponseWriter, r *ht
 //	@Security		Bearer
 //	@Success		200
 //	@Failure		400
+//	@Failure		404
+//	@Failure		409
 //	@Failure		500
 //	@Router			/category/{id} [put]
 func (h *categoryHandlerImpl) UpdateCategoryHandler(w http.ResponseWriter, r *http.Request) {
 	categoryID := r.PathValue("id")
 	var reqBody entity.CategoryUpdateRequest
-	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+	banner, err := decodeFormRequest(r, &reqBody, "banner")
+	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		resp, _ := json.Marshal(helper.M{"error": err.Error()})
@@ -116,8 +237,31 @@ func (h *categoryHandlerImpl) UpdateCategoryHandler(w http.ResponseWriter, r *ht
 		w.Write(resp)
 		return
 	}
+	if banner != nil {
+		keys, err := h.service.S3().UploadFiles(r.Context(), []*multipart.FileHeader{banner}, "categories")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		reqBody.BannerImage = &keys[0]
+	}
 	if err := h.service.Category().UpdateCategory(r.Context(), categoryID, &reqBody); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "category or parent category not found"}`))
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another category"}`))
+		case errors.Is(err, domain.ErrCategoryCycle):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "category cannot be moved under itself or its descendants"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -126,21 +270,115 @@ func (h *categoryHandlerImpl) UpdateCategoryHandler(w http.ResponseWriter, r *ht
 // DeleteCategoryHandler godoc
 //
 //	@Summary		delete category endpoint
-//	@Description	delete category by id
+//	@Description	move a category and its sub categories to the trash. refused while products are filed under it, merge it instead
 //	@Accept			json
 //	@Produce		json
 //	@Tags			Category
 //	@Param			id	path	string	true	"category id"
 //	@Security		Bearer
 //	@Success		204
+//	@Failure		404
+//	@Failure		409
 //	@Failure		500
 //	@Router			/category/{id} [delete]
 func (h *categoryHandlerImpl) DeleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
 	categoryID := r.PathValue("id")
 	if err := h.service.Category().DeleteCategory(r.Context(), categoryID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrCategoryInUse):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "category still has products, move them or merge the category into another one"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCategoryImpactHandler godoc
+//
+//	@Summary		category delete preview endpoint
+//	@Description	count the sub categories and products a delete or merge of the category would touch
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Category
+//	@Param			id	path	string	true	"category id"
+//	@Security		Bearer
+//	@Success		200	{object}	model.CategoryImpact
+//	@Failure		404
+//	@Failure		500
+//	@Router			/category/delete-preview/{id} [get]
+func (h *categoryHandlerImpl) GetCategoryImpactHandler(w http.ResponseWriter, r *http.Request) {
+	impact, err := h.service.Category().GetCategoryImpact(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	resp, err := json.Marshal(impact)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// MergeCategoryHandler godoc
+//
+//	@Summary		merge category endpoint
+//	@Description	move the sub categories and products of a category to another category and move the emptied category to the trash
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Category
+//	@Param			id		path	string							true	"category id"
+//	@Param			request	body	entity.CategoryMergeRequest		true	"category to merge into"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/category/merge/{id} [post]
+func (h *categoryHandlerImpl) MergeCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.CategoryMergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Category().MergeCategory(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "category or target category not found"}`))
+		case errors.Is(err, domain.ErrCategoryCycle):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "category cannot be merged into itself or its descendants"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -183,3 +421,92 @@ func (h *categoryHandlerImpl) ExistsCategoryHandler(w http.ResponseWriter, r *ht
 		return
 	}
 }
+
+// GetDeletedCategoriesHandler godoc
+//
+//	@Summary		get deleted categorys endpoint
+//	@Description	page through categorys in the trash, most recently deleted first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Category
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.Category
+//	@Failure		400
+//	@Failure		500
+//	@Router			/category/trash [get]
+func (h *categoryHandlerImpl) GetDeletedCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	categories, err := h.service.Category().GetDeletedCategories(r.Context(), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(categories)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// RestoreCategoryHandler godoc
+//
+//	@Summary		restore category endpoint
+//	@Description	move a category out of the trash
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Category
+//	@Param			id	path	string	true	"category id"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/category/restore/{id} [post]
+func (h *categoryHandlerImpl) RestoreCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	categoryID := r.PathValue("id")
+	if err := h.service.Category().RestoreCategory(r.Context(), categoryID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another category"}`))
+		case errors.Is(err, domain.ErrParentDeleted):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "restore the parent category first"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// decodeFormRequest reads a body sent as json, or as a multipart form whose data field holds the json and whose
+// optional fileField holds an image.
+func decodeFormRequest(r *http.Request, dst any, fileField string) (*multipart.FileHeader, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return nil, json.NewDecoder(r.Body).Decode(dst)
+	}
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(r.FormValue("data")), dst); err != nil {
+		return nil, err
+	}
+	files := r.MultipartForm.File[fileField]
+	if len(files) == 0 {
+		return nil, nil
+	}
+	return files[0], nil
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type collectionHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewCollectionHandler(service domain.Service, validator *validator.Validate) domain.CollectionHandler {
+	return &collectionHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllCollectionsHandler godoc
+//
+//	@Summary		get collections endpoint
+//	@Description	get all collections ordered by name
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Collection
+//	@Success		200	{array}	model.Collection
+//	@Failure		500
+//	@Router			/collection [get]
+func (h *collectionHandlerImpl) GetAllCollectionsHandler(w http.ResponseWriter, r *http.Request) {
+	collections, err := h.service.Collection().GetAllCollections(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(collections)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetCollectionBySlugHandler godoc
+//
+//	@Summary		get collection by slug endpoint
+//	@Description	get a collection with a page of its published products, in the curated order for manual collections and newest first for rule based ones
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Collection
+//	@Param			slug	path		string	true	"collection slug"
+//	@Param			limit	query		int		false	"product page size"		default(20)
+//	@Param			offset	query		int		false	"product page offset"	default(0)
+//	@Success		200		{object}	model.CollectionDetail
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/collection/{slug} [get]
+func (h *collectionHandlerImpl) GetCollectionBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	collection, err := h.service.Collection().GetCollectionBySlug(r.Context(), r.PathValue("slug"), limit, offset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(collection)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// CreateCollectionHandler godoc
+//
+//	@Summary		create collection endpoint
+//	@Description	create new collection. manual collections get their products through the products endpoint, rule based ones list every published product matching the rule
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Collection
+//	@Param			request	body	entity.CollectionRequest	true	"collection data for create"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/collection [post]
+func (h *collectionHandlerImpl) CreateCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Collection().CreateCollection(r.Context(), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "rule category, brand or tag not found"}`))
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another collection"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UpdateCollectionHandler godoc
+//
+//	@Summary		update collection endpoint
+//	@Description	update collection by id. switching to the rule mode keeps the hand picked products for when it switches back
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Collection
+//	@Param			id		path	string						true	"collection id"
+//	@Param			request	body	entity.CollectionRequest	true	"collection data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/collection/{id} [put]
+func (h *collectionHandlerImpl) UpdateCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Collection().UpdateCollection(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "collection or rule category, brand or tag not found"}`))
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another collection"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteCollectionHandler godoc
+//
+//	@Summary		delete collection endpoint
+//	@Description	delete collection by id, its products are kept
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Collection
+//	@Param			id	path	string	true	"collection id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/collection/{id} [delete]
+func (h *collectionHandlerImpl) DeleteCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Collection().DeleteCollection(r.Context(), r.PathValue("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetCollectionProductsHandler godoc
+//
+//	@Summary		set collection products endpoint
+//	@Description	replace the products of a manual collection, in display order. an empty list empties it
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Collection
+//	@Param			id		path	string							true	"collection id"
+//	@Param			request	body	entity.CollectionProductRequest	true	"product ids"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/collection/products/{id} [put]
+func (h *collectionHandlerImpl) SetCollectionProductsHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.CollectionProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Collection().SetCollectionProducts(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "collection or product not found"}`))
+		case errors.Is(err, domain.ErrCollectionRuleBased):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "products of a rule based collection follow its rule"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
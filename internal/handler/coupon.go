@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type couponHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewCouponHandler(service domain.Service, validator *validator.Validate) domain.CouponHandler {
+	return &couponHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllCouponsHandler godoc
+//
+//	@Summary		get coupons endpoint
+//	@Description	page through coupons, newest first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Coupon
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.Coupon
+//	@Failure		400
+//	@Failure		500
+//	@Router			/coupon [get]
+func (h *couponHandlerImpl) GetAllCouponsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	coupons, err := h.service.Coupon().GetAllCoupons(r.Context(), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(coupons)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetCouponByIDHandler godoc
+//
+//	@Summary		get coupon endpoint
+//	@Description	get coupon by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Coupon
+//	@Param			id	path	string	true	"coupon id"
+//	@Security		Bearer
+//	@Success		200	{object}	model.Coupon
+//	@Failure		404
+//	@Failure		500
+//	@Router			/coupon/{id} [get]
+func (h *couponHandlerImpl) GetCouponByIDHandler(w http.ResponseWriter, r *http.Request) {
+	couponID := r.PathValue("id")
+	coupon, err := h.service.Coupon().GetCouponByID(r.Context(), couponID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(coupon)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// CreateCouponHandler godoc
+//
+//	@Summary		create coupon endpoint
+//	@Description	create a percentage or fixed amount coupon code with usage rules
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Coupon
+//	@Param			request	body	entity.CouponRequest	true	"coupon data for create"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/coupon [post]
+func (h *couponHandlerImpl) CreateCouponHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.CouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Coupon().CreateCoupon(r.Context(), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "product or category not found"}`))
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "coupon code already exists"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UpdateCouponHandler godoc
+//
+//	@Summary		update coupon endpoint
+//	@Description	update coupon by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Coupon
+//	@Param			id		path	string					true	"coupon id"
+//	@Param			request	body	entity.CouponRequest	true	"coupon data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/coupon/{id} [put]
+func (h *couponHandlerImpl) UpdateCouponHandler(w http.ResponseWriter, r *http.Request) {
+	couponID := r.PathValue("id")
+	var reqBody entity.CouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Coupon().UpdateCoupon(r.Context(), couponID, &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "coupon code already exists"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteCouponHandler godoc
+//
+//	@Summary		delete coupon endpoint
+//	@Description	delete coupon by id together with its redemptions. to stop a coupon early prefer setting ends_at
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Coupon
+//	@Param			id	path	string	true	"coupon id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/coupon/{id} [delete]
+func (h *couponHandlerImpl) DeleteCouponHandler(w http.ResponseWriter, r *http.Request) {
+	couponID := r.PathValue("id")
+	if err := h.service.Coupon().DeleteCoupon(r.Context(), couponID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewCouponHandler godoc
+//
+//	@Summary		preview coupon endpoint
+//	@Description	validate a coupon code against a basket of products and quantities and show the discount it gives
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Coupon
+//	@Param			request	body	entity.CouponBasketRequest	true	"coupon code and basket"
+//	@Security		Bearer
+//	@Success		200	{object}	model.CouponPreview
+//	@Failure		400
+//	@Failure		404
+//	@Failure		422
+//	@Failure		500
+//	@Router			/coupon/preview [post]
+func (h *couponHandlerImpl) PreviewCouponHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.CouponBasketRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	preview, err := h.service.Coupon().PreviewCoupon(r.Context(), currentUserID, &reqBody)
+	if err != nil {
+		writeCouponError(w, err)
+		return
+	}
+	resp, err := json.Marshal(preview)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// RedeemCouponHandler godoc
+//
+//	@Summary		redeem coupon endpoint
+//	@Description	apply a coupon code to a basket and record the redemption against the usage limits
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Coupon
+//	@Param			request	body	entity.CouponBasketRequest	true	"coupon code and basket"
+//	@Security		Bearer
+//	@Success		201	{object}	model.CouponPreview
+//	@Failure		400
+//	@Failure		404
+//	@Failure		422
+//	@Failure		500
+//	@Router			/coupon/redeem [post]
+func (h *couponHandlerImpl) RedeemCouponHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.CouponBasketRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	preview, err := h.service.Coupon().RedeemCoupon(r.Context(), currentUserID, &reqBody)
+	if err != nil {
+		writeCouponError(w, err)
+		return
+	}
+	resp, err := json.Marshal(preview)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(resp)
+}
+
+// writeCouponError answers a coupon that does not fit the basket with 422 and the reason.
+func writeCouponError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "product not found"}`))
+	case errors.Is(err, domain.ErrCouponInvalid),
+		errors.Is(err, domain.ErrCouponNotApplicable),
+		errors.Is(err, domain.ErrCouponMinBasket),
+		errors.Is(err, domain.ErrCouponUsedUp),
+		errors.Is(err, domain.ErrCouponFirstOrder):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type discountHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewDiscountHandler(service domain.Service, validator *validator.Validate) domain.DiscountHandler {
+	return &discountHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllDiscountsHandler godoc
+//
+//	@Summary		get discounts endpoint
+//	@Description	page through discounts, latest start first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Discount
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.Discount
+//	@Failure		400
+//	@Failure		500
+//	@Router			/discount [get]
+func (h *discountHandlerImpl) GetAllDiscountsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	discounts, err := h.service.Discount().GetAllDiscounts(r.Context(), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(discounts)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetDiscountByIDHandler godoc
+//
+//	@Summary		get discount endpoint
+//	@Description	get discount by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Discount
+//	@Param			id	path	string	true	"discount id"
+//	@Security		Bearer
+//	@Success		200	{object}	model.Discount
+//	@Failure		404
+//	@Failure		500
+//	@Router			/discount/{id} [get]
+func (h *discountHandlerImpl) GetDiscountByIDHandler(w http.ResponseWriter, r *http.Request) {
+	discountID := r.PathValue("id")
+	discount, err := h.service.Discount().GetDiscountByID(r.Context(), discountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(discount)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// CreateDiscountHandler godoc
+//
+//	@Summary		create discount endpoint
+//	@Description	create a percentage, fixed or sale price discount for a product, a category tree or the whole catalog
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Discount
+//	@Param			request	body	entity.DiscountRequest	true	"discount data for create"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/discount [post]
+func (h *discountHandlerImpl) CreateDiscountHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.DiscountRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Discount().CreateDiscount(r.Context(), &reqBody); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "product or category not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UpdateDiscountHandler godoc
+//
+//	@Summary		update discount endpoint
+//	@Description	update discount by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Discount
+//	@Param			id		path	string					true	"discount id"
+//	@Param			request	body	entity.DiscountRequest	true	"discount data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/discount/{id} [put]
+func (h *discountHandlerImpl) UpdateDiscountHandler(w http.ResponseWriter, r *http.Request) {
+	discountID := r.PathValue("id")
+	var reqBody entity.DiscountRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Discount().UpdateDiscount(r.Context(), discountID, &reqBody); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteDiscountHandler godoc
+//
+//	@Summary		delete discount endpoint
+//	@Description	delete discount by id. to end a running sale early prefer setting ends_at
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Discount
+//	@Param			id	path	string	true	"discount id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/discount/{id} [delete]
+func (h *discountHandlerImpl) DeleteDiscountHandler(w http.ResponseWriter, r *http.Request) {
+	discountID := r.PathValue("id")
+	if err := h.service.Discount().DeleteDiscount(r.Context(), discountID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -15,6 +15,22 @@ type handlerImpl struct {
 	productImageHandler       domain.ProductImageHandler
 	productCommentHandler     domain.ProductCommentHandler
 	productCommentLikeHandler domain.ProductCommentLikeHandler
+	notificationHandler       domain.NotificationHandler
+	userAddressHandler        domain.UserAddressHandler
+	auditHandler              domain.AuditHandler
+	productRevisionHandler    domain.ProductRevisionHandler
+	discountHandler           domain.DiscountHandler
+	couponHandler             domain.CouponHandler
+	stockMovementHandler      domain.StockMovementHandler
+	stockSubscriptionHandler  domain.StockSubscriptionHandler
+	wishlistHandler           domain.WishlistHandler
+	productViewHandler        domain.ProductViewHandler
+	productRelationHandler    domain.ProductRelationHandler
+	brandHandler              domain.BrandHandler
+	tagHandler                domain.TagHandler
+	collectionHandler         domain.CollectionHandler
+	homeHandler               domain.HomeHandler
+	cacheHandler              domain.CacheHandler
 }
 
 func NewHandler(services domain.Service) domain.Handler {
@@ -29,6 +45,22 @@ func NewHandler(services domain.Service) domain.Handler {
 		productImageHandler:       NewProductImageHandler(services, v),
 		productCommentHandler:     NewProductCommentHandler(services, v),
 		productCommentLikeHandler: NewProductCommentLikeHandler(services, v),
+		notificationHandler:       NewNotificationHandler(services, v),
+		userAddressHandler:        NewUserAddressHandler(services, v),
+		auditHandler:              NewAuditHandler(services, v),
+		productRevisionHandler:    NewProductRevisionHandler(services, v),
+		discountHandler:           NewDiscountHandler(services, v),
+		couponHandler:             NewCouponHandler(services, v),
+		stockMovementHandler:      NewStockMovementHandler(services, v),
+		stockSubscriptionHandler:  NewStockSubscriptionHandler(services, v),
+		wishlistHandler:           NewWishlistHandler(services, v),
+		productViewHandler:        NewProductViewHandler(services, v),
+		productRelationHandler:    NewProductRelationHandler(services, v),
+		brandHandler:              NewBrandHandler(services, v),
+		tagHandler:                NewTagHandler(services, v),
+		collectionHandler:         NewCollectionHandler(services, v),
+		homeHandler:               NewHomeHandler(services, v),
+		cacheHandler:              NewCacheHandler(services, v),
 	}
 }
 
@@ -63,3 +95,67 @@ func (h *handlerImpl) ProductComment() domain.ProductCommentHandler {
 func (h *handlerImpl) ProductCommentLike() domain.ProductCommentLikeHandler {
 	return h.productCommentLikeHandler
 }
+
+func (h *handlerImpl) Notification() domain.NotificationHandler {
+	return h.notificationHandler
+}
+
+func (h *handlerImpl) UserAddress() domain.UserAddressHandler {
+	return h.userAddressHandler
+}
+
+func (h *handlerImpl) Audit() domain.AuditHandler {
+	return h.auditHandler
+}
+
+func (h *handlerImpl) ProductRevision() domain.ProductRevisionHandler {
+	return h.productRevisionHandler
+}
+
+func (h *handlerImpl) Discount() domain.DiscountHandler {
+	return h.discountHandler
+}
+
+func (h *handlerImpl) Coupon() domain.CouponHandler {
+	return h.couponHandler
+}
+
+func (h *handlerImpl) StockMovement() domain.StockMovementHandler {
+	return h.stockMovementHandler
+}
+
+func (h *handlerImpl) StockSubscription() domain.StockSubscriptionHandler {
+	return h.stockSubscriptionHandler
+}
+
+func (h *handlerImpl) Wishlist() domain.WishlistHandler {
+	return h.wishlistHandler
+}
+
+func (h *handlerImpl) ProductView() domain.ProductViewHandler {
+	return h.productViewHandler
+}
+
+func (h *handlerImpl) ProductRelation() domain.ProductRelationHandler {
+	return h.productRelationHandler
+}
+
+func (h *handlerImpl) Brand() domain.BrandHandler {
+	return h.brandHandler
+}
+
+func (h *handlerImpl) Tag() domain.TagHandler {
+	return h.tagHandler
+}
+
+func (h *handlerImpl) Collection() domain.CollectionHandler {
+	return h.collectionHandler
+}
+
+func (h *handlerImpl) Home() domain.HomeHandler {
+	return h.homeHandler
+}
+
+func (h *handlerImpl) Cache() domain.CacheHandler {
+	return h.cacheHandler
+}
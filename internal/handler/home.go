@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type homeHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewHomeHandler(service domain.Service, validator *validator.Validate) domain.HomeHandler {
+	return &homeHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetHomeHandler godoc
+//
+//	@Summary		get homepage endpoint
+//	@Description	get the visible homepage sections in order with their banners, products and category tiles
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Home
+//	@Success		200	{object}	model.Home
+//	@Failure		500
+//	@Router			/home [get]
+func (h *homeHandlerImpl) GetHomeHandler(w http.ResponseWriter, r *http.Request) {
+	home, err := h.service.Home().GetHome(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(home)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetHomeSectionsHandler godoc
+//
+//	@Summary		get home sections endpoint
+//	@Description	get every homepage section as configured, hidden ones included
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Home
+//	@Security		Bearer
+//	@Success		200	{array}	model.HomeSection
+//	@Failure		500
+//	@Router			/home/section [get]
+func (h *homeHandlerImpl) GetHomeSectionsHandler(w http.ResponseWriter, r *http.Request) {
+	sections, err := h.service.Home().GetHomeSections(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(sections)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// CreateHomeSectionHandler godoc
+//
+//	@Summary		create home section endpoint
+//	@Description	create a homepage section
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Home
+//	@Param			request	body	entity.HomeSectionRequest	true	"section data for create"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/home/section [post]
+func (h *homeHandlerImpl) CreateHomeSectionHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.HomeSectionRequest
+	if !h.decodeSectionRequest(w, r, &reqBody) {
+		return
+	}
+	if err := h.service.Home().CreateHomeSection(r.Context(), &reqBody); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "collection not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UpdateHomeSectionHandler godoc
+//
+//	@Summary		update home section endpoint
+//	@Description	update a homepage section by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Home
+//	@Param			id		path	string						true	"section id"
+//	@Param			request	body	entity.HomeSectionRequest	true	"section data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/home/section/{id} [put]
+func (h *homeHandlerImpl) UpdateHomeSectionHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.HomeSectionRequest
+	if !h.decodeSectionRequest(w, r, &reqBody) {
+		return
+	}
+	if err := h.service.Home().UpdateHomeSection(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "home section or collection not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteHomeSectionHandler godoc
+//
+//	@Summary		delete home section endpoint
+//	@Description	delete a homepage section by id together with its banners
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Home
+//	@Param			id	path	string	true	"section id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/home/section/{id} [delete]
+func (h *homeHandlerImpl) DeleteHomeSectionHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Home().DeleteHomeSection(r.Context(), r.PathValue("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateHomeBannerHandler godoc
+//
+//	@Summary		create home banner endpoint
+//	@Description	add a banner to a banner section. send multipart/form-data with the json in a data field and the image in an image field
+//	@Accept			mpfd
+//	@Produce		json
+//	@Tags			Home
+//	@Param			id		path	string						true	"section id"
+//	@Param			request	body	entity.HomeBannerRequest	true	"banner data"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/home/banner/{id} [post]
+func (h *homeHandlerImpl) CreateHomeBannerHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.HomeBannerRequest
+	image, err := decodeFormRequest(r, &reqBody, "image")
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if image == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "banner image is required"}`))
+		return
+	}
+	keys, err := h.service.S3().UploadFiles(r.Context(), []*multipart.FileHeader{image}, "home")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	reqBody.Image = &keys[0]
+	if err := h.service.Home().CreateHomeBanner(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrHomeSectionKind):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "banners can only be added to banner sections"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// DeleteHomeBannerHandler godoc
+//
+//	@Summary		delete home banner endpoint
+//	@Description	delete a homepage banner by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Home
+//	@Param			id	path	string	true	"banner id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/home/banner/{id} [delete]
+func (h *homeHandlerImpl) DeleteHomeBannerHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Home().DeleteHomeBanner(r.Context(), r.PathValue("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *homeHandlerImpl) decodeSectionRequest(w http.ResponseWriter, r *http.Request, reqBody *entity.HomeSectionRequest) bool {
+	if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return false
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type notificationHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewNotificationHandler(service domain.Service, validator *validator.Validate) domain.NotificationHandler {
+	return &notificationHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllNotificationsHandler godoc
+//
+//	@Summary		get notifications endpoint
+//	@Description	list outbound notifications with their delivery status. newest first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Notification
+//	@Param			status		query	string	false	"delivery status"	Enums(pending, processing, sent, dead)
+//	@Param			channel		query	string	false	"delivery channel"
+//	@Param			recipient	query	string	false	"recipient phone or address"
+//	@Param			user_id		query	string	false	"user id"
+//	@Param			event		query	string	false	"event name"
+//	@Param			limit		query	int		false	"page size"	default(20)
+//	@Param			offset		query	int		false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.Notification
+//	@Failure		400
+//	@Failure		500
+//	@Router			/notification [get]
+func (h *notificationHandlerImpl) GetAllNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var reqParam entity.NotificationQueryParamRequest
+	reqParam.Status = query.Get("status")
+	reqParam.Channel = query.Get("channel")
+	reqParam.Recipient = query.Get("recipient")
+	reqParam.UserID = query.Get("user_id")
+	reqParam.Event = query.Get("event")
+	limit, err := helper.ParseQueryInt(query, "limit", 20)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	offset, err := helper.ParseQueryInt(query, "offset", 0)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	reqParam.Limit = limit
+	reqParam.Offset = offset
+	if err := h.validator.Struct(reqParam); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	notifications, err := h.service.Notification().GetAllNotifications(r.Context(), &reqParam)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(notifications)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetNotificationByIDHandler godoc
+//
+//	@Summary		get notification endpoint
+//	@Description	get a single notification and its delivery status by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Notification
+//	@Param			id	path	string	true	"notification id"
+//	@Security		Bearer
+//	@Success		200	{object}	model.Notification
+//	@Failure		404
+//	@Failure		500
+//	@Router			/notification/{id} [get]
+func (h *notificationHandlerImpl) GetNotificationByIDHandler(w http.ResponseWriter, r *http.Request) {
+	notificationID := r.PathValue("id")
+	notification, err := h.service.Notification().GetNotificationByID(r.Context(), notificationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(notification)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// RetryNotificationHandler godoc
+//
+//	@Summary		retry notification endpoint
+//	@Description	move a dead-lettered notification back to the queue with a fresh attempt budget
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Notification
+//	@Param			id	path	string	true	"notification id"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		404
+//	@Failure		500
+//	@Router			/notification/retry/{id} [post]
+func (h *notificationHandlerImpl) RetryNotificationHandler(w http.ResponseWriter, r *http.Request) {
+	notificationID := r.PathValue("id")
+	if err := h.service.Notification().RetryNotification(r.Context(), notificationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "no dead notification found with this id"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+)
+
+// parsePage reads limit and offset from the query string and writes a bad request response when they are invalid.
+func parsePage(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	query := r.URL.Query()
+	limit, err := helper.ParseQueryInt(query, "limit", 20)
+	if err != nil || limit < 1 || limit > 100 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "limit must be an integer between 1 and 100"}`))
+		return 0, 0, false
+	}
+	offset, err := helper.ParseQueryInt(query, "offset", 0)
+	if err != nil || offset < 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "offset must be a non-negative integer"}`))
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
+// parseLimit reads the size of a list that is not paged, such as recommendations.
+func parseLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	limit, err := helper.ParseQueryInt(r.URL.Query(), "limit", 10)
+	if err != nil || limit < 1 || limit > 50 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "limit must be an integer between 1 and 50"}`))
+		return 0, false
+	}
+	return limit, true
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
@@ -30,11 +32,24 @@ func NewProductHandler(service domain.Service, validator *validator.Validate) do
 //	@Accept			json
 //	@Produce		json
 //	@Tags			Product
-//	@Success		200	{array}	model.Product
+//	@Param			status		query	string	false	"publication status, admins only"	Enums(draft, published, archived)
+//	@Param			brand_id	query	string	false	"only list products of this brand"
+//	@Success		200			{array}	model.Products
+//	@Failure		400
 //	@Failure		500
 //	@Router			/product [get]
 func (h *productHandlerImpl) GetAllProductsHandler(w http.ResponseWriter, r *http.Request) {
-	products, err := h.service.Product().GetAllProducts(r.Context())
+	var reqParam entity.ProductQueryParamRequest
+	reqParam.Status = r.URL.Query().Get("status")
+	reqParam.BrandID = r.URL.Query().Get("brand_id")
+	if err := h.validator.Struct(reqParam); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	products, err := h.service.Product().GetAllProducts(r.Context(), &reqParam)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -58,12 +73,17 @@ func (h *productHandlerImpl) GetAllProductsHandler(w http.ResponseWriter, r *htt
 //	@Tags			Product
 //	@Param			id	path		string	true	"product id"
 //	@Success		200	{object}	model.Product
+//	@Failure		404
 //	@Failure		500
 //	@Router			/product/id/{id} [get]
 func (h *productHandlerImpl) GetProductByIDHandler(w http.ResponseWriter, r *http.Request) {
 	productID := r.PathValue("id")
 	product, err := h.service.Product().GetProductByID(r.Context(), productID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -80,18 +100,23 @@ func (h *productHandlerImpl) GetProductByIDHandler(w http.ResponseWriter, r *htt
 // GetProductBySlugHandler godoc
 //
 //	@Summary		get product by slug endpoint
-//	@Description	get product by slug
+//	@Description	get product by slug. authenticated admins can preview drafts and archived products
 //	@Accept			json
 //	@Produce		json
 //	@Tags			Product
 //	@Param			slug	path		string	true	"product slug"
 //	@Success		200		{object}	model.Product
+//	@Failure		404
 //	@Failure		500
 //	@Router			/product/slug/{slug} [get]
 func (h *productHandlerImpl) GetProductBySlugHandler(w http.ResponseWriter, r *http.Request) {
 	productSlug := r.PathValue("slug")
 	product, err := h.service.Product().GetProductBySlug(r.Context(), productSlug)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -153,6 +178,8 @@ func (h *productHandlerImpl) CreateProductHandler(w http.ResponseWriter, r *http
 //	@Security		Bearer
 //	@Success		200
 //	@Failure		400
+//	@Failure		404
+//	@Failure		409
 //	@Failure		500
 //	@Router			/product/{id} [put]
 func (h *productHandlerImpl) UpdateProductHandler(w http.ResponseWriter, r *http.Request) {
@@ -173,6 +200,58 @@ func (h *productHandlerImpl) UpdateProductHandler(w http.ResponseWriter, r *http
 		return
 	}
 	if err := h.service.Product().UpdateProduct(r.Context(), productID, &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "slug is already used by another product"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UpdateProductStatusHandler godoc
+//
+//	@Summary		update product status endpoint
+//	@Description	move a product between draft, published and archived. publish_at schedules a draft, unpublish_at archives it later
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id		path	string								true	"product id"
+//	@Param			request	body	entity.ProductStatusUpdateRequest	true	"publication status and schedule"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/status/{id} [put]
+func (h *productHandlerImpl) UpdateProductStatusHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("id")
+	var reqBody entity.ProductStatusUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Product().UpdateProductStatus(r.Context(), productID, &reqBody); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -189,11 +268,16 @@ func (h *productHandlerImpl) UpdateProductHandler(w http.ResponseWriter, r *http
 //	@Param			id	path	string	true	"product id"
 //	@Security		Bearer
 //	@Success		204
+//	@Failure		404
 //	@Failure		500
 //	@Router			/product/{id} [delete]
 func (h *productHandlerImpl) DeleteProductHandler(w http.ResponseWriter, r *http.Request) {
 	productID := r.PathValue("id")
 	if err := h.service.Product().DeleteProduct(r.Context(), productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -225,3 +309,69 @@ func (h *productHandlerImpl) ExistsProductHandler(w http.ResponseWriter, r *http
 	}
 	w.WriteHeader(http.StatusNotFound)
 }
+
+// GetDeletedProductsHandler godoc
+//
+//	@Summary		get deleted products endpoint
+//	@Description	page through products in the trash, most recently deleted first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.Products
+//	@Failure		400
+//	@Failure		500
+//	@Router			/product/trash [get]
+func (h *productHandlerImpl) GetDeletedProductsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.service.Product().GetDeletedProducts(r.Context(), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(products)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// RestoreProductHandler godoc
+//
+//	@Summary		restore product endpoint
+//	@Description	move a product out of the trash
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id	path	string	true	"product id"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/product/restore/{id} [post]
+func (h *productHandlerImpl) RestoreProductHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("id")
+	if err := h.service.Product().RestoreProduct(r.Context(), productID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "slug is already used by another product"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
@@ -74,6 +76,7 @@ func (h *productCommentHandlerImpl) CreateProductCommentHandler(w http.ResponseW
 //	@Success		200
 //	@Failure		400
 //	@Failure		403
+//	@Failure		404
 //	@Failure		500
 //	@Router			/product/comment/{id} [put]
 func (h *productCommentHandlerImpl) UpdateProductCommentHandler(w http.ResponseWriter, r *http.Request) {
@@ -96,10 +99,14 @@ func (h *productCommentHandlerImpl) UpdateProductCommentHandler(w http.ResponseW
 	}
 	productComment, err := h.service.ProductComment().GetProductCommentByID(r.Context(), productCommentID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	if productComment.UserID != currentUserID {
+	if productComment.UserID == nil || *productComment.UserID != currentUserID {
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
@@ -122,6 +129,7 @@ func (h *productCommentHandlerImpl) UpdateProductCommentHandler(w http.ResponseW
 //	@Success		204
 //	@Failure		400
 //	@Failure		403
+//	@Failure		404
 //	@Failure		500
 //	@Router			/product/comment/{id} [delete]
 func (h *productCommentHandlerImpl) DeleteProductCommentHandler(w http.ResponseWriter, r *http.Request) {
@@ -130,10 +138,14 @@ func (h *productCommentHandlerImpl) DeleteProductCommentHandler(w http.ResponseW
 	isAdmin := r.Context().Value(helper.CtxIsAdmin).(bool)
 	productComment, err := h.service.ProductComment().GetProductCommentByID(r.Context(), productCommentID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	if productComment.UserID != currentUserID && !isAdmin {
+	if (productComment.UserID == nil || *productComment.UserID != currentUserID) && !isAdmin {
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
@@ -143,3 +155,69 @@ func (h *productCommentHandlerImpl) DeleteProductCommentHandler(w http.ResponseW
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetDeletedProductCommentsHandler godoc
+//
+//	@Summary		get deleted product comments endpoint
+//	@Description	page through product comments in the trash, most recently deleted first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product Comment
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.ProductComment
+//	@Failure		400
+//	@Failure		500
+//	@Router			/product/comment/trash [get]
+func (h *productCommentHandlerImpl) GetDeletedProductCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	productComments, err := h.service.ProductComment().GetDeletedProductComments(r.Context(), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(productComments)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// RestoreProductCommentHandler godoc
+//
+//	@Summary		restore product comment endpoint
+//	@Description	move a product comment out of the trash
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product Comment
+//	@Param			id	path	string	true	"product comment id"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/product/comment/restore/{id} [post]
+func (h *productCommentHandlerImpl) RestoreProductCommentHandler(w http.ResponseWriter, r *http.Request) {
+	productCommentID := r.PathValue("id")
+	if err := h.service.ProductComment().RestoreProductComment(r.Context(), productCommentID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrParentDeleted):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "restore the parent comment first"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/go-playground/validator/v10"
+)
+
+type productRelationHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewProductRelationHandler(service domain.Service, validator *validator.Validate) domain.ProductRelationHandler {
+	return &productRelationHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetRelatedProductsHandler godoc
+//
+//	@Summary		related products endpoint
+//	@Description	list the products related to a product. pinned relations come first, then products of the same or a sibling category and products with a similar name or description
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id		path	string	true	"product id"
+//	@Param			limit	query	int		false	"list size"	default(10)
+//	@Success		200		{array}	model.Products
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/related/{id} [get]
+func (h *productRelationHandlerImpl) GetRelatedProductsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.service.ProductRelation().GetRelatedProducts(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(products)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// SetRelatedProductsHandler godoc
+//
+//	@Summary		pin related products endpoint
+//	@Description	replace the products pinned as related to a product, in display order. an empty list removes the pins
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id		path	string							true	"product id"
+//	@Param			request	body	entity.ProductRelationRequest	true	"related product ids"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/related/{id} [put]
+func (h *productRelationHandlerImpl) SetRelatedProductsHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.ProductRelationRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.ProductRelation().SetRelatedProducts(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "product not found"}`))
+		case errors.Is(err, domain.ErrRelatedToSelf):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "product cannot be related to itself"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
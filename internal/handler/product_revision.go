@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type productRevisionHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewProductRevisionHandler(service domain.Service, validator *validator.Validate) domain.ProductRevisionHandler {
+	return &productRevisionHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetProductRevisionsHandler godoc
+//
+//	@Summary		get product revisions endpoint
+//	@Description	page through the saved revisions of a product, newest first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product Revision
+//	@Param			id		path	string	true	"product id"
+//	@Param			limit	query	int		false	"page size"		default(20)
+//	@Param			offset	query	int		false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.ProductRevision
+//	@Failure		400
+//	@Failure		500
+//	@Router			/product/revision/{id} [get]
+func (h *productRevisionHandlerImpl) GetProductRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("id")
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	productRevisions, err := h.service.ProductRevision().GetProductRevisions(r.Context(), productID, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(productRevisions)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// DiffProductRevisionsHandler godoc
+//
+//	@Summary		diff product revisions endpoint
+//	@Description	compare two revisions of a product. before and after only hold the fields that changed
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product Revision
+//	@Param			id		path	string	true	"product id"
+//	@Param			from	query	int		true	"base revision"
+//	@Param			to		query	int		false	"compared revision, defaults to the latest"
+//	@Security		Bearer
+//	@Success		200	{object}	model.ProductRevisionDiff
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/revision/diff/{id} [get]
+func (h *productRevisionHandlerImpl) DiffProductRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("id")
+	query := r.URL.Query()
+	var reqParam entity.ProductRevisionDiffQueryParamRequest
+	from, err := helper.ParseQueryInt(query, "from", 0)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	to, err := helper.ParseQueryInt(query, "to", 0)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	reqParam.From = from
+	reqParam.To = to
+	if err := h.validator.Struct(reqParam); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	diff, err := h.service.ProductRevision().DiffProductRevisions(r.Context(), productID, reqParam.From, reqParam.To)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(diff)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// RollbackProductHandler godoc
+//
+//	@Summary		rollback product endpoint
+//	@Description	restore the fields of a product from an earlier revision. the rollback is saved as a new revision
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product Revision
+//	@Param			id		path	string							true	"product id"
+//	@Param			request	body	entity.ProductRollbackRequest	true	"revision to roll back to"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/product/revision/rollback/{id} [post]
+func (h *productRevisionHandlerImpl) RollbackProductHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("id")
+	var reqBody entity.ProductRollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.ProductRevision().RollbackProduct(r.Context(), productID, reqBody.Revision); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "slug of this revision is used by another product"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
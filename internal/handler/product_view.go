@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/go-playground/validator/v10"
+)
+
+type productViewHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewProductViewHandler(service domain.Service, validator *validator.Validate) domain.ProductViewHandler {
+	return &productViewHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetRecentlyViewedHandler godoc
+//
+//	@Summary		recently viewed products endpoint
+//	@Description	list the products the current user viewed last, latest first. anonymous visitors are tracked by the X-Device-ID header
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			X-Device-ID	header	string	false	"device id returned by an earlier product read"
+//	@Param			limit		query	int		false	"list size"	default(10)
+//	@Success		200			{array}	model.Products
+//	@Failure		400
+//	@Failure		500
+//	@Router			/product/recently-viewed [get]
+func (h *productViewHandlerImpl) GetRecentlyViewedHandler(w http.ResponseWriter, r *http.Request) {
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.service.ProductView().GetRecentlyViewed(r.Context(), limit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(products)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetAlsoViewedHandler godoc
+//
+//	@Summary		customers also viewed endpoint
+//	@Description	list the products most often viewed together with a product, topped up with popular products of its category
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id		path	string	true	"product id"
+//	@Param			limit	query	int		false	"list size"	default(10)
+//	@Success		200		{array}	model.Products
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/also-viewed/{id} [get]
+func (h *productViewHandlerImpl) GetAlsoViewedHandler(w http.ResponseWriter, r *http.Request) {
+	limit, ok := parseLimit(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.service.ProductView().GetAlsoViewed(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(products)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
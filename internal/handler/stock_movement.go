@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type stockMovementHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewStockMovementHandler(service domain.Service, validator *validator.Validate) domain.StockMovementHandler {
+	return &stockMovementHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetStockMovementsHandler godoc
+//
+//	@Summary		get stock movements endpoint
+//	@Description	page through the inventory ledger of a product, newest first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Stock
+//	@Param			id		path	string	true	"product id"
+//	@Param			limit	query	int		false	"page size"		default(20)
+//	@Param			offset	query	int		false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.StockMovement
+//	@Failure		400
+//	@Failure		500
+//	@Router			/product/stock/{id} [get]
+func (h *stockMovementHandlerImpl) GetStockMovementsHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("id")
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	movements, err := h.service.StockMovement().GetStockMovements(r.Context(), productID, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(movements)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetLowStockProductsHandler godoc
+//
+//	@Summary		low stock report endpoint
+//	@Description	page through products at or below the low stock threshold, emptiest first. archived products are left out
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Stock
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.LowStockProduct
+//	@Failure		400
+//	@Failure		500
+//	@Router			/product/stock/low [get]
+func (h *stockMovementHandlerImpl) GetLowStockProductsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.service.StockMovement().GetLowStockProducts(r.Context(), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(products)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// MoveStockHandler godoc
+//
+//	@Summary		move stock endpoint
+//	@Description	book a restock, sale, return or manual adjustment. delta is signed, a sale takes stock away
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Stock
+//	@Param			id		path	string						true	"product id"
+//	@Param			request	body	entity.StockMovementRequest	true	"stock movement"
+//	@Security		Bearer
+//	@Success		201	{object}	model.StockMovement
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/product/stock/{id} [post]
+func (h *stockMovementHandlerImpl) MoveStockHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.PathValue("id")
+	var reqBody entity.StockMovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	movement, err := h.service.StockMovement().MoveStock(r.Context(), productID, &reqBody)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrOutOfStock):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "not enough stock"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	resp, err := json.Marshal(movement)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(resp)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/go-playground/validator/v10"
+)
+
+type stockSubscriptionHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewStockSubscriptionHandler(service domain.Service, validator *validator.Validate) domain.StockSubscriptionHandler {
+	return &stockSubscriptionHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// SubscribeHandler godoc
+//
+//	@Summary		back in stock subscription endpoint
+//	@Description	get an sms once an out of stock product is available again. the subscription is cleared after the sms
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id	path	string	true	"product id"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/product/notify-me/{id} [post]
+func (h *stockSubscriptionHandlerImpl) SubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	productID := r.PathValue("id")
+	if err := h.service.StockSubscription().Subscribe(r.Context(), currentUserID, productID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrInStock):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "product is in stock"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UnsubscribeHandler godoc
+//
+//	@Summary		cancel back in stock subscription endpoint
+//	@Description	stop waiting for a product to come back in stock
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id	path	string	true	"product id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/notify-me/{id} [delete]
+func (h *stockSubscriptionHandlerImpl) UnsubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	productID := r.PathValue("id")
+	if err := h.service.StockSubscription().Unsubscribe(r.Context(), currentUserID, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
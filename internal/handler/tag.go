@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type tagHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewTagHandler(service domain.Service, validator *validator.Validate) domain.TagHandler {
+	return &tagHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllTagsHandler godoc
+//
+//	@Summary		get tags endpoint
+//	@Description	get all tags ordered by name
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Tag
+//	@Success		200	{array}	model.Tag
+//	@Failure		500
+//	@Router			/tag [get]
+func (h *tagHandlerImpl) GetAllTagsHandler(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.service.Tag().GetAllTags(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(tags)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetTagBySlugHandler godoc
+//
+//	@Summary		get tag by slug endpoint
+//	@Description	get a tag with a page of the published products carrying it
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Tag
+//	@Param			slug	path		string	true	"tag slug"
+//	@Param			limit	query		int		false	"product page size"		default(20)
+//	@Param			offset	query		int		false	"product page offset"	default(0)
+//	@Success		200		{object}	model.TagDetail
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/tag/{slug} [get]
+func (h *tagHandlerImpl) GetTagBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	tag, err := h.service.Tag().GetTagBySlug(r.Context(), r.PathValue("slug"), limit, offset)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(tag)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// CreateTagHandler godoc
+//
+//	@Summary		create tag endpoint
+//	@Description	create new tag
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Tag
+//	@Param			request	body	entity.TagRequest	true	"tag data for create"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		409
+//	@Failure		500
+//	@Router			/tag [post]
+func (h *tagHandlerImpl) CreateTagHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Tag().CreateTag(r.Context(), &reqBody); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another tag"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UpdateTagHandler godoc
+//
+//	@Summary		update tag endpoint
+//	@Description	update tag by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Tag
+//	@Param			id		path	string				true	"tag id"
+//	@Param			request	body	entity.TagRequest	true	"tag data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/tag/{id} [put]
+func (h *tagHandlerImpl) UpdateTagHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Tag().UpdateTag(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "name or slug is already used by another tag"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteTagHandler godoc
+//
+//	@Summary		delete tag endpoint
+//	@Description	delete tag by id and remove it from every product
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Tag
+//	@Param			id	path	string	true	"tag id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/tag/{id} [delete]
+func (h *tagHandlerImpl) DeleteTagHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Tag().DeleteTag(r.Context(), r.PathValue("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetProductTagsHandler godoc
+//
+//	@Summary		set product tags endpoint
+//	@Description	replace the tags of a product. an empty list removes them all
+//	@Accept			json
+//	@Produce		json
+//	@Tags			Product
+//	@Param			id		path	string						true	"product id"
+//	@Param			request	body	entity.ProductTagRequest	true	"tag ids"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/product/tags/{id} [put]
+func (h *tagHandlerImpl) SetProductTagsHandler(w http.ResponseWriter, r *http.Request) {
+	var reqBody entity.ProductTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.Tag().SetProductTags(r.Context(), r.PathValue("id"), &reqBody); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "product or tag not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
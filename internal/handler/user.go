@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
 	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/go-playground/validator/v10"
@@ -49,3 +53,739 @@ func (h *userHandlerImpl) UserProfileHandler(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusOK)
 	w.Write(resp)
 }
+
+// UpdateNotificationPreferenceHandler godoc
+//
+//	@Summary		update notification preference endpoint
+//	@Description	opt in or out of sms and email notifications for account and shop events
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	entity.UserNotificationPreferenceRequest	true	"notification channels"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user/notification/preference [put]
+func (h *userHandlerImpl) UpdateNotificationPreferenceHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserNotificationPreferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.User().UpdateUserNotificationPreference(r.Context(), currentUserID, &reqBody); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UserNotificationsHandler godoc
+//
+//	@Summary		user notifications endpoint
+//	@Description	delivery history of notifications sent to the current user. newest first
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.Notification
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user/notification [get]
+func (h *userHandlerImpl) UserNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	query := r.URL.Query()
+	limit, err := helper.ParseQueryInt(query, "limit", 20)
+	if err != nil || limit < 1 || limit > 100 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "limit must be an integer between 1 and 100"}`))
+		return
+	}
+	offset, err := helper.ParseQueryInt(query, "offset", 0)
+	if err != nil || offset < 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "offset must be a non-negative integer"}`))
+		return
+	}
+	notifications, err := h.service.UserNotification().GetUserNotifications(r.Context(), currentUserID, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(notifications)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// UpdateUserProfileHandler godoc
+//
+//	@Summary		update user profile endpoint
+//	@Description	update name, birthday and national id of current user. omitted fields are cleared
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	entity.UserProfileUpdateRequest	true	"profile data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/profile [put]
+func (h *userHandlerImpl) UpdateUserProfileHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserProfileUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.User().UpdateUserProfile(r.Context(), currentUserID, &reqBody); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "national id is already registered"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// RequestEmailChangeHandler godoc
+//
+//	@Summary		request email change endpoint
+//	@Description	send a verification code to the new email. email is changed after verification
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	entity.UserEmailChangeRequest	true	"new email"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user/profile/email [put]
+func (h *userHandlerImpl) RequestEmailChangeHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserEmailChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.User().RequestUserEmailChange(r.Context(), currentUserID, reqBody.Email); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// VerifyEmailChangeHandler godoc
+//
+//	@Summary		verify email change endpoint
+//	@Description	verify the code sent to the new email and store it on the profile
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	entity.UserVerifyCodeRequest	true	"verification code"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/profile/email/verify [post]
+func (h *userHandlerImpl) VerifyEmailChangeHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserVerifyCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.User().VerifyUserEmailChange(r.Context(), currentUserID, reqBody.Code); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCode):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid verification code or expired"}`))
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "email is already registered"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// RequestUserDeletionHandler godoc
+//
+//	@Summary		request account deletion endpoint
+//	@Description	send a confirmation code to the user phone for deleting the account
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		500
+//	@Router			/user/profile/delete [post]
+func (h *userHandlerImpl) RequestUserDeletionHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if err := h.service.User().RequestUserDeletion(r.Context(), currentUserID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteUserHandler godoc
+//
+//	@Summary		delete account endpoint
+//	@Description	delete current user account with the confirmation code. comments and ratings are kept anonymously
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	entity.UserVerifyCodeRequest	true	"confirmation code"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/profile [delete]
+func (h *userHandlerImpl) DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserVerifyCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.User().DeleteUser(r.Context(), currentUserID, reqBody.Code); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCode):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid confirmation code or expired"}`))
+		case errors.Is(err, domain.ErrLastAdmin):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "the last active admin cannot delete their account, promote another admin first"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestPhoneChangeHandler godoc
+//
+//	@Summary		request phone change endpoint
+//	@Description	send confirmation codes to the new phone and to the current phone
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	entity.UserPhoneChangeRequest	true	"new phone"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/profile/phone [put]
+func (h *userHandlerImpl) RequestPhoneChangeHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	h.requestPhoneChange(w, r, currentUserID, false)
+}
+
+// VerifyPhoneChangeHandler godoc
+//
+//	@Summary		verify phone change endpoint
+//	@Description	confirm both codes and swap the phone. all sessions are revoked and a new access token is returned
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	entity.UserPhoneChangeVerifyRequest	true	"codes sent to the new and current phone"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/profile/phone/verify [post]
+func (h *userHandlerImpl) VerifyPhoneChangeHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if !h.verifyPhoneChange(w, r, currentUserID, currentUserID, false) {
+		return
+	}
+	user, err := h.service.User().GetUserByID(r.Context(), currentUserID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	token, err := h.service.User().GenerateUserJwtToken(r.Context(), user)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(fmt.Sprintf(`{"access_token": "%s"}`, token)))
+}
+
+// OverridePhoneChangeHandler godoc
+//
+//	@Summary		override phone change endpoint
+//	@Description	start a phone change for a user who lost the current phone. only the new phone is asked for a code
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string							true	"user id"
+//	@Param			request	body	entity.UserPhoneChangeRequest	true	"new phone"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/phone/override/{id} [post]
+func (h *userHandlerImpl) OverridePhoneChangeHandler(w http.ResponseWriter, r *http.Request) {
+	h.requestPhoneChange(w, r, r.PathValue("id"), true)
+}
+
+// VerifyOverridePhoneChangeHandler godoc
+//
+//	@Summary		verify override phone change endpoint
+//	@Description	confirm the code sent to the new phone and swap it. all sessions of the user are revoked
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string								true	"user id"
+//	@Param			request	body	entity.UserPhoneChangeVerifyRequest	true	"code sent to the new phone"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/phone/override/verify/{id} [post]
+func (h *userHandlerImpl) VerifyOverridePhoneChangeHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if !h.verifyPhoneChange(w, r, currentUserID, r.PathValue("id"), true) {
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *userHandlerImpl) requestPhoneChange(w http.ResponseWriter, r *http.Request, userID string, override bool) {
+	var reqBody entity.UserPhoneChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.User().RequestUserPhoneChange(r.Context(), userID, reqBody.Phone, override); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrDuplicate):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "phone is already registered"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifyPhoneChange writes the error response itself and reports whether the phone was swapped.
+func (h *userHandlerImpl) verifyPhoneChange(w http.ResponseWriter, r *http.Request, actorID, userID string, override bool) bool {
+	var reqBody entity.UserPhoneChangeVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return false
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return false
+	}
+	if err := h.service.User().VerifyUserPhoneChange(r.Context(), actorID, userID, &reqBody, override); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCode):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid confirmation code or expired"}`))
+		case errors.Is(err, domain.ErrDuplicate), errors.Is(err, sql.ErrNoRows):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "phone is already registered or was changed meanwhile"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return false
+	}
+	return true
+}
+
+// GetAllUsersHandler godoc
+//
+//	@Summary		get users endpoint
+//	@Description	page through users, newest first. phone matches as a prefix and the created range is inclusive
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			phone			query	string	false	"phone prefix"
+//	@Param			created_from	query	string	false	"registered on or after (YYYY-MM-DD)"
+//	@Param			created_to		query	string	false	"registered on or before (YYYY-MM-DD)"
+//	@Param			limit			query	int		false	"page size"	default(20)
+//	@Param			offset			query	int		false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.User
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user [get]
+func (h *userHandlerImpl) GetAllUsersHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var reqParam entity.UserQueryParamRequest
+	reqParam.Phone = query.Get("phone")
+	reqParam.CreatedFrom = query.Get("created_from")
+	reqParam.CreatedTo = query.Get("created_to")
+	limit, err := helper.ParseQueryInt(query, "limit", 20)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	offset, err := helper.ParseQueryInt(query, "offset", 0)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	reqParam.Limit = limit
+	reqParam.Offset = offset
+	if err := h.validator.Struct(reqParam); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	users, err := h.service.User().GetAllUsers(r.Context(), &reqParam)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(users)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetUserByIDHandler godoc
+//
+//	@Summary		get user endpoint
+//	@Description	get a single user by id
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"user id"
+//	@Security		Bearer
+//	@Success		200	{object}	model.User
+//	@Failure		404
+//	@Failure		500
+//	@Router			/user/{id} [get]
+func (h *userHandlerImpl) GetUserByIDHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+	user, err := h.service.User().GetUserByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(user)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetUserCommentsHandler godoc
+//
+//	@Summary		get user comments endpoint
+//	@Description	page through comments written by a user, newest first
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"user id"
+//	@Param			limit	query	int		false	"page size"	default(20)
+//	@Param			offset	query	int		false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.ProductComment
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user/comment/{id} [get]
+func (h *userHandlerImpl) GetUserCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	productComments, err := h.service.ProductComment().GetUserProductComments(r.Context(), userID, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(productComments)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetUserRatingsHandler godoc
+//
+//	@Summary		get user ratings endpoint
+//	@Description	page through product ratings given by a user, newest first
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string	true	"user id"
+//	@Param			limit	query	int		false	"page size"	default(20)
+//	@Param			offset	query	int		false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.ProductRating
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user/rating/{id} [get]
+func (h *userHandlerImpl) GetUserRatingsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	productRatings, err := h.service.ProductRating().GetUserProductRatings(r.Context(), userID, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(productRatings)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// BanUserHandler godoc
+//
+//	@Summary		ban user endpoint
+//	@Description	ban a user. the user can no longer log in and every active session is revoked
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"user id"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/ban/{id} [post]
+func (h *userHandlerImpl) BanUserHandler(w http.ResponseWriter, r *http.Request) {
+	h.setUserBanned(w, r, true)
+}
+
+// UnbanUserHandler godoc
+//
+//	@Summary		unban user endpoint
+//	@Description	lift the ban of a user
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"user id"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/user/ban/{id} [delete]
+func (h *userHandlerImpl) UnbanUserHandler(w http.ResponseWriter, r *http.Request) {
+	h.setUserBanned(w, r, false)
+}
+
+// UpdateUserRoleHandler godoc
+//
+//	@Summary		update user role endpoint
+//	@Description	promote a user to admin or demote an admin. active sessions of the user are revoked
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string							true	"user id"
+//	@Param			request	body	entity.UserRoleUpdateRequest	true	"new role"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		409
+//	@Failure		500
+//	@Router			/user/role/{id} [put]
+func (h *userHandlerImpl) UpdateUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserRoleUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if userID == currentUserID {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "admins cannot change their own role"}`))
+		return
+	}
+	if err := h.service.User().SetUserAdmin(r.Context(), userID, *reqBody.IsAdmin); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrLastAdmin):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "the last active admin cannot be demoted"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *userHandlerImpl) setUserBanned(w http.ResponseWriter, r *http.Request, banned bool) {
+	userID := r.PathValue("id")
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if userID == currentUserID {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "admins cannot ban themselves"}`))
+		return
+	}
+	if err := h.service.User().SetUserBanned(r.Context(), userID, banned); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, domain.ErrLastAdmin):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "the last active admin cannot be banned"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
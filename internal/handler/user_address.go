@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	_ "github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/go-playground/validator/v10"
+)
+
+type userAddressHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewUserAddressHandler(service domain.Service, validator *validator.Validate) domain.UserAddressHandler {
+	return &userAddressHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllUserAddressesHandler godoc
+//
+//	@Summary		get user addresses endpoint
+//	@Description	get address book of current user. default address comes first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Address
+//	@Security		Bearer
+//	@Success		200	{array}	model.UserAddress
+//	@Failure		500
+//	@Router			/user/address [get]
+func (h *userAddressHandlerImpl) GetAllUserAddressesHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	addresses, err := h.service.UserAddress().GetAllUserAddresses(r.Context(), currentUserID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(addresses)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// CreateUserAddressHandler godoc
+//
+//	@Summary		create user address endpoint
+//	@Description	add address to current user address book
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Address
+//	@Param			request	body	entity.UserAddressRequest	true	"address data for create"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user/address [post]
+func (h *userAddressHandlerImpl) CreateUserAddressHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserAddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.UserAddress().CreateUserAddress(r.Context(), currentUserID, &reqBody); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UpdateUserAddressHandler godoc
+//
+//	@Summary		update user address endpoint
+//	@Description	update address of current user by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Address
+//	@Param			id		path	string						true	"address id"
+//	@Param			request	body	entity.UserAddressRequest	true	"address data for update"
+//	@Security		Bearer
+//	@Success		200
+//	@Failure		400
+//	@Failure		404
+//	@Failure		500
+//	@Router			/user/address/{id} [put]
+func (h *userAddressHandlerImpl) UpdateUserAddressHandler(w http.ResponseWriter, r *http.Request) {
+	addressID := r.PathValue("id")
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	var reqBody entity.UserAddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.validator.Struct(reqBody); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		resp, _ := json.Marshal(helper.M{"error": err.Error()})
+		w.Write(resp)
+		return
+	}
+	if err := h.service.UserAddress().UpdateUserAddress(r.Context(), addressID, currentUserID, &reqBody); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeleteUserAddressHandler godoc
+//
+//	@Summary		delete user address endpoint
+//	@Description	delete address of current user by id
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Address
+//	@Param			id	path	string	true	"address id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/user/address/{id} [delete]
+func (h *userAddressHandlerImpl) DeleteUserAddressHandler(w http.ResponseWriter, r *http.Request) {
+	addressID := r.PathValue("id")
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if err := h.service.UserAddress().DeleteUserAddress(r.Context(), addressID, currentUserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
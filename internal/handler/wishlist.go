@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/go-playground/validator/v10"
+)
+
+type wishlistHandlerImpl struct {
+	service   domain.Service
+	validator *validator.Validate
+}
+
+func NewWishlistHandler(service domain.Service, validator *validator.Validate) domain.WishlistHandler {
+	return &wishlistHandlerImpl{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetWishlistHandler godoc
+//
+//	@Summary		get wishlist endpoint
+//	@Description	page through the products in the wishlist of the current user, latest addition first
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Wishlist
+//	@Param			limit	query	int	false	"page size"		default(20)
+//	@Param			offset	query	int	false	"page offset"	default(0)
+//	@Security		Bearer
+//	@Success		200	{array}	model.Products
+//	@Failure		400
+//	@Failure		500
+//	@Router			/user/wishlist [get]
+func (h *wishlistHandlerImpl) GetWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	products, err := h.service.Wishlist().GetWishlist(r.Context(), currentUserID, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(products)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// GetSharedWishlistHandler godoc
+//
+//	@Summary		get shared wishlist endpoint
+//	@Description	page through a wishlist its owner has shared. an unknown or revoked token yields an empty list
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Wishlist
+//	@Param			token	path	string	true	"share token"
+//	@Param			limit	query	int		false	"page size"		default(20)
+//	@Param			offset	query	int		false	"page offset"	default(0)
+//	@Success		200		{array}	model.Products
+//	@Failure		400
+//	@Failure		500
+//	@Router			/wishlist/shared/{token} [get]
+func (h *wishlistHandlerImpl) GetSharedWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.service.Wishlist().GetSharedWishlist(r.Context(), r.PathValue("token"), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(products)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// AddToWishlistHandler godoc
+//
+//	@Summary		add to wishlist endpoint
+//	@Description	add a published product to the wishlist of the current user. adding it again has no effect
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Wishlist
+//	@Param			id	path	string	true	"product id"
+//	@Security		Bearer
+//	@Success		201
+//	@Failure		404
+//	@Failure		500
+//	@Router			/user/wishlist/{id} [post]
+func (h *wishlistHandlerImpl) AddToWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if err := h.service.Wishlist().AddToWishlist(r.Context(), currentUserID, r.PathValue("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// RemoveFromWishlistHandler godoc
+//
+//	@Summary		remove from wishlist endpoint
+//	@Description	remove a product from the wishlist of the current user
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Wishlist
+//	@Param			id	path	string	true	"product id"
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/user/wishlist/{id} [delete]
+func (h *wishlistHandlerImpl) RemoveFromWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if err := h.service.Wishlist().RemoveFromWishlist(r.Context(), currentUserID, r.PathValue("id")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ShareWishlistHandler godoc
+//
+//	@Summary		share wishlist endpoint
+//	@Description	get the public link of the wishlist of the current user, it is created on first use
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Wishlist
+//	@Security		Bearer
+//	@Success		200	{object}	model.WishlistShare
+//	@Failure		500
+//	@Router			/user/wishlist/share [post]
+func (h *wishlistHandlerImpl) ShareWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	share, err := h.service.Wishlist().ShareWishlist(r.Context(), currentUserID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp, err := json.Marshal(share)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// UnshareWishlistHandler godoc
+//
+//	@Summary		unshare wishlist endpoint
+//	@Description	revoke the public link of the wishlist of the current user
+//	@Accept			json
+//	@Produce		json
+//	@Tags			User Wishlist
+//	@Security		Bearer
+//	@Success		204
+//	@Failure		404
+//	@Failure		500
+//	@Router			/user/wishlist/share [delete]
+func (h *wishlistHandlerImpl) UnshareWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	currentUserID := r.Context().Value(helper.CtxUserID).(string)
+	if err := h.service.Wishlist().UnshareWishlist(r.Context(), currentUserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
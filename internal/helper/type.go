@@ -3,9 +3,12 @@ package helper
 type ctxKey string
 
 const (
-	CtxUserID  ctxKey = "user_id"
-	CtxPhone   ctxKey = "phone"
-	CtxIsAdmin ctxKey = "is_admin"
+	CtxUserID    ctxKey = "user_id"
+	CtxPhone     ctxKey = "phone"
+	CtxIsAdmin   ctxKey = "is_admin"
+	CtxRequestID ctxKey = "request_id"
+	CtxClientIP  ctxKey = "client_ip"
+	CtxDeviceID  ctxKey = "device_id"
 )
 
 type M map[string]any
@@ -2,6 +2,8 @@ package helper
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/arshamroshannejad/squidshop-backend/config"
@@ -16,3 +18,23 @@ func BuildMediaURL(cfg *config.Config, mediaPath *string) *string {
 	url := fmt.Sprintf("%s/%s", domain, path)
 	return &url
 }
+
+func ParseQueryInt(values url.Values, key string, fallback int) (int, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("query parameter %s must be an integer", key)
+	}
+	return value, nil
+}
+
+func Truncate(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	return string(runes[:maxRunes]) + "…"
+}
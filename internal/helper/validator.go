@@ -2,15 +2,38 @@ package helper
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
 	"github.com/go-playground/validator/v10"
 )
 
-var iranPhoneRegex = regexp.MustCompile(`^\+989\d{9}$`)
+var (
+	iranPhoneRegex      = regexp.MustCompile(`^\+989\d{9}$`)
+	phonePrefixRegex    = regexp.MustCompile(`^\+\d{1,12}$`)
+	iranNationalIDRegex = regexp.MustCompile(`^\d{10}$`)
+	iranPostalCodeRegex = regexp.MustCompile(`^[13-9]{4}[1346-9][013-9]{5}$`)
+)
 
 func RegisterValidations(v *validator.Validate) error {
 	v.RegisterStructValidation(validateCategoryQueryParams, entity.CategoryQueryParamRequest{})
+	v.RegisterStructValidation(validateProductCreate, entity.ProductCreateRequest{})
+	v.RegisterStructValidation(validateProductStatusUpdate, entity.ProductStatusUpdateRequest{})
+	v.RegisterStructValidation(validateDiscount, entity.DiscountRequest{})
+	v.RegisterStructValidation(validateCoupon, entity.CouponRequest{})
+	v.RegisterStructValidation(validateStockMovement, entity.StockMovementRequest{})
+	v.RegisterStructValidation(validateCollection, entity.CollectionRequest{})
+	v.RegisterStructValidation(validateHomeSection, entity.HomeSectionRequest{})
+	if err := v.RegisterValidation("irnationalid", validateIranNationalID); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("irpostalcode", validateIranPostalCode); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("phoneprefix", validatePhonePrefix); err != nil {
+		return err
+	}
 	return v.RegisterValidation("irphone", validateIranPhone)
 }
 
@@ -22,6 +45,163 @@ func validateCategoryQueryParams(sl validator.StructLevel) {
 	}
 }
 
+func validateProductCreate(sl validator.StructLevel) {
+	req := sl.Current().Interface().(entity.ProductCreateRequest)
+	status := req.Status
+	if status == "" {
+		status = "draft"
+	}
+	validateProductSchedule(sl, status, req.PublishAt, req.UnpublishAt)
+}
+
+func validateProductStatusUpdate(sl validator.StructLevel) {
+	req := sl.Current().Interface().(entity.ProductStatusUpdateRequest)
+	validateProductSchedule(sl, req.Status, req.PublishAt, req.UnpublishAt)
+}
+
+// validateProductSchedule only allows publish_at on drafts, no unpublish_at on archived products
+// and an unpublish_at that comes after publish_at.
+func validateProductSchedule(sl validator.StructLevel, status string, publishAt, unpublishAt *time.Time) {
+	if publishAt != nil && status != "draft" {
+		sl.ReportError(publishAt, "PublishAt", "publish_at", "draft_only", "publish_at can only be set on drafts")
+	}
+	if unpublishAt != nil && status == "archived" {
+		sl.ReportError(unpublishAt, "UnpublishAt", "unpublish_at", "not_archived", "unpublish_at cannot be set on archived products")
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		sl.ReportError(unpublishAt, "UnpublishAt", "unpublish_at", "gtfield", "unpublish_at must be after publish_at")
+	}
+}
+
+// validateDiscount mirrors the check constraints of the discounts table so bad input is a 400 and not a 500.
+func validateDiscount(sl validator.StructLevel) {
+	req := sl.Current().Interface().(entity.DiscountRequest)
+	if (req.Scope == "product") != (req.ProductID != nil) {
+		sl.ReportError(req.ProductID, "ProductID", "product_id", "product_scope", "product_id is required for and only allowed with the product scope")
+	}
+	if (req.Scope == "category") != (req.CategoryID != nil) {
+		sl.ReportError(req.CategoryID, "CategoryID", "category_id", "category_scope", "category_id is required for and only allowed with the category scope")
+	}
+	validateDiscountValue(sl, req.Kind, req.Percent, req.Amount)
+	if req.Kind == "price" && req.Scope != "product" {
+		sl.ReportError(req.Kind, "Kind", "kind", "product_scope", "a sale price only applies to a single product")
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		sl.ReportError(req.EndsAt, "EndsAt", "ends_at", "gtfield", "ends_at must be after starts_at")
+	}
+}
+
+// validateCoupon mirrors the check constraints of the coupons table.
+func validateCoupon(sl validator.StructLevel) {
+	req := sl.Current().Interface().(entity.CouponRequest)
+	if (req.Scope == "product") != (req.ProductID != nil) {
+		sl.ReportError(req.ProductID, "ProductID", "product_id", "product_scope", "product_id is required for and only allowed with the product scope")
+	}
+	if (req.Scope == "category") != (req.CategoryID != nil) {
+		sl.ReportError(req.CategoryID, "CategoryID", "category_id", "category_scope", "category_id is required for and only allowed with the category scope")
+	}
+	validateDiscountValue(sl, req.Kind, req.Percent, req.Amount)
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		sl.ReportError(req.EndsAt, "EndsAt", "ends_at", "gtfield", "ends_at must be after starts_at")
+	}
+}
+
+// validateDiscountValue asks a percentage for percent and any other kind for an amount of money.
+func validateDiscountValue(sl validator.StructLevel, kind string, percent *float64, amount *money.Money) {
+	if (kind == "percentage") != (percent != nil) {
+		sl.ReportError(percent, "Percent", "percent", "percentage_kind", "percent is required for and only allowed with the percentage kind")
+	}
+	if (kind == "percentage") == (amount != nil) {
+		sl.ReportError(amount, "Amount", "amount", "amount_kind", "amount is required for every kind except percentage")
+	}
+}
+
+// validateStockMovement keeps the sign of delta in line with the kind and asks a manual adjustment for its reason.
+func validateStockMovement(sl validator.StructLevel) {
+	req := sl.Current().Interface().(entity.StockMovementRequest)
+	switch {
+	case (req.Kind == "restock" || req.Kind == "return") && req.Delta < 0:
+		sl.ReportError(req.Delta, "Delta", "delta", "gt", "a restock or return adds stock")
+	case req.Kind == "sale" && req.Delta > 0:
+		sl.ReportError(req.Delta, "Delta", "delta", "lt", "a sale takes stock away")
+	}
+	if req.Kind == "adjustment" && req.Reason == "" {
+		sl.ReportError(req.Reason, "Reason", "reason", "required", "a manual adjustment needs a reason")
+	}
+}
+
+// validateCollection asks a rule for and only for the rule mode. The rule needs at least one condition and a
+// price range that is not inverted.
+func validateCollection(sl validator.StructLevel) {
+	req := sl.Current().Interface().(entity.CollectionRequest)
+	if (req.Mode == "rule") != (req.Rule != nil) {
+		sl.ReportError(req.Rule, "Rule", "rule", "rule_mode", "rule is required for and only allowed with the rule mode")
+		return
+	}
+	if req.Rule == nil {
+		return
+	}
+	rule := req.Rule
+	if rule.CategoryID == nil && rule.BrandID == nil && rule.TagID == nil && rule.MinPrice == nil && rule.MaxPrice == nil {
+		sl.ReportError(req.Rule, "Rule", "rule", "required", "a rule needs at least one condition")
+	}
+	if rule.MinPrice != nil && rule.MaxPrice != nil && *rule.MaxPrice < *rule.MinPrice {
+		sl.ReportError(rule.MaxPrice, "MaxPrice", "max_price", "gtefield", "max_price cannot be below min_price")
+	}
+}
+
+// validateHomeSection asks collection sections for their collection and category tiles for their categories.
+func validateHomeSection(sl validator.StructLevel) {
+	req := sl.Current().Interface().(entity.HomeSectionRequest)
+	if (req.Kind == "collection") != (req.CollectionID != nil) {
+		sl.ReportError(req.CollectionID, "CollectionID", "collection_id", "collection_kind", "collection_id is required for and only allowed with the collection kind")
+	}
+	if (req.Kind == "categories") != (len(req.CategoryIDs) > 0) {
+		sl.ReportError(req.CategoryIDs, "CategoryIDs", "category_ids", "categories_kind", "category_ids is required for and only allowed with the categories kind")
+	}
+}
+
 func validateIranPhone(fl validator.FieldLevel) bool {
 	return iranPhoneRegex.MatchString(fl.Field().String())
 }
+
+// validatePhonePrefix accepts the leading part of a phone number, a plus and up to twelve digits. It is used
+// for prefix searches, so LIKE wildcards never reach the query.
+func validatePhonePrefix(fl validator.FieldLevel) bool {
+	return phonePrefixRegex.MatchString(fl.Field().String())
+}
+
+func validateIranNationalID(fl validator.FieldLevel) bool {
+	return IsValidIranNationalID(fl.Field().String())
+}
+
+func validateIranPostalCode(fl validator.FieldLevel) bool {
+	return iranPostalCodeRegex.MatchString(fl.Field().String())
+}
+
+// IsValidIranNationalID checks the length and the check digit (last digit) of an Iranian national code.
+func IsValidIranNationalID(code string) bool {
+	if !iranNationalIDRegex.MatchString(code) {
+		return false
+	}
+	allSame := true
+	for i := 1; i < len(code); i++ {
+		if code[i] != code[0] {
+			allSame = false
+			break
+		}
+	}
+	if allSame {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(code[i]-'0') * (10 - i)
+	}
+	check := int(code[9] - '0')
+	remainder := sum % 11
+	if remainder < 2 {
+		return check == remainder
+	}
+	return check == 11-remainder
+}
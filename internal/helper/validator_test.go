@@ -0,0 +1,201 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidIranNationalID(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected bool
+	}{
+		{name: "valid code", code: "0499370899", expected: true},
+		{name: "valid code with remainder below two", code: "0000001041", expected: true},
+		{name: "wrong check digit", code: "0499370898", expected: false},
+		{name: "repeated digits", code: "1111111111", expected: false},
+		{name: "too short", code: "123456789", expected: false},
+		{name: "not numeric", code: "04993708a9", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsValidIranNationalID(tt.code))
+		})
+	}
+}
+
+func TestValidateIranPostalCode(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, RegisterValidations(v))
+	tests := []struct {
+		name     string
+		code     string
+		expected bool
+	}{
+		{name: "valid code", code: "1439951314", expected: true},
+		{name: "valid code with zeros in the tail", code: "1335800100", expected: true},
+		{name: "two in the tail", code: "1439951234", expected: false},
+		{name: "zero in the head", code: "1039951314", expected: false},
+		{name: "five as the fifth digit", code: "1439551314", expected: false},
+		{name: "too short", code: "143995131", expected: false},
+		{name: "not numeric", code: "14399513a4", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, v.Var(tt.code, "irpostalcode") == nil)
+		})
+	}
+}
+
+func TestValidateProductStatusUpdate(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, RegisterValidations(v))
+	now := time.Now()
+	later := now.Add(time.Hour)
+	tests := []struct {
+		name    string
+		req     entity.ProductStatusUpdateRequest
+		wantErr bool
+	}{
+		{name: "publish now", req: entity.ProductStatusUpdateRequest{Status: "published"}},
+		{name: "scheduled draft", req: entity.ProductStatusUpdateRequest{Status: "draft", PublishAt: &now, UnpublishAt: &later}},
+		{name: "published with end date", req: entity.ProductStatusUpdateRequest{Status: "published", UnpublishAt: &later}},
+		{name: "publish_at on published", req: entity.ProductStatusUpdateRequest{Status: "published", PublishAt: &now}, wantErr: true},
+		{name: "unpublish_at on archived", req: entity.ProductStatusUpdateRequest{Status: "archived", UnpublishAt: &later}, wantErr: true},
+		{name: "unpublish before publish", req: entity.ProductStatusUpdateRequest{Status: "draft", PublishAt: &later, UnpublishAt: &now}, wantErr: true},
+		{name: "unknown status", req: entity.ProductStatusUpdateRequest{Status: "hidden"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateStockMovement(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, RegisterValidations(v))
+	tests := []struct {
+		name    string
+		req     entity.StockMovementRequest
+		wantErr bool
+	}{
+		{name: "restock", req: entity.StockMovementRequest{Kind: "restock", Delta: 20}},
+		{name: "sale", req: entity.StockMovementRequest{Kind: "sale", Delta: -1}},
+		{name: "adjustment with reason", req: entity.StockMovementRequest{Kind: "adjustment", Delta: -3, Reason: "damaged in storage"}},
+		{name: "adjustment without reason", req: entity.StockMovementRequest{Kind: "adjustment", Delta: -3}, wantErr: true},
+		{name: "negative restock", req: entity.StockMovementRequest{Kind: "restock", Delta: -5}, wantErr: true},
+		{name: "positive sale", req: entity.StockMovementRequest{Kind: "sale", Delta: 2}, wantErr: true},
+		{name: "zero delta", req: entity.StockMovementRequest{Kind: "return"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateCollection(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, RegisterValidations(v))
+	categoryID := 1
+	low, high := money.Money(100000), money.Money(500000)
+	tests := []struct {
+		name    string
+		req     entity.CollectionRequest
+		wantErr bool
+	}{
+		{name: "manual", req: entity.CollectionRequest{Name: "Picks", Slug: "picks", Mode: "manual"}},
+		{name: "rule by category", req: entity.CollectionRequest{Name: "Picks", Slug: "picks", Mode: "rule", Rule: &entity.CollectionRuleRequest{CategoryID: &categoryID}}},
+		{name: "rule by price range", req: entity.CollectionRequest{Name: "Picks", Slug: "picks", Mode: "rule", Rule: &entity.CollectionRuleRequest{MinPrice: &low, MaxPrice: &high}}},
+		{name: "rule mode without rule", req: entity.CollectionRequest{Name: "Picks", Slug: "picks", Mode: "rule"}, wantErr: true},
+		{name: "rule on manual", req: entity.CollectionRequest{Name: "Picks", Slug: "picks", Mode: "manual", Rule: &entity.CollectionRuleRequest{CategoryID: &categoryID}}, wantErr: true},
+		{name: "empty rule", req: entity.CollectionRequest{Name: "Picks", Slug: "picks", Mode: "rule", Rule: &entity.CollectionRuleRequest{}}, wantErr: true},
+		{name: "inverted price range", req: entity.CollectionRequest{Name: "Picks", Slug: "picks", Mode: "rule", Rule: &entity.CollectionRuleRequest{MinPrice: &high, MaxPrice: &low}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateHomeSection(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, RegisterValidations(v))
+	collectionID := 1
+	tests := []struct {
+		name    string
+		req     entity.HomeSectionRequest
+		wantErr bool
+	}{
+		{name: "newest", req: entity.HomeSectionRequest{Kind: "newest", Limit: 8}},
+		{name: "collection", req: entity.HomeSectionRequest{Kind: "collection", CollectionID: &collectionID}},
+		{name: "categories", req: entity.HomeSectionRequest{Kind: "categories", CategoryIDs: []int{1, 4}}},
+		{name: "collection without id", req: entity.HomeSectionRequest{Kind: "collection"}, wantErr: true},
+		{name: "collection id on banner", req: entity.HomeSectionRequest{Kind: "banner", CollectionID: &collectionID}, wantErr: true},
+		{name: "categories without ids", req: entity.HomeSectionRequest{Kind: "categories"}, wantErr: true},
+		{name: "duplicate categories", req: entity.HomeSectionRequest{Kind: "categories", CategoryIDs: []int{1, 1}}, wantErr: true},
+		{name: "unknown kind", req: entity.HomeSectionRequest{Kind: "video"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateUserQueryParams(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, RegisterValidations(v))
+	tests := []struct {
+		name    string
+		req     entity.UserQueryParamRequest
+		wantErr bool
+	}{
+		{name: "no phone", req: entity.UserQueryParamRequest{Limit: 20}},
+		{name: "phone prefix", req: entity.UserQueryParamRequest{Phone: "+98912", Limit: 20}},
+		{name: "full phone", req: entity.UserQueryParamRequest{Phone: "+989120000000", Limit: 20}},
+		{name: "plus only", req: entity.UserQueryParamRequest{Phone: "+", Limit: 20}, wantErr: true},
+		{name: "missing plus", req: entity.UserQueryParamRequest{Phone: "98912", Limit: 20}, wantErr: true},
+		{name: "percent wildcard", req: entity.UserQueryParamRequest{Phone: "%", Limit: 20}, wantErr: true},
+		{name: "underscore wildcard", req: entity.UserQueryParamRequest{Phone: "+98_12", Limit: 20}, wantErr: true},
+		{name: "too long", req: entity.UserQueryParamRequest{Phone: "+9891200000000", Limit: 20}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Struct(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -2,11 +2,13 @@ package middleware
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -15,10 +17,12 @@ type Claims struct {
 	UserID  string `json:"user_id"`
 	Phone   string `json:"phone"`
 	IsAdmin bool   `json:"is_admin"`
+	// SessionVersion must match the current version of the user, see domain.SessionService.
+	SessionVersion int `json:"sv"`
 	jwt.RegisteredClaims
 }
 
-func RequireAuth(cfg *config.Config) func(http.Handler) http.Handler {
+func RequireAuth(cfg *config.Config, sessions domain.SessionService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -59,6 +63,28 @@ func RequireAuth(cfg *config.Config) func(http.Handler) http.Handler {
 				w.Write([]byte(`{"error":"invalid token"}`))
 				return
 			}
+			sessionVersion, err := sessions.Version(r.Context(), claims.UserID)
+			if err != nil {
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte(`{"error":"session is revoked"}`))
+				case errors.Is(err, domain.ErrUserBanned):
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusForbidden)
+					w.Write([]byte(`{"error":"user is banned"}`))
+				default:
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+				return
+			}
+			if claims.SessionVersion != sessionVersion {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":"session is revoked"}`))
+				return
+			}
 			ctx := context.WithValue(r.Context(), helper.CtxUserID, claims.UserID)
 			ctx = context.WithValue(ctx, helper.CtxPhone, claims.Phone)
 			ctx = context.WithValue(ctx, helper.CtxIsAdmin, claims.IsAdmin)
@@ -67,6 +93,21 @@ func RequireAuth(cfg *config.Config) func(http.Handler) http.Handler {
 	}
 }
 
+// OptionalAuth lets anonymous requests through untouched and authenticates the rest like RequireAuth,
+// so public endpoints can still tell admins apart. A bad token is rejected rather than silently ignored.
+func OptionalAuth(cfg *config.Config, sessions domain.SessionService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authenticated := RequireAuth(cfg, sessions)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
+
 func RequireAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		isAdmin, ok := r.Context().Value(helper.CtxIsAdmin).(bool)
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+)
+
+// DeviceID identifies anonymous visitors across requests. Clients keep the X-Device-ID they get back and send it
+// with later requests, a missing or oversized one is replaced with a fresh id.
+func DeviceID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deviceID := r.Header.Get("X-Device-ID")
+		if deviceID == "" || len(deviceID) > 64 {
+			deviceID = newRequestID()
+		}
+		w.Header().Set("X-Device-ID", deviceID)
+		ctx := context.WithValue(r.Context(), helper.CtxDeviceID, deviceID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
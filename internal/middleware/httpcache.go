@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+)
+
+const privateCacheControl = "private, no-cache"
+
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (brw *bufferedResponseWriter) WriteHeader(code int) {
+	brw.statusCode = code
+}
+
+func (brw *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return brw.body.Write(b)
+}
+
+// HTTPCache tags successful responses with a strong ETag over the body and the Cache-Control configured for the
+// route, and answers If-None-Match with 304 Not Modified. Authenticated requests and responses handing out a
+// cookie or a device id may differ per user, so they are marked private and never stored by a shared cache.
+func HTTPCache(cfg *config.Config, route string) func(http.Handler) http.Handler {
+	cacheControl, ok := cfg.HTTPCache.Routes[route]
+	if !ok {
+		cacheControl = "no-cache"
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			brw := &bufferedResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(brw, r)
+			if brw.statusCode != http.StatusOK {
+				w.WriteHeader(brw.statusCode)
+				w.Write(brw.body.Bytes())
+				return
+			}
+			sum := sha256.Sum256(brw.body.Bytes())
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+			header := w.Header()
+			header.Set("ETag", etag)
+			header.Add("Vary", "Authorization")
+			if r.Header.Get("Authorization") != "" || header.Get("Set-Cookie") != "" || header.Get("X-Device-ID") != "" {
+				header.Set("Cache-Control", privateCacheControl)
+			} else {
+				header.Set("Cache-Control", cacheControl)
+			}
+			if notModified(r, etag) {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(brw.body.Bytes())
+		})
+	}
+}
+
+// notModified reports whether the If-None-Match of the request lists the ETag of the response.
+func notModified(r *http.Request, etag string) bool {
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
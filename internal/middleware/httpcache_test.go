@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPCache(t *testing.T) {
+	cfg := &config.Config{HTTPCache: &config.HTTPCache{Routes: map[string]string{"product": "public, max-age=60"}}}
+	handler := HTTPCache(cfg, "product")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/api/v1/product/id/1", nil))
+	etag := first.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "public, max-age=60", first.Header().Get("Cache-Control"))
+	tests := []struct {
+		name         string
+		header       http.Header
+		expectedCode int
+		cacheControl string
+	}{
+		{name: "matching etag", header: http.Header{"If-None-Match": {`"stale", ` + etag}}, expectedCode: http.StatusNotModified, cacheControl: "public, max-age=60"},
+		{name: "weak form of the etag", header: http.Header{"If-None-Match": {"W/" + etag}}, expectedCode: http.StatusNotModified, cacheControl: "public, max-age=60"},
+		{name: "changed etag", header: http.Header{"If-None-Match": {`"stale"`}}, expectedCode: http.StatusOK, cacheControl: "public, max-age=60"},
+		{name: "authenticated is private", header: http.Header{"Authorization": {"Bearer token"}}, expectedCode: http.StatusOK, cacheControl: privateCacheControl},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/product/id/1", nil)
+			r.Header = tt.header
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.cacheControl, w.Header().Get("Cache-Control"))
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			if tt.expectedCode == http.StatusNotModified {
+				assert.Empty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestHTTPCacheSkipsErrors(t *testing.T) {
+	cfg := &config.Config{HTTPCache: &config.HTTPCache{}}
+	handler := HTTPCache(cfg, "product")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/product/id/9", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
+
+func TestHTTPCacheDeviceIDIsPrivate(t *testing.T) {
+	cfg := &config.Config{HTTPCache: &config.HTTPCache{Routes: map[string]string{"product": "public, max-age=60"}}}
+	handler := HTTPCache(cfg, "product")(DeviceID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"1"}`))
+	})))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/product/id/1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("X-Device-ID"))
+	assert.Equal(t, privateCacheControl, w.Header().Get("Cache-Control"))
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
 )
 
 type loggingResponseWriter struct {
@@ -18,10 +20,11 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 }
 
 type logEntry struct {
-	Method   string `json:"method"`
-	Path     string `json:"path"`
-	Status   int    `json:"status"`
-	Duration string `json:"duration"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Status    int    `json:"status"`
+	Duration  string `json:"duration"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func Logger(next http.Handler) http.Handler {
@@ -35,6 +38,7 @@ func Logger(next http.Handler) http.Handler {
 			Status:   lrw.statusCode,
 			Duration: time.Since(start).String(),
 		}
+		entry.RequestID, _ = r.Context().Value(helper.CtxRequestID).(string)
 		b, err := json.Marshal(entry)
 		if err != nil {
 			log.Printf("error marshaling log: %v", err)
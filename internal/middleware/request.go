@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+)
+
+// RequestID tags every request with an id and the client ip so logs and audit entries can be traced back to it.
+// An incoming X-Request-ID is kept when it looks sane, and X-Forwarded-For is only trusted behind a proxy.
+func RequestID(cfg *config.Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" || len(requestID) > 64 {
+				requestID = newRequestID()
+			}
+			w.Header().Set("X-Request-ID", requestID)
+			ctx := context.WithValue(r.Context(), helper.CtxRequestID, requestID)
+			ctx = context.WithValue(ctx, helper.CtxClientIP, clientIP(r, cfg.App.TrustProxy))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
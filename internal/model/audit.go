@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditEntityUser           = "user"
+	AuditEntityCategory       = "category"
+	AuditEntityBrand          = "brand"
+	AuditEntityTag            = "tag"
+	AuditEntityCollection     = "collection"
+	AuditEntityHomeSection    = "home_section"
+	AuditEntityHomeBanner     = "home_banner"
+	AuditEntityProduct        = "product"
+	AuditEntityProductComment = "product_comment"
+	AuditEntityNotification   = "notification"
+	AuditEntityDiscount       = "discount"
+	AuditEntityCoupon         = "coupon"
+)
+
+const (
+	AuditActionUserPhoneChanged       = "user.phone_changed"
+	AuditActionUserBanned             = "user.banned"
+	AuditActionUserUnbanned           = "user.unbanned"
+	AuditActionUserPromoted           = "user.promoted"
+	AuditActionUserDemoted            = "user.demoted"
+	AuditActionCategoryCreated        = "category.created"
+	AuditActionCategoryUpdated        = "category.updated"
+	AuditActionCategoryDeleted        = "category.deleted"
+	AuditActionCategoryRestored       = "category.restored"
+	AuditActionCategoryMerged         = "category.merged"
+	AuditActionBrandCreated           = "brand.created"
+	AuditActionBrandUpdated           = "brand.updated"
+	AuditActionBrandDeleted           = "brand.deleted"
+	AuditActionTagCreated             = "tag.created"
+	AuditActionTagUpdated             = "tag.updated"
+	AuditActionTagDeleted             = "tag.deleted"
+	AuditActionCollectionCreated      = "collection.created"
+	AuditActionCollectionUpdated      = "collection.updated"
+	AuditActionCollectionDeleted      = "collection.deleted"
+	AuditActionCollectionProductsSet  = "collection.products_set"
+	AuditActionHomeSectionCreated     = "home_section.created"
+	AuditActionHomeSectionUpdated     = "home_section.updated"
+	AuditActionHomeSectionDeleted     = "home_section.deleted"
+	AuditActionHomeBannerCreated      = "home_banner.created"
+	AuditActionHomeBannerDeleted      = "home_banner.deleted"
+	AuditActionProductCreated         = "product.created"
+	AuditActionProductUpdated         = "product.updated"
+	AuditActionProductDeleted         = "product.deleted"
+	AuditActionProductRestored        = "product.restored"
+	AuditActionProductRolledBack      = "product.rolled_back"
+	AuditActionProductStatusChanged   = "product.status_changed"
+	AuditActionProductPublished       = "product.published"
+	AuditActionProductUnpublished     = "product.unpublished"
+	AuditActionProductImagesAdded     = "product.images_added"
+	AuditActionProductStockMoved      = "product.stock_moved"
+	AuditActionProductRelationsSet    = "product.relations_set"
+	AuditActionProductTagsSet         = "product.tags_set"
+	AuditActionProductCommentDeleted  = "product_comment.deleted"
+	AuditActionProductCommentRestored = "product_comment.restored"
+	AuditActionDiscountCreated        = "discount.created"
+	AuditActionDiscountUpdated        = "discount.updated"
+	AuditActionDiscountDeleted        = "discount.deleted"
+	AuditActionCouponCreated          = "coupon.created"
+	AuditActionCouponUpdated          = "coupon.updated"
+	AuditActionCouponDeleted          = "coupon.deleted"
+	AuditActionNotificationRetried    = "notification.retried"
+)
+
+type AuditLog struct {
+	ID         string          `json:"id" example:"1"`
+	ActorID    *string         `json:"actor_id" example:"1"`
+	Action     string          `json:"action" example:"product.updated"`
+	EntityType string          `json:"entity_type" example:"product"`
+	EntityID   string          `json:"entity_id" example:"1"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	IP         *string         `json:"ip,omitempty" example:"127.0.0.1"`
+	RequestID  *string         `json:"request_id,omitempty" example:"4f9c1a7d2b3e4f5a6b7c8d9e0f1a2b3c"`
+	CreatedAt  time.Time       `json:"created_at" example:"2025-09-28T01:20:57+03:30"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type Brand struct {
+	ID          string    `json:"id" example:"1"`
+	Name        string    `json:"name" example:"Activision"`
+	Slug        string    `json:"slug" example:"activision"`
+	Logo        *string   `json:"logo,omitempty" example:"https://example.com/brands/activision.png"`
+	Description *string   `json:"description,omitempty" example:"Publisher of the Call of Duty series"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
+
+// BrandDetail is the storefront page of a brand with one page of its published products.
+type BrandDetail struct {
+	Brand
+	Products []Products `json:"products"`
+}
@@ -0,0 +1,11 @@
+package model
+
+// CacheStats counts the read-through cache lookups of one key namespace since the process started. Shared
+// lookups waited for a concurrent load of the same key instead of going to the database.
+type CacheStats struct {
+	Namespace string  `json:"namespace" example:"product"`
+	Hits      uint64  `json:"hits" example:"1200"`
+	Misses    uint64  `json:"misses" example:"40"`
+	Shared    uint64  `json:"shared" example:"12"`
+	HitRatio  float64 `json:"hit_ratio" example:"0.96"`
+}
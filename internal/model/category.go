@@ -1,9 +1,54 @@
 package model
 
+import "time"
+
+const (
+	BreadcrumbKindCategory = "category"
+	BreadcrumbKindProduct  = "product"
+)
+
+// Category is a node of the tree. The storefront fields are only loaded for a single category.
 type Category struct {
-	ID            string     `json:"id" example:"1"`
-	Name          string     `json:"name" example:"Laptop"`
-	Slug          string     `json:"slug" example:"laptop"`
-	ParentID      *string    `json:"-"`
-	SubCategories []Category `json:"sub_categories,omitempty"`
+	ID              string     `json:"id" example:"1"`
+	Name            string     `json:"name" example:"Laptop"`
+	Slug            string     `json:"slug" example:"laptop"`
+	Position        int        `json:"position" example:"0"`
+	Description     *string    `json:"description,omitempty" example:"Laptops for work and play"`
+	BannerImage     *string    `json:"banner_image,omitempty" example:"categories/laptop.jpg"`
+	SeoTitle        *string    `json:"seo_title,omitempty" example:"Buy laptops online"`
+	MetaDescription *string    `json:"meta_description,omitempty" example:"Compare and buy laptops with fast delivery"`
+	ParentID        *string    `json:"-"`
+	Path            string     `json:"-"`
+	SubCategories   []Category `json:"sub_categories,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" example:"2025-09-12T00:12:12.123456789Z"`
+}
+
+// CategoryDetail is the storefront page of a category. Products holds one page of the published products of the
+// category and all of its descendants.
+type CategoryDetail struct {
+	ID              string     `json:"id" example:"1"`
+	Name            string     `json:"name" example:"Laptop"`
+	Slug            string     `json:"slug" example:"laptop"`
+	Description     *string    `json:"description,omitempty" example:"Laptops for work and play"`
+	BannerImage     *string    `json:"banner_image,omitempty" example:"https://example.com/categories/laptop.jpg"`
+	SeoTitle        *string    `json:"seo_title,omitempty" example:"Buy laptops online"`
+	MetaDescription *string    `json:"meta_description,omitempty" example:"Compare and buy laptops with fast delivery"`
+	Path            string     `json:"-"`
+	SubCategories   []Category `json:"sub_categories"`
+	Products        []Products `json:"products"`
+}
+
+// CategoryImpact counts what deleting or merging a category touches: its live sub categories at any depth and the
+// live products filed under it or one of them.
+type CategoryImpact struct {
+	SubCategories int `json:"sub_categories" example:"3"`
+	Products      int `json:"products" example:"42"`
+}
+
+// Breadcrumb is one step on the way from a top level category down to a category or product.
+type Breadcrumb struct {
+	Kind string `json:"kind" example:"category"`
+	ID   string `json:"id" example:"1"`
+	Name string `json:"name" example:"Laptop"`
+	Slug string `json:"slug" example:"laptop"`
 }
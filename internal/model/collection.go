@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+const (
+	CollectionModeManual = "manual"
+	CollectionModeRule   = "rule"
+)
+
+type Collection struct {
+	ID          string          `json:"id" example:"1"`
+	Name        string          `json:"name" example:"Black Friday picks"`
+	Slug        string          `json:"slug" example:"black-friday-picks"`
+	Description *string         `json:"description,omitempty" example:"Our favourite deals of the week"`
+	Mode        string          `json:"mode" example:"manual"`
+	Rule        *CollectionRule `json:"rule,omitempty"`
+	CreatedAt   time.Time       `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	UpdatedAt   time.Time       `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
+
+// CollectionRule selects the published products that match every condition that is set. The category condition
+// includes its descendants and the prices are compared with the list price, before discounts. Stale marks a rule
+// whose category, brand or tag was deleted, it matches nothing until it is saved again.
+type CollectionRule struct {
+	CategoryID *string      `json:"category_id,omitempty" example:"1"`
+	BrandID    *string      `json:"brand_id,omitempty" example:"1"`
+	TagID      *string      `json:"tag_id,omitempty" example:"1"`
+	MinPrice   *money.Money `json:"min_price,omitempty" example:"100000"`
+	MaxPrice   *money.Money `json:"max_price,omitempty" example:"5000000"`
+	Stale      bool         `json:"stale" example:"false"`
+}
+
+// CollectionDetail is the storefront page of a collection with one page of its products, in the manual order or
+// newest first for rule based collections.
+type CollectionDetail struct {
+	Collection
+	Products []Products `json:"products"`
+}
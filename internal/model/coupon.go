@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+const (
+	CouponKindPercentage = "percentage"
+	CouponKindFixed      = "fixed"
+)
+
+// Coupon is a code a customer enters to take money off a basket. Scope uses the discount scopes and limits what
+// part of the basket the coupon counts against, max_discount caps a percentage coupon.
+type Coupon struct {
+	ID              string       `json:"id" example:"1"`
+	Code            string       `json:"code" example:"WELCOME10"`
+	Kind            string       `json:"kind" example:"percentage"`
+	Percent         *float64     `json:"percent,omitempty" example:"10"`
+	Amount          *money.Money `json:"amount,omitempty" example:"500000"`
+	MaxDiscount     *money.Money `json:"max_discount,omitempty" example:"500000"`
+	MinBasket       money.Money  `json:"min_basket" example:"2000000"`
+	Scope           string       `json:"scope" example:"catalog"`
+	ProductID       *string      `json:"product_id,omitempty" example:"1"`
+	CategoryID      *string      `json:"category_id,omitempty" example:"1"`
+	UsageLimit      *int         `json:"usage_limit,omitempty" example:"1000"`
+	PerUserLimit    *int         `json:"per_user_limit,omitempty" example:"1"`
+	FirstOrderOnly  bool         `json:"first_order_only" example:"true"`
+	RedemptionCount int          `json:"redemption_count" example:"42"`
+	StartsAt        time.Time    `json:"starts_at" example:"2025-03-15T00:00:00Z"`
+	EndsAt          *time.Time   `json:"ends_at,omitempty" example:"2025-04-02T00:00:00Z"`
+	CreatedAt       time.Time    `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	UpdatedAt       time.Time    `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
+
+// CouponUsage is how often a user already used a coupon and any coupon at all.
+type CouponUsage struct {
+	CouponRedemptions int
+	TotalRedemptions  int
+}
+
+type CouponPreviewItem struct {
+	ProductID string      `json:"product_id" example:"1"`
+	Quantity  int         `json:"quantity" example:"2"`
+	UnitPrice money.Money `json:"unit_price" example:"169900"`
+	LineTotal money.Money `json:"line_total" example:"339800"`
+	Eligible  bool        `json:"eligible" example:"true"`
+}
+
+// CouponPreview is the basket priced with a coupon. Unit prices already include running discounts.
+type CouponPreview struct {
+	Code             string              `json:"code" example:"WELCOME10"`
+	Items            []CouponPreviewItem `json:"items"`
+	Subtotal         money.Money         `json:"subtotal" example:"339800"`
+	EligibleSubtotal money.Money         `json:"eligible_subtotal" example:"339800"`
+	Discount         money.Money         `json:"discount" example:"33980"`
+	Total            money.Money         `json:"total" example:"305820"`
+}
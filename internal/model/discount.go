@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+const (
+	DiscountKindPercentage = "percentage"
+	DiscountKindFixed      = "fixed"
+	DiscountKindPrice      = "price"
+)
+
+const (
+	DiscountScopeProduct  = "product"
+	DiscountScopeCategory = "category"
+	DiscountScopeCatalog  = "catalog"
+)
+
+// Discount lowers the price of a product, every product of a category tree or the whole catalog while it runs.
+// A percentage discount takes percent off the price, a fixed discount takes amount off and a price discount sells
+// one product for amount.
+type Discount struct {
+	ID         string       `json:"id" example:"1"`
+	Name       string       `json:"name" example:"Nowruz sale"`
+	Kind       string       `json:"kind" example:"percentage"`
+	Percent    *float64     `json:"percent,omitempty" example:"15"`
+	Amount     *money.Money `json:"amount,omitempty" example:"150000"`
+	Scope      string       `json:"scope" example:"category"`
+	ProductID  *string      `json:"product_id,omitempty" example:"1"`
+	CategoryID *string      `json:"category_id,omitempty" example:"1"`
+	StartsAt   time.Time    `json:"starts_at" example:"2025-03-15T00:00:00Z"`
+	EndsAt     *time.Time   `json:"ends_at,omitempty" example:"2025-04-02T00:00:00Z"`
+	CreatedAt  time.Time    `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	UpdatedAt  time.Time    `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
+
+// Pricing is the price a customer pays right now. Price on the product stays the original price.
+type Pricing struct {
+	EffectivePrice  money.Money `json:"effective_price" example:"169900"`
+	DiscountPercent *float64    `json:"discount_percent,omitempty" example:"15"`
+	SaleEndsAt      *time.Time  `json:"sale_ends_at,omitempty" example:"2025-04-02T00:00:00Z"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+const (
+	EventAccountCreated      = "account_created"
+	EventCommentReplied      = "comment_replied"
+	EventProductPriceDropped = "product_price_dropped"
+	EventProductLowStock     = "product_low_stock"
+	EventProductRestocked    = "product_restocked"
+)
+
+type Event struct {
+	Name       string
+	UserID     string
+	Payload    map[string]string
+	OccurredAt time.Time
+}
@@ -0,0 +1,46 @@
+package model
+
+const (
+	HomeSectionBanner     = "banner"
+	HomeSectionCollection = "collection"
+	HomeSectionNewest     = "newest"
+	HomeSectionBestRated  = "best_rated"
+	HomeSectionCategories = "categories"
+)
+
+// HomeSection is the stored configuration of one homepage section as admins edit it.
+type HomeSection struct {
+	ID           string       `json:"id" example:"1"`
+	Kind         string       `json:"kind" example:"collection"`
+	Title        string       `json:"title" example:"Black Friday picks"`
+	Position     int          `json:"position" example:"0"`
+	Hidden       bool         `json:"hidden" example:"false"`
+	CollectionID *string      `json:"collection_id,omitempty" example:"1"`
+	CategoryIDs  []string     `json:"category_ids,omitempty" example:"1,4"`
+	Limit        int          `json:"limit" example:"8"`
+	Banners      []HomeBanner `json:"banners,omitempty"`
+}
+
+type HomeBanner struct {
+	ID       string  `json:"id" example:"1"`
+	Image    string  `json:"image" example:"https://example.com/home/black-friday.jpg"`
+	Link     *string `json:"link,omitempty" example:"/collection/black-friday-picks"`
+	Alt      *string `json:"alt,omitempty" example:"Black Friday sale"`
+	Position int     `json:"position" example:"0"`
+}
+
+// Home is the resolved homepage, its visible sections in order with everything they show.
+type Home struct {
+	Sections []HomeBlock `json:"sections"`
+}
+
+// HomeBlock is a resolved section. Only the field matching its kind is set.
+type HomeBlock struct {
+	ID         string       `json:"id" example:"1"`
+	Kind       string       `json:"kind" example:"newest"`
+	Title      string       `json:"title" example:"New arrivals"`
+	Banners    []HomeBanner `json:"banners,omitempty"`
+	Collection *Collection  `json:"collection,omitempty"`
+	Products   []Products   `json:"products,omitempty"`
+	Categories []Category   `json:"categories,omitempty"`
+}
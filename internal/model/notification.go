@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+const (
+	NotificationChannelSms   = "sms"
+	NotificationChannelEmail = "email"
+)
+
+const (
+	NotificationStatusPending    = "pending"
+	NotificationStatusProcessing = "processing"
+	NotificationStatusSent       = "sent"
+	NotificationStatusDead       = "dead"
+)
+
+const (
+	NotificationPriorityNormal = 0
+	NotificationPriorityHigh   = 10
+)
+
+// NotificationRedactedMessage replaces the message of sensitive notifications wherever they are shown to admins.
+const NotificationRedactedMessage = "[redacted]"
+
+// Notification is a row of the outbox. Sensitive notifications carry one time codes, so their message only
+// leaves the outbox through delivery and is redacted everywhere else.
+type Notification struct {
+	ID            string     `json:"id" example:"1"`
+	UserID        *string    `json:"user_id,omitempty" example:"1"`
+	Event         *string    `json:"event,omitempty" example:"account_created"`
+	Channel       string     `json:"channel" example:"sms"`
+	Recipient     string     `json:"recipient" example:"+989029266635"`
+	Subject       *string    `json:"subject,omitempty" example:"welcome to squidshop"`
+	Message       string     `json:"message" example:"your order has shipped"`
+	Sensitive     bool       `json:"sensitive" example:"false"`
+	Priority      int        `json:"priority" example:"10"`
+	Status        string     `json:"status" example:"sent"`
+	Attempts      int        `json:"attempts" example:"1"`
+	MaxAttempts   int        `json:"max_attempts" example:"5"`
+	LastError     *string    `json:"last_error,omitempty" example:"sms provider returned status 502"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" example:"2025-09-12T00:12:12.123456789Z"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" example:"2025-09-12T00:14:12.123456789Z"`
+	SentAt        *time.Time `json:"sent_at,omitempty" example:"2025-09-12T00:12:13.123456789Z"`
+	CreatedAt     time.Time  `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	UpdatedAt     time.Time  `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
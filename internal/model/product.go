@@ -1,21 +1,39 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+const (
+	ProductStatusDraft     = "draft"
+	ProductStatusPublished = "published"
+	ProductStatusArchived  = "archived"
+)
 
 type Products struct {
-	ID               string    `json:"id" example:"1"`
-	Name             string    `json:"name" example:"Call of Duty black ops 4"`
-	Slug             string    `json:"slug" example:"call-of-duty-black-ops-4"`
-	Description      string    `json:"description" example:"Call of Duty black ops 4 is a first-person shooter game"`
-	ShortDescription string    `json:"short_description" example:"Call of Duty black ops 4 is a first-person shooter game"`
-	Price            float64   `json:"price" example:"19.99"`
-	Quantity         int       `json:"quantity" example:"10"`
-	CreatedAt        time.Time `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
-	UpdatedAt        time.Time `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
-	CategoryID       string    `json:"category_id" example:"1"`
-	AverageRating    float64   `json:"average_rating" example:"4.5"`
-	RatingCount      int       `json:"rating_count" example:"12"`
-	MainImage        *string   `json:"main_image,omitempty" example:"https://example.com/image.jpg"`
+	ID               string      `json:"id" example:"1"`
+	Name             string      `json:"name" example:"Call of Duty black ops 4"`
+	Slug             string      `json:"slug" example:"call-of-duty-black-ops-4"`
+	Description      string      `json:"description" example:"Call of Duty black ops 4 is a first-person shooter game"`
+	ShortDescription string      `json:"short_description" example:"Call of Duty black ops 4 is a first-person shooter game"`
+	Price            money.Money `json:"price" example:"199900"`
+	Quantity         int         `json:"quantity" example:"10"`
+	CreatedAt        time.Time   `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	UpdatedAt        time.Time   `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
+	CategoryID       *string     `json:"category_id" example:"1"`
+	BrandID          *string     `json:"brand_id" example:"1"`
+	Status           string      `json:"status" example:"published"`
+	PublishAt        *time.Time  `json:"publish_at,omitempty" example:"2025-09-12T00:12:12Z"`
+	UnpublishAt      *time.Time  `json:"unpublish_at,omitempty" example:"2025-10-12T00:12:12Z"`
+	PublishedAt      *time.Time  `json:"published_at,omitempty" example:"2025-09-12T00:12:12Z"`
+	AverageRating    float64     `json:"average_rating" example:"4.5"`
+	RatingCount      int         `json:"rating_count" example:"12"`
+	MainImage        *string     `json:"main_image,omitempty" example:"https://example.com/image.jpg"`
+	DeletedAt        *time.Time  `json:"deleted_at,omitempty" example:"2025-09-12T00:12:12.123456789Z"`
+	Wishlisted       *bool       `json:"wishlisted,omitempty" example:"true"`
+	Pricing
 }
 type Product struct {
 	ID               string         `json:"id" example:"1"`
@@ -23,12 +41,22 @@ type Product struct {
 	Slug             string         `json:"slug" example:"call-of-duty-black-ops-4"`
 	Description      string         `json:"description" example:"Call of Duty black ops 4 is a first-person shooter game"`
 	ShortDescription string         `json:"short_description" example:"Call of Duty black ops 4 is a first-person shooter game"`
-	Price            float64        `json:"price" example:"19.99"`
+	Price            money.Money    `json:"price" example:"199900"`
 	Quantity         int            `json:"quantity" example:"10"`
 	CreatedAt        time.Time      `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
 	UpdatedAt        time.Time      `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
-	CategoryID       string         `json:"category_id" example:"1"`
+	CategoryID       *string        `json:"category_id" example:"1"`
+	BrandID          *string        `json:"brand_id" example:"1"`
+	Status           string         `json:"status" example:"published"`
+	PublishAt        *time.Time     `json:"publish_at,omitempty" example:"2025-09-12T00:12:12Z"`
+	UnpublishAt      *time.Time     `json:"unpublish_at,omitempty" example:"2025-10-12T00:12:12Z"`
+	PublishedAt      *time.Time     `json:"published_at,omitempty" example:"2025-09-12T00:12:12Z"`
 	AverageRating    float64        `json:"average_rating" example:"4.5"`
 	RatingCount      int            `json:"rating_count" example:"12"`
 	Images           []ProductImage `json:"images,omitempty"`
+	Tags             []Tag          `json:"tags"`
+	LowestPrice30d   *money.Money   `json:"lowest_price_30d,omitempty" example:"179900"`
+	PriceHistory     []PriceHistory `json:"price_history,omitempty"`
+	Wishlisted       *bool          `json:"wishlisted,omitempty" example:"true"`
+	Pricing
 }
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type ProductRating struct {
+	ID        string    `json:"id" example:"1"`
+	ProductID string    `json:"product_id" example:"1"`
+	UserID    *string   `json:"user_id" example:"1"`
+	Rating    int       `json:"rating" example:"4"`
+	CreatedAt time.Time `json:"created_at" example:"2025-09-28T01:20:57+03:30"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type ProductRevision struct {
+	ID               string      `json:"id" example:"1"`
+	ProductID        string      `json:"product_id" example:"1"`
+	Revision         int         `json:"revision" example:"3"`
+	Name             string      `json:"name" example:"Call of Duty black ops 4"`
+	Slug             string      `json:"slug" example:"call-of-duty-black-ops-4"`
+	Description      string      `json:"description" example:"Call of Duty black ops 4 is a first-person shooter game"`
+	ShortDescription string      `json:"short_description" example:"Call of Duty black ops 4 is a first-person shooter game"`
+	Price            money.Money `json:"price" example:"199900"`
+	Quantity         int         `json:"quantity" example:"10"`
+	CategoryID       *string     `json:"category_id" example:"1"`
+	BrandID          *string     `json:"brand_id" example:"1"`
+	EditorID         *string     `json:"editor_id" example:"1"`
+	RolledBackFrom   *int        `json:"rolled_back_from,omitempty" example:"1"`
+	CreatedAt        time.Time   `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
+
+// ProductRevisionDiff holds only the fields that differ between two revisions.
+type ProductRevisionDiff struct {
+	From   int            `json:"from" example:"1"`
+	To     int            `json:"to" example:"3"`
+	Before map[string]any `json:"before"`
+	After  map[string]any `json:"after"`
+}
+
+type PriceHistory struct {
+	Price     money.Money `json:"price" example:"199900"`
+	ChangedAt time.Time   `json:"changed_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
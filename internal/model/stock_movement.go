@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	StockMovementRestock    = "restock"
+	StockMovementSale       = "sale"
+	StockMovementReturn     = "return"
+	StockMovementAdjustment = "adjustment"
+)
+
+// StockMovement is one line of the inventory ledger. The quantity of a product is the sum of its deltas.
+type StockMovement struct {
+	ID            string    `json:"id" example:"1"`
+	ProductID     string    `json:"product_id" example:"1"`
+	Kind          string    `json:"kind" example:"restock"`
+	Delta         int       `json:"delta" example:"20"`
+	QuantityAfter int       `json:"quantity_after" example:"32"`
+	Reason        *string   `json:"reason,omitempty" example:"supplier delivery"`
+	ActorID       *string   `json:"actor_id,omitempty" example:"1"`
+	CreatedAt     time.Time `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
+
+type LowStockProduct struct {
+	ID       string `json:"id" example:"1"`
+	Name     string `json:"name" example:"Call of Duty black ops 4"`
+	Slug     string `json:"slug" example:"call-of-duty-black-ops-4"`
+	Status   string `json:"status" example:"published"`
+	Quantity int    `json:"quantity" example:"2"`
+}
@@ -0,0 +1,8 @@
+package model
+
+// StockSubscriber is a user waiting for a product to come back in stock.
+type StockSubscriber struct {
+	SubscriptionID string
+	UserID         string
+	Phone          string
+}
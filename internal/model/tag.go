@@ -0,0 +1,13 @@
+package model
+
+type Tag struct {
+	ID   string `json:"id" example:"1"`
+	Name string `json:"name" example:"Multiplayer"`
+	Slug string `json:"slug" example:"multiplayer"`
+}
+
+// TagDetail is the storefront page of a tag with one page of its published products.
+type TagDetail struct {
+	Tag
+	Products []Products `json:"products"`
+}
@@ -3,8 +3,19 @@ package model
 import "time"
 
 type User struct {
-	ID        string    `json:"id" example:"1"`
-	Phone     string    `json:"phone" example:"+989029266635"`
-	CreatedAt time.Time `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
-	IsAdmin   bool      `json:"is_admin" example:"false"`
+	ID          string     `json:"id" example:"1"`
+	Phone       string     `json:"phone" example:"+989029266635"`
+	CreatedAt   time.Time  `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	IsAdmin     bool       `json:"is_admin" example:"false"`
+	Email       *string    `json:"email,omitempty" example:"user@squidshop.ir"`
+	NotifySms   bool       `json:"notify_sms" example:"true"`
+	NotifyEmail bool       `json:"notify_email" example:"false"`
+	FirstName   *string    `json:"first_name,omitempty" example:"Arsham"`
+	LastName    *string    `json:"last_name,omitempty" example:"Roshannejad"`
+	Birthday    *time.Time `json:"birthday,omitempty" example:"2001-03-21T00:00:00Z"`
+	NationalID  *string    `json:"national_id,omitempty" example:"0499370899"`
+	IsBanned    bool       `json:"is_banned" example:"false"`
+	BannedAt    *time.Time `json:"banned_at,omitempty" example:"2025-09-12T00:12:12.123456789Z"`
+	// SessionVersion is embedded in issued tokens, bumping it revokes all of them.
+	SessionVersion int `json:"-"`
 }
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type UserAddress struct {
+	ID            string    `json:"id" example:"1"`
+	UserID        string    `json:"user_id" example:"1"`
+	Title         string    `json:"title" example:"home"`
+	ReceiverName  string    `json:"receiver_name" example:"Arsham Roshannejad"`
+	ReceiverPhone string    `json:"receiver_phone" example:"+989029266635"`
+	Province      string    `json:"province" example:"Tehran"`
+	City          string    `json:"city" example:"Tehran"`
+	PostalCode    string    `json:"postal_code" example:"1439951314"`
+	Address       string    `json:"address" example:"No. 12, Azadi St."`
+	IsDefault     bool      `json:"is_default" example:"true"`
+	CreatedAt     time.Time `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
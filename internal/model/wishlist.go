@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// WishlistShare is the public link to a wishlist. Anyone with the token can read the wishlist.
+type WishlistShare struct {
+	Token     string    `json:"token" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	URL       string    `json:"url" example:"/api/v1/wishlist/shared/9f86d081884c7d659a2feaa0c55ad015"`
+	CreatedAt time.Time `json:"created_at" example:"2025-09-12T00:12:12.123456789Z"`
+}
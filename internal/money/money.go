@@ -0,0 +1,134 @@
+// Package money keeps amounts as whole rials so prices add up exactly. Rial is the only unit stored,
+// toman is the unit customers read and is worth ten rial.
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Currency string
+
+const (
+	Rial  Currency = "IRR"
+	Toman Currency = "IRT"
+)
+
+const rialsPerToman = 10
+
+// Money is an amount in rial. It goes to and from JSON and the database as a plain integer.
+type Money int64
+
+func FromToman(toman int64) Money {
+	return Money(toman * rialsPerToman)
+}
+
+// Toman converts to toman rounded to the nearest toman.
+func (m Money) Toman() int64 {
+	return int64(math.Round(float64(m) / rialsPerToman))
+}
+
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Percent returns percent of m rounded to the nearest rial.
+func (m Money) Percent(percent float64) Money {
+	return Money(math.Round(float64(m) * percent / 100))
+}
+
+// In returns the amount in the given currency, rial for anything that is not toman.
+func (m Money) In(currency Currency) int64 {
+	if currency == Toman {
+		return m.Toman()
+	}
+	return int64(m)
+}
+
+// Format writes the amount in the given currency with Persian digits and thousands separators, e.g. ۱۲٬۵۰۰ تومان.
+func (m Money) Format(currency Currency) string {
+	unit := "ریال"
+	if currency == Toman {
+		unit = "تومان"
+	}
+	return PersianDigits(group(m.In(currency))) + " " + unit
+}
+
+func (m Money) String() string {
+	return strconv.FormatInt(int64(m), 10) + " " + string(Rial)
+}
+
+// UnmarshalJSON takes a whole number of rials either as a JSON number or as a string. Strings may use Persian or
+// Arabic digits and thousands separators, which is what admin forms typed on a Persian keyboard send.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := string(bytes.TrimSpace(data))
+	if raw == "null" {
+		return nil
+	}
+	if strings.HasPrefix(raw, `"`) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		raw = normalize(text)
+	}
+	amount, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("money must be a whole number of rials, got %s", data)
+	}
+	*m = Money(amount)
+	return nil
+}
+
+// PersianDigits replaces ASCII digits and the thousands separator with their Persian forms.
+func PersianDigits(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune('۰' + r - '0')
+		case r == ',':
+			b.WriteRune('٬')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// normalize turns Persian and Arabic digits into ASCII and drops thousands separators and spaces.
+func normalize(text string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(text) {
+		switch {
+		case r >= '۰' && r <= '۹':
+			b.WriteRune('0' + r - '۰')
+		case r >= '٠' && r <= '٩':
+			b.WriteRune('0' + r - '٠')
+		case r == ',' || r == '٬' || r == '،' || r == ' ':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func group(amount int64) string {
+	digits := strconv.FormatInt(amount, 10)
+	sign := ""
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String()
+}
@@ -0,0 +1,63 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		currency Currency
+		expected string
+	}{
+		{name: "rial", amount: 1250000, currency: Rial, expected: "۱٬۲۵۰٬۰۰۰ ریال"},
+		{name: "toman", amount: 1250000, currency: Toman, expected: "۱۲۵٬۰۰۰ تومان"},
+		{name: "toman rounds to the nearest toman", amount: 1255, currency: Toman, expected: "۱۲۶ تومان"},
+		{name: "small amount", amount: 950, currency: Rial, expected: "۹۵۰ ریال"},
+		{name: "negative", amount: -1000, currency: Rial, expected: "-۱٬۰۰۰ ریال"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.amount.Format(tt.currency))
+		})
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Money
+		wantErr  bool
+	}{
+		{name: "number", input: `1250000`, expected: 1250000},
+		{name: "string", input: `"1250000"`, expected: 1250000},
+		{name: "persian digits with separators", input: `"۱٬۲۵۰٬۰۰۰"`, expected: 1250000},
+		{name: "arabic digits", input: `"١٢٥٠"`, expected: 1250},
+		{name: "fractions are rejected", input: `19.99`, wantErr: true},
+		{name: "text is rejected", input: `"free"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := json.Unmarshal([]byte(tt.input), &m)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestMoneyPercent(t *testing.T) {
+	assert.Equal(t, Money(1500), Money(10000).Percent(15))
+	assert.Equal(t, Money(333), Money(999).Percent(33.3))
+	assert.Equal(t, Money(30000), Money(10000).Mul(3))
+	assert.Equal(t, Money(125000), FromToman(12500))
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+const auditLogColumns = "id, actor_id, action, entity_type, entity_id, before, after, ip, request_id, created_at"
+
+type auditRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) domain.AuditRepository {
+	return &auditRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *auditRepositoryImpl) GetAll(ctx context.Context, params *entity.AuditLogQueryParamRequest) ([]model.AuditLog, error) {
+	conditions := make([]string, 0, 6)
+	args := make([]any, 0, 8)
+	if params.ActorID != "" {
+		args = append(args, params.ActorID)
+		conditions = append(conditions, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+	if params.Action != "" {
+		args = append(args, params.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+	if params.EntityType != "" {
+		args = append(args, params.EntityType)
+		conditions = append(conditions, fmt.Sprintf("entity_type = $%d", len(args)))
+	}
+	if params.EntityID != "" {
+		args = append(args, params.EntityID)
+		conditions = append(conditions, fmt.Sprintf("entity_id = $%d", len(args)))
+	}
+	if params.From != "" {
+		args = append(args, params.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d::date", len(args)))
+	}
+	if params.To != "" {
+		args = append(args, params.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d::date + 1", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, params.Limit, params.Offset)
+	query := fmt.Sprintf(
+		"SELECT %s FROM audit_log %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
+		auditLogColumns, where, len(args)-1, len(args),
+	)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	auditLogs := make([]model.AuditLog, 0)
+	for rows.Next() {
+		var auditLog model.AuditLog
+		var before, after []byte
+		err := rows.Scan(
+			&auditLog.ID,
+			&auditLog.ActorID,
+			&auditLog.Action,
+			&auditLog.EntityType,
+			&auditLog.EntityID,
+			&before,
+			&after,
+			&auditLog.IP,
+			&auditLog.RequestID,
+			&auditLog.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		auditLog.Before = before
+		auditLog.After = after
+		auditLogs = append(auditLogs, auditLog)
+	}
+	return auditLogs, rows.Err()
+}
+
+func (r *auditRepositoryImpl) Create(ctx context.Context, entry *entity.AuditLogCreateRequest) error {
+	const createAuditLogQuery string = `
+		INSERT INTO audit_log (actor_id, action, entity_type, entity_id, before, after, ip, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	before, err := marshalAuditState(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalAuditState(entry.After)
+	if err != nil {
+		return err
+	}
+	args := []any{entry.ActorID, entry.Action, entry.EntityType, entry.EntityID, before, after, entry.IP, entry.RequestID}
+	_, err = r.db.ExecContext(ctx, createAuditLogQuery, args...)
+	return err
+}
+
+// marshalAuditState encodes a snapshot for a JSONB column, keeping a missing snapshot as NULL.
+func marshalAuditState(state any) (any, error) {
+	if state == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/gosimple/slug"
+)
+
+type brandRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewBrandRepository(db *sql.DB) domain.BrandRepository {
+	return &brandRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *brandRepositoryImpl) GetAll(ctx context.Context) ([]model.Brand, error) {
+	const getAllBrandsQuery string = "SELECT id, name, slug, logo, description, created_at, updated_at FROM brands ORDER BY name"
+	rows, err := r.db.QueryContext(ctx, getAllBrandsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	brands := make([]model.Brand, 0)
+	for rows.Next() {
+		var brand model.Brand
+		err := rows.Scan(
+			&brand.ID,
+			&brand.Name,
+			&brand.Slug,
+			&brand.Logo,
+			&brand.Description,
+			&brand.CreatedAt,
+			&brand.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		brands = append(brands, brand)
+	}
+	return brands, rows.Err()
+}
+
+func (r *brandRepositoryImpl) GetByID(ctx context.Context, brandID string) (*model.Brand, error) {
+	const getBrandByIDQuery string = "SELECT id, name, slug, logo, description, created_at, updated_at FROM brands WHERE id = $1"
+	args := []any{brandID}
+	return collectBrandRow(r.db.QueryRowContext(ctx, getBrandByIDQuery, args...))
+}
+
+func (r *brandRepositoryImpl) GetBySlug(ctx context.Context, brandSlug string) (*model.Brand, error) {
+	const getBrandBySlugQuery string = "SELECT id, name, slug, logo, description, created_at, updated_at FROM brands WHERE slug = $1"
+	args := []any{brandSlug}
+	return collectBrandRow(r.db.QueryRowContext(ctx, getBrandBySlugQuery, args...))
+}
+
+func (r *brandRepositoryImpl) Create(ctx context.Context, brand *entity.BrandRequest) (string, error) {
+	const createBrandQuery string = `
+		INSERT INTO brands (name, slug, logo, description)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING id
+	`
+	args := []any{brand.Name, slug.Make(brand.Slug), brand.Logo, brand.Description}
+	var brandID string
+	if err := r.db.QueryRowContext(ctx, createBrandQuery, args...).Scan(&brandID); err != nil {
+		return "", mapUniqueViolation(err)
+	}
+	return brandID, nil
+}
+
+func (r *brandRepositoryImpl) Update(ctx context.Context, brandID string, brand *entity.BrandRequest) error {
+	const updateBrandQuery string = `
+		UPDATE brands SET
+		    name = $1,
+		    slug = $2,
+		    description = NULLIF($3, ''),
+		    logo = CASE WHEN $4 THEN NULL ELSE COALESCE($5, logo) END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+	`
+	args := []any{brand.Name, slug.Make(brand.Slug), brand.Description, brand.RemoveLogo, brand.Logo, brandID}
+	result, err := r.db.ExecContext(ctx, updateBrandQuery, args...)
+	if err != nil {
+		return mapUniqueViolation(err)
+	}
+	return requireAffected(result)
+}
+
+// Delete removes the brand for good, its products stay and lose their brand.
+func (r *brandRepositoryImpl) Delete(ctx context.Context, brandID string) error {
+	const deleteBrandQuery string = "DELETE FROM brands WHERE id = $1"
+	args := []any{brandID}
+	result, err := r.db.ExecContext(ctx, deleteBrandQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func collectBrandRow(row *sql.Row) (*model.Brand, error) {
+	var brand model.Brand
+	err := row.Scan(
+		&brand.ID,
+		&brand.Name,
+		&brand.Slug,
+		&brand.Logo,
+		&brand.Description,
+		&brand.CreatedAt,
+		&brand.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &brand, nil
+}
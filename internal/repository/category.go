@@ -3,11 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/gosimple/slug"
+	"github.com/lib/pq"
 )
 
 type categoryRepositoryImpl struct {
@@ -20,8 +24,9 @@ func NewCategoryRepository(db *sql.DB) domain.CategoryRepository {
 	}
 }
 
+// GetAll returns the live categories as a tree, siblings ordered by position and then name.
 func (r *categoryRepositoryImpl) GetAll(ctx context.Context) ([]model.Category, error) {
-	const query = `SELECT id, name, slug, parent_id FROM categories`
+	const query = `SELECT id, name, slug, position, parent_id, path FROM categories WHERE deleted_at IS NULL ORDER BY position, name`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -31,33 +36,425 @@ func (r *categoryRepositoryImpl) GetAll(ctx context.Context) ([]model.Category,
 	if err != nil {
 		return nil, err
 	}
-	tree := buildCategoryTree(*categories, nil)
+	tree := buildCategoryTree(*categories)
 	return tree, nil
 }
 
-func (r *categoryRepositoryImpl) Create(ctx context.Context, category *entity.CategoryCreateRequest) error {
-	const createCategoryQuery string = "INSERT INTO categories (name, slug, parent_id) VALUES ($1, $2, $3)"
-	args := []any{category.Name, slug.Make(category.Slug), category.ParentID}
-	_, err := r.db.ExecContext(ctx, createCategoryQuery, args...)
-	return err
+func (r *categoryRepositoryImpl) GetByID(ctx context.Context, categoryID string) (*model.Category, error) {
+	const getCategoryByIDQuery string = `
+		SELECT id, name, slug, position, description, banner_image, seo_title, meta_description, parent_id, path
+		FROM categories
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	args := []any{categoryID}
+	var category model.Category
+	err := r.db.QueryRowContext(ctx, getCategoryByIDQuery, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.Slug,
+		&category.Position,
+		&category.Description,
+		&category.BannerImage,
+		&category.SeoTitle,
+		&category.MetaDescription,
+		&category.ParentID,
+		&category.Path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetBySlug returns the storefront fields of a live category, without its sub categories and products.
+func (r *categoryRepositoryImpl) GetBySlug(ctx context.Context, categorySlug string) (*model.CategoryDetail, error) {
+	const getCategoryBySlugQuery string = `
+		SELECT id, name, slug, description, banner_image, seo_title, meta_description, path
+		FROM categories
+		WHERE slug = $1 AND deleted_at IS NULL
+	`
+	args := []any{categorySlug}
+	var category model.CategoryDetail
+	err := r.db.QueryRowContext(ctx, getCategoryBySlugQuery, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.Slug,
+		&category.Description,
+		&category.BannerImage,
+		&category.SeoTitle,
+		&category.MetaDescription,
+		&category.Path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetChildren lists the live direct sub categories, ordered like the tree.
+func (r *categoryRepositoryImpl) GetChildren(ctx context.Context, categoryID string) ([]model.Category, error) {
+	const getCategoryChildrenQuery string = `
+		SELECT id, name, slug, position, parent_id, path
+		FROM categories
+		WHERE parent_id = $1 AND deleted_at IS NULL
+		ORDER BY position, name
+	`
+	args := []any{categoryID}
+	rows, err := r.db.QueryContext(ctx, getCategoryChildrenQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	categories, err := collectCategoryRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if *categories == nil {
+		return make([]model.Category, 0), nil
+	}
+	return *categories, nil
+}
+
+// GetByIDs lists the live categories among the given ids in the order of the ids, with their storefront fields.
+func (r *categoryRepositoryImpl) GetByIDs(ctx context.Context, categoryIDs []string) ([]model.Category, error) {
+	const getCategoriesByIDsQuery string = `
+		SELECT id, name, slug, position, description, banner_image, seo_title, meta_description, parent_id, path
+		FROM categories
+		WHERE id = ANY($1::int[]) AND deleted_at IS NULL
+		ORDER BY array_position($1::int[], id)
+	`
+	args := []any{pq.Array(categoryIDs)}
+	rows, err := r.db.QueryContext(ctx, getCategoriesByIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	categories := make([]model.Category, 0)
+	for rows.Next() {
+		var category model.Category
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.Slug,
+			&category.Position,
+			&category.Description,
+			&category.BannerImage,
+			&category.SeoTitle,
+			&category.MetaDescription,
+			&category.ParentID,
+			&category.Path,
+		)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
 }
 
+// GetBreadcrumb lists the live category and its ancestors, top level first. The ancestors are read off the path.
+func (r *categoryRepositoryImpl) GetBreadcrumb(ctx context.Context, categoryID string) ([]model.Breadcrumb, error) {
+	const getCategoryBreadcrumbQuery string = `
+		SELECT
+		    c.id,
+		    c.name,
+		    c.slug
+		FROM
+		    categories t
+		JOIN
+		    categories c ON c.id = ANY(string_to_array(trim(BOTH '/' FROM t.path), '/')::int[])
+		WHERE
+		    t.id = $1 AND t.deleted_at IS NULL
+		ORDER BY
+		    length(c.path)
+	`
+	args := []any{categoryID}
+	rows, err := r.db.QueryContext(ctx, getCategoryBreadcrumbQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	breadcrumb := make([]model.Breadcrumb, 0)
+	for rows.Next() {
+		crumb := model.Breadcrumb{Kind: model.BreadcrumbKindCategory}
+		if err := rows.Scan(&crumb.ID, &crumb.Name, &crumb.Slug); err != nil {
+			return nil, err
+		}
+		breadcrumb = append(breadcrumb, crumb)
+	}
+	return breadcrumb, rows.Err()
+}
+
+// Create inserts the category below a live parent, sql.ErrNoRows when the parent is missing or trashed.
+func (r *categoryRepositoryImpl) Create(ctx context.Context, category *entity.CategoryCreateRequest) (string, error) {
+	const createCategoryQuery string = `
+		INSERT INTO categories (name, slug, parent_id, position, description, seo_title, meta_description, banner_image, path)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, '')
+		RETURNING id
+	`
+	const setCategoryPathQuery string = "UPDATE categories SET path = $2 || id || '/' WHERE id = $1"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+	parentPath := "/"
+	if category.ParentID != nil {
+		if parentPath, err = lockCategoryPath(ctx, tx, strconv.Itoa(*category.ParentID)); err != nil {
+			return "", err
+		}
+	}
+	args := []any{
+		category.Name,
+		slug.Make(category.Slug),
+		category.ParentID,
+		category.Position,
+		category.Description,
+		category.SeoTitle,
+		category.MetaDescription,
+		category.BannerImage,
+	}
+	var categoryID string
+	if err := tx.QueryRowContext(ctx, createCategoryQuery, args...).Scan(&categoryID); err != nil {
+		return "", mapUniqueViolation(err)
+	}
+	if _, err := tx.ExecContext(ctx, setCategoryPathQuery, categoryID, parentPath); err != nil {
+		return "", err
+	}
+	return categoryID, tx.Commit()
+}
+
+// GetAllDeleted lists the trash flat, most recently deleted first.
+func (r *categoryRepositoryImpl) GetAllDeleted(ctx context.Context, limit, offset int) ([]model.Category, error) {
+	const getDeletedCategoriesQuery string = `
+		SELECT id, name, slug, position, parent_id, path, deleted_at
+		FROM categories
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+	args := []any{limit, offset}
+	rows, err := r.db.QueryContext(ctx, getDeletedCategoriesQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	categories := make([]model.Category, 0)
+	for rows.Next() {
+		var category model.Category
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.Slug,
+			&category.Position,
+			&category.ParentID,
+			&category.Path,
+			&category.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+// Update edits the category and moves it with its subtree when the parent changes. Moving a category under
+// itself or one of its descendants is refused with domain.ErrCategoryCycle.
 func (r *categoryRepositoryImpl) Update(ctx context.Context, categoryID string, category *entity.CategoryUpdateRequest) error {
-	const updateCategoryQuery string = "UPDATE categories SET name = $1, slug = $2, parent_id = $3 WHERE id = $4"
-	args := []any{category.Name, slug.Make(category.Slug), category.ParentID, categoryID}
-	_, err := r.db.ExecContext(ctx, updateCategoryQuery, args...)
-	return err
+	const updateCategoryQuery string = `
+		UPDATE categories SET
+		    name = $1,
+		    slug = $2,
+		    parent_id = $3,
+		    position = $4,
+		    description = NULLIF($5, ''),
+		    seo_title = NULLIF($6, ''),
+		    meta_description = NULLIF($7, ''),
+		    banner_image = CASE WHEN $8 THEN NULL ELSE COALESCE($9, banner_image) END
+		WHERE id = $10
+	`
+	const moveCategorySubtreeQuery string = "UPDATE categories SET path = $2 || substr(path, length($1) + 1) WHERE path LIKE $1 || '%'"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	oldPath, err := lockCategoryPath(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+	parentPath := "/"
+	if category.ParentID != nil {
+		if parentPath, err = lockCategoryPath(ctx, tx, strconv.Itoa(*category.ParentID)); err != nil {
+			return err
+		}
+		if strings.HasPrefix(parentPath, oldPath) {
+			return domain.ErrCategoryCycle
+		}
+	}
+	args := []any{
+		category.Name,
+		slug.Make(category.Slug),
+		category.ParentID,
+		category.Position,
+		category.Description,
+		category.SeoTitle,
+		category.MetaDescription,
+		category.RemoveBanner,
+		category.BannerImage,
+		categoryID,
+	}
+	if _, err := tx.ExecContext(ctx, updateCategoryQuery, args...); err != nil {
+		return mapUniqueViolation(err)
+	}
+	if newPath := parentPath + categoryID + "/"; newPath != oldPath {
+		if _, err := tx.ExecContext(ctx, moveCategorySubtreeQuery, oldPath, newPath); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-func (r *categoryRepositoryImpl) Delete(ctx context.Context, categoryID string) error {
-	const deleteCategoryQuery string = "DELETE FROM categories WHERE id = $1"
+// GetImpact counts the live sub categories and products below a live category.
+func (r *categoryRepositoryImpl) GetImpact(ctx context.Context, categoryID string) (*model.CategoryImpact, error) {
+	const getCategoryImpactQuery string = `
+		SELECT
+		    (SELECT COUNT(*) FROM categories d WHERE d.path LIKE c.path || '%' AND d.id <> c.id AND d.deleted_at IS NULL),
+		    (
+		        SELECT COUNT(*)
+		        FROM products p
+		        JOIN categories d ON d.id = p.category_id
+		        WHERE d.path LIKE c.path || '%' AND d.deleted_at IS NULL AND p.deleted_at IS NULL
+		    )
+		FROM
+		    categories c
+		WHERE
+		    c.id = $1 AND c.deleted_at IS NULL
+	`
 	args := []any{categoryID}
-	_, err := r.db.ExecContext(ctx, deleteCategoryQuery, args...)
-	return err
+	var impact model.CategoryImpact
+	if err := r.db.QueryRowContext(ctx, getCategoryImpactQuery, args...).Scan(&impact.SubCategories, &impact.Products); err != nil {
+		return nil, err
+	}
+	return &impact, nil
+}
+
+// Delete moves the category and its sub categories to the trash. It refuses with domain.ErrCategoryInUse while
+// live products are filed anywhere in the subtree, those have to be moved or merged away first.
+func (r *categoryRepositoryImpl) Delete(ctx context.Context, categoryID string) error {
+	const countCategoryProductsQuery string = `
+		SELECT COUNT(*)
+		FROM products p
+		JOIN categories d ON d.id = p.category_id
+		WHERE d.path LIKE $1 || '%' AND d.deleted_at IS NULL AND p.deleted_at IS NULL
+	`
+	const deleteCategorySubtreeQuery string = "UPDATE categories SET deleted_at = CURRENT_TIMESTAMP WHERE deleted_at IS NULL AND path LIKE $1 || '%'"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	path, err := lockCategoryPath(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+	var products int
+	if err := tx.QueryRowContext(ctx, countCategoryProductsQuery, path).Scan(&products); err != nil {
+		return err
+	}
+	if products > 0 {
+		return domain.ErrCategoryInUse
+	}
+	if _, err := tx.ExecContext(ctx, deleteCategorySubtreeQuery, path); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Merge moves the sub categories and products of a category to the target and trashes the emptied category.
+// Trashed sub categories and products move as well so restoring them never lands under a trashed parent.
+// The target cannot be the category itself or one of its descendants.
+func (r *categoryRepositoryImpl) Merge(ctx context.Context, categoryID, targetID string) error {
+	const moveCategoryChildrenQuery string = "UPDATE categories SET parent_id = $2 WHERE parent_id = $1"
+	const moveCategoryDescendantsQuery string = `
+		UPDATE categories SET path = $2 || substr(path, length($1) + 1) WHERE path LIKE $1 || '%' AND id <> $3
+	`
+	const moveCategoryProductsQuery string = "UPDATE products SET category_id = $2 WHERE category_id = $1"
+	const deleteCategoryQuery string = "UPDATE categories SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	path, err := lockCategoryPath(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+	targetPath, err := lockCategoryPath(ctx, tx, targetID)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(targetPath, path) {
+		return domain.ErrCategoryCycle
+	}
+	if _, err := tx.ExecContext(ctx, moveCategoryChildrenQuery, categoryID, targetID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, moveCategoryDescendantsQuery, path, targetPath, categoryID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, moveCategoryProductsQuery, categoryID, targetID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, deleteCategoryQuery, categoryID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Restore brings back a trashed category together with the sub categories that were trashed with it.
+// Sub categories deleted on their own earlier stay in the trash, and a category whose parent is still trashed
+// cannot be restored on its own.
+func (r *categoryRepositoryImpl) Restore(ctx context.Context, categoryID string) error {
+	const getTrashedCategoryQuery string = `
+		SELECT t.path, t.deleted_at, p.deleted_at IS NOT NULL
+		FROM categories t
+		LEFT JOIN categories p ON p.id = t.parent_id
+		WHERE t.id = $1
+		FOR UPDATE OF t
+	`
+	const restoreCategorySubtreeQuery string = "UPDATE categories SET deleted_at = NULL WHERE path LIKE $1 || '%' AND deleted_at = $2"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var path string
+	var deletedAt sql.NullTime
+	var parentDeleted bool
+	if err := tx.QueryRowContext(ctx, getTrashedCategoryQuery, categoryID).Scan(&path, &deletedAt, &parentDeleted); err != nil {
+		return err
+	}
+	if !deletedAt.Valid {
+		return sql.ErrNoRows
+	}
+	if parentDeleted {
+		return domain.ErrParentDeleted
+	}
+	args := []any{path, deletedAt.Time}
+	if _, err := tx.ExecContext(ctx, restoreCategorySubtreeQuery, args...); err != nil {
+		return mapUniqueViolation(err)
+	}
+	return tx.Commit()
+}
+
+func (r *categoryRepositoryImpl) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purgeDeleted(ctx, r.db, "categories", before)
 }
 
 func (r *categoryRepositoryImpl) Exists(ctx context.Context, category *entity.CategoryQueryParamRequest) (bool, error) {
-	const existsCategoryQuery string = "SELECT EXISTS (SELECT 1 FROM categories WHERE name = $1 OR slug = $2)"
+	const existsCategoryQuery string = "SELECT EXISTS (SELECT 1 FROM categories WHERE (name = $1 OR slug = $2) AND deleted_at IS NULL)"
 	args := []any{category.Name, category.Slug}
 	var exists bool
 	if err := r.db.QueryRowContext(ctx, existsCategoryQuery, args...).Scan(&exists); err != nil {
@@ -74,24 +471,45 @@ func collectCategoryRows(rows *sql.Rows) (*[]model.Category, error) {
 			&category.ID,
 			&category.Name,
 			&category.Slug,
+			&category.Position,
 			&category.ParentID,
+			&category.Path,
 		)
 		if err != nil {
 			return nil, err
 		}
 		categories = append(categories, category)
 	}
-	return &categories, nil
+	return &categories, rows.Err()
 }
 
-func buildCategoryTree(categories []model.Category, parentID *string) []model.Category {
-	tree := make([]model.Category, 0)
+// buildCategoryTree nests the flat list in one pass over it, keeping the order of the list among siblings.
+// Categories whose parent is not in the list are left out.
+func buildCategoryTree(categories []model.Category) []model.Category {
+	children := make(map[string][]model.Category)
+	roots := make([]model.Category, 0)
 	for _, c := range categories {
-		if (c.ParentID == nil && parentID == nil) ||
-			(c.ParentID != nil && parentID != nil && *c.ParentID == *parentID) {
-			c.SubCategories = buildCategoryTree(categories, &c.ID)
-			tree = append(tree, c)
+		if c.ParentID == nil {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+	var attach func(nodes []model.Category) []model.Category
+	attach = func(nodes []model.Category) []model.Category {
+		for i := range nodes {
+			nodes[i].SubCategories = attach(children[nodes[i].ID])
 		}
+		return nodes
 	}
-	return tree
+	return attach(roots)
+}
+
+// lockCategoryPath locks a live category and returns its path, sql.ErrNoRows when it is missing or trashed.
+func lockCategoryPath(ctx context.Context, tx *sql.Tx, categoryID string) (string, error) {
+	const lockCategoryQuery string = "SELECT path FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE"
+	args := []any{categoryID}
+	var path string
+	err := tx.QueryRowContext(ctx, lockCategoryQuery, args...).Scan(&path)
+	return path, err
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	parent := func(id string) *string { return &id }
+	categories := []model.Category{
+		{ID: "3", Name: "Phones", ParentID: parent("1")},
+		{ID: "1", Name: "Electronics"},
+		{ID: "4", Name: "Laptops", ParentID: parent("1")},
+		{ID: "5", Name: "Gaming", ParentID: parent("4")},
+		{ID: "2", Name: "Books"},
+		{ID: "7", Name: "Orphan", ParentID: parent("6")},
+	}
+	expected := []model.Category{
+		{ID: "1", Name: "Electronics", SubCategories: []model.Category{
+			{ID: "3", Name: "Phones", ParentID: parent("1")},
+			{ID: "4", Name: "Laptops", ParentID: parent("1"), SubCategories: []model.Category{
+				{ID: "5", Name: "Gaming", ParentID: parent("4")},
+			}},
+		}},
+		{ID: "2", Name: "Books"},
+	}
+	assert.Equal(t, expected, buildCategoryTree(categories))
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/gosimple/slug"
+	"github.com/lib/pq"
+)
+
+// collectionColumns reads a rule as stale once a category, brand or tag it was saved with is deleted, the foreign
+// keys only null the id out.
+const collectionColumns = "id, name, slug, description, mode, rule_category_id, rule_brand_id, rule_tag_id, rule_min_price, rule_max_price, " +
+	"(rule_has_category AND rule_category_id IS NULL) OR (rule_has_brand AND rule_brand_id IS NULL) OR (rule_has_tag AND rule_tag_id IS NULL), " +
+	"created_at, updated_at"
+
+type collectionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCollectionRepository(db *sql.DB) domain.CollectionRepository {
+	return &collectionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *collectionRepositoryImpl) GetAll(ctx context.Context) ([]model.Collection, error) {
+	const getAllCollectionsQuery = `SELECT ` + collectionColumns + ` FROM collections ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, getAllCollectionsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	collections := make([]model.Collection, 0)
+	for rows.Next() {
+		collection, err := collectCollectionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, *collection)
+	}
+	return collections, rows.Err()
+}
+
+func (r *collectionRepositoryImpl) GetByID(ctx context.Context, collectionID string) (*model.Collection, error) {
+	const getCollectionByIDQuery = `SELECT ` + collectionColumns + ` FROM collections WHERE id = $1`
+	args := []any{collectionID}
+	return collectCollectionRow(r.db.QueryRowContext(ctx, getCollectionByIDQuery, args...))
+}
+
+func (r *collectionRepositoryImpl) GetBySlug(ctx context.Context, collectionSlug string) (*model.Collection, error) {
+	const getCollectionBySlugQuery = `SELECT ` + collectionColumns + ` FROM collections WHERE slug = $1`
+	args := []any{collectionSlug}
+	return collectCollectionRow(r.db.QueryRowContext(ctx, getCollectionBySlugQuery, args...))
+}
+
+// GetProductIDs lists the hand picked members of a collection in their order, trashed products included.
+func (r *collectionRepositoryImpl) GetProductIDs(ctx context.Context, collectionID string) ([]string, error) {
+	const getCollectionProductIDsQuery string = "SELECT product_id FROM collection_products WHERE collection_id = $1 ORDER BY position"
+	args := []any{collectionID}
+	rows, err := r.db.QueryContext(ctx, getCollectionProductIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectIDRows(rows)
+}
+
+// Create inserts the collection, sql.ErrNoRows when the rule points at a missing category, brand or tag.
+func (r *collectionRepositoryImpl) Create(ctx context.Context, collection *entity.CollectionRequest) (string, error) {
+	const createCollectionQuery string = `
+		INSERT INTO collections (
+		    name, slug, description, mode, rule_category_id, rule_brand_id, rule_tag_id, rule_min_price, rule_max_price,
+		    rule_has_category, rule_has_brand, rule_has_tag
+		)
+		VALUES (
+		    $1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9,
+		    $5::INTEGER IS NOT NULL, $6::INTEGER IS NOT NULL, $7::INTEGER IS NOT NULL
+		)
+		RETURNING id
+	`
+	args := append([]any{collection.Name, slug.Make(collection.Slug), collection.Description, collection.Mode}, collectionRuleArgs(collection.Rule)...)
+	var collectionID string
+	if err := r.db.QueryRowContext(ctx, createCollectionQuery, args...).Scan(&collectionID); err != nil {
+		return "", mapForeignKeyViolation(mapUniqueViolation(err))
+	}
+	return collectionID, nil
+}
+
+// Update overwrites the collection. Switching to the rule mode keeps the hand picked members so switching back
+// brings them back.
+func (r *collectionRepositoryImpl) Update(ctx context.Context, collectionID string, collection *entity.CollectionRequest) error {
+	const updateCollectionQuery string = `
+		UPDATE collections SET
+		    name = $1,
+		    slug = $2,
+		    description = NULLIF($3, ''),
+		    mode = $4,
+		    rule_category_id = $5,
+		    rule_brand_id = $6,
+		    rule_tag_id = $7,
+		    rule_min_price = $8,
+		    rule_max_price = $9,
+		    rule_has_category = $5::INTEGER IS NOT NULL,
+		    rule_has_brand = $6::INTEGER IS NOT NULL,
+		    rule_has_tag = $7::INTEGER IS NOT NULL,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $10
+	`
+	args := append([]any{collection.Name, slug.Make(collection.Slug), collection.Description, collection.Mode}, collectionRuleArgs(collection.Rule)...)
+	args = append(args, collectionID)
+	result, err := r.db.ExecContext(ctx, updateCollectionQuery, args...)
+	if err != nil {
+		return mapForeignKeyViolation(mapUniqueViolation(err))
+	}
+	return requireAffected(result)
+}
+
+func (r *collectionRepositoryImpl) Delete(ctx context.Context, collectionID string) error {
+	const deleteCollectionQuery string = "DELETE FROM collections WHERE id = $1"
+	args := []any{collectionID}
+	result, err := r.db.ExecContext(ctx, deleteCollectionQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// ReplaceProducts swaps the members of a manual collection for productIDs, keeping their order. A rule based
+// collection is refused with domain.ErrCollectionRuleBased.
+func (r *collectionRepositoryImpl) ReplaceProducts(ctx context.Context, collectionID string, productIDs []int) error {
+	const lockCollectionQuery string = "SELECT mode FROM collections WHERE id = $1 FOR UPDATE"
+	const deleteCollectionProductsQuery string = "DELETE FROM collection_products WHERE collection_id = $1"
+	const createCollectionProductsQuery string = `
+		INSERT INTO collection_products (collection_id, product_id, position)
+		SELECT $1, member.id, member.position FROM unnest($2::int[]) WITH ORDINALITY AS member(id, position)
+	`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var mode string
+	if err := tx.QueryRowContext(ctx, lockCollectionQuery, collectionID).Scan(&mode); err != nil {
+		return err
+	}
+	if mode != model.CollectionModeManual {
+		return domain.ErrCollectionRuleBased
+	}
+	if _, err := tx.ExecContext(ctx, deleteCollectionProductsQuery, collectionID); err != nil {
+		return err
+	}
+	args := []any{collectionID, pq.Array(productIDs)}
+	if _, err := tx.ExecContext(ctx, createCollectionProductsQuery, args...); err != nil {
+		return mapForeignKeyViolation(err)
+	}
+	return tx.Commit()
+}
+
+// collectionRuleArgs spreads the rule over its five columns, all NULL without a rule.
+func collectionRuleArgs(rule *entity.CollectionRuleRequest) []any {
+	if rule == nil {
+		return []any{nil, nil, nil, nil, nil}
+	}
+	return []any{rule.CategoryID, rule.BrandID, rule.TagID, rule.MinPrice, rule.MaxPrice}
+}
+
+// collectCollectionRow reads a row of collectionColumns. The rule is only set in the rule mode.
+func collectCollectionRow(row rowScanner) (*model.Collection, error) {
+	var collection model.Collection
+	var rule model.CollectionRule
+	err := row.Scan(
+		&collection.ID,
+		&collection.Name,
+		&collection.Slug,
+		&collection.Description,
+		&collection.Mode,
+		&rule.CategoryID,
+		&rule.BrandID,
+		&rule.TagID,
+		&rule.MinPrice,
+		&rule.MaxPrice,
+		&rule.Stale,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if collection.Mode == model.CollectionModeRule {
+		collection.Rule = &rule
+	}
+	return &collection, nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+const couponColumns = `
+	id, code, kind, percent, amount, max_discount, min_basket, scope, product_id, category_id, usage_limit, per_user_limit,
+	first_order_only, redemption_count, starts_at, ends_at, created_at, updated_at
+`
+
+type couponRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCouponRepository(db *sql.DB) domain.CouponRepository {
+	return &couponRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *couponRepositoryImpl) GetAll(ctx context.Context, limit, offset int) ([]model.Coupon, error) {
+	const getAllCouponsQuery string = `
+		SELECT ` + couponColumns + ` FROM coupons
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+	args := []any{limit, offset}
+	rows, err := r.db.QueryContext(ctx, getAllCouponsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectCouponRows(rows)
+}
+
+func (r *couponRepositoryImpl) GetByID(ctx context.Context, couponID string) (*model.Coupon, error) {
+	const getCouponByIDQuery string = `SELECT ` + couponColumns + ` FROM coupons WHERE id = $1`
+	args := []any{couponID}
+	row := r.db.QueryRowContext(ctx, getCouponByIDQuery, args...)
+	return collectCouponRow(row)
+}
+
+// GetActiveByCode finds a coupon by its case insensitive code if it is valid at the current database time.
+func (r *couponRepositoryImpl) GetActiveByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	const getActiveCouponByCodeQuery string = `
+		SELECT ` + couponColumns + ` FROM coupons
+		WHERE UPPER(code) = UPPER($1) AND starts_at <= CURRENT_TIMESTAMP AND (ends_at IS NULL OR ends_at > CURRENT_TIMESTAMP)
+	`
+	args := []any{code}
+	row := r.db.QueryRowContext(ctx, getActiveCouponByCodeQuery, args...)
+	return collectCouponRow(row)
+}
+
+func (r *couponRepositoryImpl) GetUsage(ctx context.Context, couponID, userID string) (*model.CouponUsage, error) {
+	const getCouponUsageQuery string = `
+		SELECT COUNT(*) FILTER (WHERE coupon_id = $1), COUNT(*)
+		FROM coupon_redemptions
+		WHERE user_id = $2
+	`
+	args := []any{couponID, userID}
+	var usage model.CouponUsage
+	if err := r.db.QueryRowContext(ctx, getCouponUsageQuery, args...).Scan(&usage.CouponRedemptions, &usage.TotalRedemptions); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+func (r *couponRepositoryImpl) Create(ctx context.Context, coupon *entity.CouponRequest) (string, error) {
+	const createCouponQuery string = `
+		INSERT INTO coupons (
+			code, kind, percent, amount, max_discount, min_basket, scope, product_id, category_id, usage_limit,
+			per_user_limit, first_order_only, starts_at, ends_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`
+	args := []any{
+		coupon.Code, coupon.Kind, coupon.Percent, coupon.Amount, coupon.MaxDiscount, coupon.MinBasket, coupon.Scope,
+		coupon.ProductID, coupon.CategoryID, coupon.UsageLimit, coupon.PerUserLimit, coupon.FirstOrderOnly, coupon.StartsAt,
+		coupon.EndsAt,
+	}
+	var couponID string
+	if err := r.db.QueryRowContext(ctx, createCouponQuery, args...).Scan(&couponID); err != nil {
+		return "", mapForeignKeyViolation(mapUniqueViolation(err))
+	}
+	return couponID, nil
+}
+
+func (r *couponRepositoryImpl) Update(ctx context.Context, couponID string, coupon *entity.CouponRequest) error {
+	const updateCouponQuery string = `
+		UPDATE coupons
+		SET code = $1, kind = $2, percent = $3, amount = $4, max_discount = $5, min_basket = $6, scope = $7,
+		    product_id = $8, category_id = $9, usage_limit = $10, per_user_limit = $11, first_order_only = $12,
+		    starts_at = $13, ends_at = $14, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $15
+	`
+	args := []any{
+		coupon.Code, coupon.Kind, coupon.Percent, coupon.Amount, coupon.MaxDiscount, coupon.MinBasket, coupon.Scope,
+		coupon.ProductID, coupon.CategoryID, coupon.UsageLimit, coupon.PerUserLimit, coupon.FirstOrderOnly, coupon.StartsAt,
+		coupon.EndsAt,
+		couponID,
+	}
+	result, err := r.db.ExecContext(ctx, updateCouponQuery, args...)
+	if err != nil {
+		return mapForeignKeyViolation(mapUniqueViolation(err))
+	}
+	return requireAffected(result)
+}
+
+func (r *couponRepositoryImpl) Delete(ctx context.Context, couponID string) error {
+	const deleteCouponQuery string = "DELETE FROM coupons WHERE id = $1"
+	args := []any{couponID}
+	result, err := r.db.ExecContext(ctx, deleteCouponQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// Redeem records a redemption after checking the limits again inside one transaction. The coupon row lock serializes
+// redemptions of the same coupon and the user row lock serializes first order checks of one user, so concurrent
+// requests cannot go over a limit. The coupon lock is always taken first to keep the lock order stable.
+// There is no order table yet, a first order is one placed by a user that never redeemed a coupon.
+func (r *couponRepositoryImpl) Redeem(ctx context.Context, couponID, userID string, discount money.Money) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	const lockCouponQuery string = `
+		SELECT usage_limit, per_user_limit, first_order_only, redemption_count
+		FROM coupons
+		WHERE id = $1 AND starts_at <= CURRENT_TIMESTAMP AND (ends_at IS NULL OR ends_at > CURRENT_TIMESTAMP)
+		FOR UPDATE
+	`
+	var usageLimit, perUserLimit sql.NullInt64
+	var firstOrderOnly bool
+	var redemptionCount int64
+	err = tx.QueryRowContext(ctx, lockCouponQuery, couponID).Scan(&usageLimit, &perUserLimit, &firstOrderOnly, &redemptionCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrCouponInvalid
+	}
+	if err != nil {
+		return err
+	}
+	const lockUserQuery string = "SELECT id FROM users WHERE id = $1 FOR UPDATE"
+	if _, err := tx.ExecContext(ctx, lockUserQuery, userID); err != nil {
+		return err
+	}
+	const getCouponUsageQuery string = `
+		SELECT COUNT(*) FILTER (WHERE coupon_id = $1), COUNT(*)
+		FROM coupon_redemptions
+		WHERE user_id = $2
+	`
+	var couponRedemptions, totalRedemptions int64
+	if err := tx.QueryRowContext(ctx, getCouponUsageQuery, couponID, userID).Scan(&couponRedemptions, &totalRedemptions); err != nil {
+		return err
+	}
+	switch {
+	case usageLimit.Valid && redemptionCount >= usageLimit.Int64:
+		return domain.ErrCouponUsedUp
+	case perUserLimit.Valid && couponRedemptions >= perUserLimit.Int64:
+		return domain.ErrCouponUsedUp
+	case firstOrderOnly && totalRedemptions > 0:
+		return domain.ErrCouponFirstOrder
+	}
+	const createRedemptionQuery string = "INSERT INTO coupon_redemptions (coupon_id, user_id, discount) VALUES ($1, $2, $3)"
+	if _, err := tx.ExecContext(ctx, createRedemptionQuery, couponID, userID, discount); err != nil {
+		return mapForeignKeyViolation(err)
+	}
+	const countRedemptionQuery string = "UPDATE coupons SET redemption_count = redemption_count + 1 WHERE id = $1"
+	if _, err := tx.ExecContext(ctx, countRedemptionQuery, couponID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func collectCouponRows(rows *sql.Rows) ([]model.Coupon, error) {
+	coupons := make([]model.Coupon, 0)
+	for rows.Next() {
+		coupon, err := collectCouponRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		coupons = append(coupons, *coupon)
+	}
+	return coupons, rows.Err()
+}
+
+func collectCouponRow(row rowScanner) (*model.Coupon, error) {
+	var coupon model.Coupon
+	err := row.Scan(
+		&coupon.ID,
+		&coupon.Code,
+		&coupon.Kind,
+		&coupon.Percent,
+		&coupon.Amount,
+		&coupon.MaxDiscount,
+		&coupon.MinBasket,
+		&coupon.Scope,
+		&coupon.ProductID,
+		&coupon.CategoryID,
+		&coupon.UsageLimit,
+		&coupon.PerUserLimit,
+		&coupon.FirstOrderOnly,
+		&coupon.RedemptionCount,
+		&coupon.StartsAt,
+		&coupon.EndsAt,
+		&coupon.CreatedAt,
+		&coupon.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
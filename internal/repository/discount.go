@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+const discountColumns = "id, name, kind, percent, amount, scope, product_id, category_id, starts_at, ends_at, created_at, updated_at"
+
+type discountRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewDiscountRepository(db *sql.DB) domain.DiscountRepository {
+	return &discountRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *discountRepositoryImpl) GetAll(ctx context.Context, limit, offset int) ([]model.Discount, error) {
+	const getAllDiscountsQuery string = `
+		SELECT ` + discountColumns + ` FROM discounts
+		ORDER BY starts_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+	args := []any{limit, offset}
+	rows, err := r.db.QueryContext(ctx, getAllDiscountsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectDiscountRows(rows)
+}
+
+func (r *discountRepositoryImpl) GetByID(ctx context.Context, discountID string) (*model.Discount, error) {
+	const getDiscountByIDQuery string = `SELECT ` + discountColumns + ` FROM discounts WHERE id = $1`
+	args := []any{discountID}
+	row := r.db.QueryRowContext(ctx, getDiscountByIDQuery, args...)
+	return collectDiscountRow(row)
+}
+
+// GetActive returns the discounts that are running at the current database time.
+func (r *discountRepositoryImpl) GetActive(ctx context.Context) ([]model.Discount, error) {
+	const getActiveDiscountsQuery string = `
+		SELECT ` + discountColumns + ` FROM discounts
+		WHERE starts_at <= CURRENT_TIMESTAMP AND (ends_at IS NULL OR ends_at > CURRENT_TIMESTAMP)
+	`
+	rows, err := r.db.QueryContext(ctx, getActiveDiscountsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectDiscountRows(rows)
+}
+
+func (r *discountRepositoryImpl) Create(ctx context.Context, discount *entity.DiscountRequest) (string, error) {
+	const createDiscountQuery string = `
+		INSERT INTO discounts (name, kind, percent, amount, scope, product_id, category_id, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+	args := []any{discount.Name, discount.Kind, discount.Percent, discount.Amount, discount.Scope, discount.ProductID, discount.CategoryID, discount.StartsAt, discount.EndsAt}
+	var discountID string
+	if err := r.db.QueryRowContext(ctx, createDiscountQuery, args...).Scan(&discountID); err != nil {
+		return "", mapForeignKeyViolation(err)
+	}
+	return discountID, nil
+}
+
+func (r *discountRepositoryImpl) Update(ctx context.Context, discountID string, discount *entity.DiscountRequest) error {
+	const updateDiscountQuery string = `
+		UPDATE discounts
+		SET name = $1, kind = $2, percent = $3, amount = $4, scope = $5, product_id = $6, category_id = $7, starts_at = $8, ends_at = $9,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $10
+	`
+	args := []any{discount.Name, discount.Kind, discount.Percent, discount.Amount, discount.Scope, discount.ProductID, discount.CategoryID, discount.StartsAt, discount.EndsAt, discountID}
+	result, err := r.db.ExecContext(ctx, updateDiscountQuery, args...)
+	if err != nil {
+		return mapForeignKeyViolation(err)
+	}
+	return requireAffected(result)
+}
+
+func (r *discountRepositoryImpl) Delete(ctx context.Context, discountID string) error {
+	const deleteDiscountQuery string = "DELETE FROM discounts WHERE id = $1"
+	args := []any{discountID}
+	result, err := r.db.ExecContext(ctx, deleteDiscountQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func collectDiscountRows(rows *sql.Rows) ([]model.Discount, error) {
+	discounts := make([]model.Discount, 0)
+	for rows.Next() {
+		discount, err := collectDiscountRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		discounts = append(discounts, *discount)
+	}
+	return discounts, rows.Err()
+}
+
+func collectDiscountRow(row rowScanner) (*model.Discount, error) {
+	var discount model.Discount
+	err := row.Scan(
+		&discount.ID,
+		&discount.Name,
+		&discount.Kind,
+		&discount.Percent,
+		&discount.Amount,
+		&discount.Scope,
+		&discount.ProductID,
+		&discount.CategoryID,
+		&discount.StartsAt,
+		&discount.EndsAt,
+		&discount.CreatedAt,
+		&discount.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &discount, nil
+}
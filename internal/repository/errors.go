@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+)
+
+func mapUniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		return domain.ErrDuplicate
+	}
+	return err
+}
+
+// mapForeignKeyViolation reports a reference to a missing row as sql.ErrNoRows.
+func mapForeignKeyViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqForeignKeyViolation {
+		return sql.ErrNoRows
+	}
+	return err
+}
+
+// requireAffected turns an update or delete that matched no rows into sql.ErrNoRows.
+func requireAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/lib/pq"
+)
+
+type homeRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewHomeRepository(db *sql.DB) domain.HomeRepository {
+	return &homeRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetSections lists every section in display order with its banners, hidden ones included.
+func (r *homeRepositoryImpl) GetSections(ctx context.Context) ([]model.HomeSection, error) {
+	const getHomeSectionsQuery string = `
+		SELECT
+		    s.id,
+		    s.kind,
+		    s.title,
+		    s.position,
+		    s.hidden,
+		    s.collection_id,
+		    s.category_ids,
+		    s.item_limit,
+		    COALESCE(
+		        json_agg(
+		            json_build_object(
+		                'id', b.id::text,
+		                'image', b.image,
+		                'link', b.link,
+		                'alt', b.alt,
+		                'position', b.position
+		            ) ORDER BY b.position, b.id
+		        ) FILTER (WHERE b.id IS NOT NULL), '[]'
+		    ) AS banners
+		FROM
+		    home_sections s
+		LEFT JOIN
+		    home_banners b ON b.section_id = s.id
+		GROUP BY
+		    s.id
+		ORDER BY
+		    s.position, s.id
+	`
+	rows, err := r.db.QueryContext(ctx, getHomeSectionsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	sections := make([]model.HomeSection, 0)
+	for rows.Next() {
+		var section model.HomeSection
+		var bannersJSON []byte
+		err := rows.Scan(
+			&section.ID,
+			&section.Kind,
+			&section.Title,
+			&section.Position,
+			&section.Hidden,
+			&section.CollectionID,
+			pq.Array(&section.CategoryIDs),
+			&section.Limit,
+			&bannersJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bannersJSON, &section.Banners); err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+	return sections, rows.Err()
+}
+
+func (r *homeRepositoryImpl) GetSectionByID(ctx context.Context, sectionID string) (*model.HomeSection, error) {
+	const getHomeSectionByIDQuery string = `
+		SELECT id, kind, title, position, hidden, collection_id, category_ids, item_limit
+		FROM home_sections
+		WHERE id = $1
+	`
+	args := []any{sectionID}
+	var section model.HomeSection
+	err := r.db.QueryRowContext(ctx, getHomeSectionByIDQuery, args...).Scan(
+		&section.ID,
+		&section.Kind,
+		&section.Title,
+		&section.Position,
+		&section.Hidden,
+		&section.CollectionID,
+		pq.Array(&section.CategoryIDs),
+		&section.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+func (r *homeRepositoryImpl) GetBannerByID(ctx context.Context, bannerID string) (*model.HomeBanner, error) {
+	const getHomeBannerByIDQuery string = "SELECT id, image, link, alt, position FROM home_banners WHERE id = $1"
+	args := []any{bannerID}
+	var banner model.HomeBanner
+	err := r.db.QueryRowContext(ctx, getHomeBannerByIDQuery, args...).Scan(
+		&banner.ID,
+		&banner.Image,
+		&banner.Link,
+		&banner.Alt,
+		&banner.Position,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &banner, nil
+}
+
+// CreateSection inserts the section, sql.ErrNoRows when its collection is missing.
+func (r *homeRepositoryImpl) CreateSection(ctx context.Context, section *entity.HomeSectionRequest) (string, error) {
+	const createHomeSectionQuery string = `
+		INSERT INTO home_sections (kind, title, position, hidden, collection_id, category_ids, item_limit)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::int[], '{}'), COALESCE(NULLIF($7, 0), 8))
+		RETURNING id
+	`
+	args := []any{section.Kind, section.Title, section.Position, section.Hidden, section.CollectionID, pq.Array(section.CategoryIDs), section.Limit}
+	var sectionID string
+	if err := r.db.QueryRowContext(ctx, createHomeSectionQuery, args...).Scan(&sectionID); err != nil {
+		return "", mapForeignKeyViolation(err)
+	}
+	return sectionID, nil
+}
+
+// UpdateSection overwrites the section. Banners stay when the kind changes and show again once it is a banner
+// section again.
+func (r *homeRepositoryImpl) UpdateSection(ctx context.Context, sectionID string, section *entity.HomeSectionRequest) error {
+	const updateHomeSectionQuery string = `
+		UPDATE home_sections SET
+		    kind = $1,
+		    title = $2,
+		    position = $3,
+		    hidden = $4,
+		    collection_id = $5,
+		    category_ids = COALESCE($6::int[], '{}'),
+		    item_limit = COALESCE(NULLIF($7, 0), 8),
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $8
+	`
+	args := []any{section.Kind, section.Title, section.Position, section.Hidden, section.CollectionID, pq.Array(section.CategoryIDs), section.Limit, sectionID}
+	result, err := r.db.ExecContext(ctx, updateHomeSectionQuery, args...)
+	if err != nil {
+		return mapForeignKeyViolation(err)
+	}
+	return requireAffected(result)
+}
+
+func (r *homeRepositoryImpl) DeleteSection(ctx context.Context, sectionID string) error {
+	const deleteHomeSectionQuery string = "DELETE FROM home_sections WHERE id = $1"
+	args := []any{sectionID}
+	result, err := r.db.ExecContext(ctx, deleteHomeSectionQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// CreateBanner adds a banner to a banner section, domain.ErrHomeSectionKind for any other kind of section.
+func (r *homeRepositoryImpl) CreateBanner(ctx context.Context, sectionID string, banner *entity.HomeBannerRequest) (string, error) {
+	const lockHomeSectionQuery string = "SELECT kind FROM home_sections WHERE id = $1 FOR UPDATE"
+	const createHomeBannerQuery string = `
+		INSERT INTO home_banners (section_id, image, link, alt, position)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5)
+		RETURNING id
+	`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+	var kind string
+	if err := tx.QueryRowContext(ctx, lockHomeSectionQuery, sectionID).Scan(&kind); err != nil {
+		return "", err
+	}
+	if kind != model.HomeSectionBanner {
+		return "", domain.ErrHomeSectionKind
+	}
+	args := []any{sectionID, banner.Image, banner.Link, banner.Alt, banner.Position}
+	var bannerID string
+	if err := tx.QueryRowContext(ctx, createHomeBannerQuery, args...).Scan(&bannerID); err != nil {
+		return "", err
+	}
+	return bannerID, tx.Commit()
+}
+
+func (r *homeRepositoryImpl) DeleteBanner(ctx context.Context, bannerID string) error {
+	const deleteHomeBannerQuery string = "DELETE FROM home_banners WHERE id = $1"
+	args := []any{bannerID}
+	result, err := r.db.ExecContext(ctx, deleteHomeBannerQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
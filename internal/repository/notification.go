@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+const notificationColumns = `
+	id,
+	user_id,
+	event,
+	channel,
+	recipient,
+	subject,
+	message,
+	sensitive,
+	priority,
+	status,
+	attempts,
+	max_attempts,
+	last_error,
+	next_attempt_at,
+	expires_at,
+	sent_at,
+	created_at,
+	updated_at
+`
+
+type notificationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) domain.NotificationRepository {
+	return &notificationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *notificationRepositoryImpl) GetAll(ctx context.Context, params *entity.NotificationQueryParamRequest) ([]model.Notification, error) {
+	conditions := make([]string, 0, 5)
+	args := make([]any, 0, 7)
+	if params.Status != "" {
+		args = append(args, params.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if params.Channel != "" {
+		args = append(args, params.Channel)
+		conditions = append(conditions, fmt.Sprintf("channel = $%d", len(args)))
+	}
+	if params.Recipient != "" {
+		args = append(args, params.Recipient)
+		conditions = append(conditions, fmt.Sprintf("recipient = $%d", len(args)))
+	}
+	if params.UserID != "" {
+		args = append(args, params.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if params.Event != "" {
+		args = append(args, params.Event)
+		conditions = append(conditions, fmt.Sprintf("event = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, params.Limit, params.Offset)
+	query := fmt.Sprintf(
+		"SELECT %s FROM notifications %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
+		notificationColumns, where, len(args)-1, len(args),
+	)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectNotificationRows(rows)
+}
+
+func (r *notificationRepositoryImpl) GetByID(ctx context.Context, notificationID string) (*model.Notification, error) {
+	getNotificationByIDQuery := "SELECT " + notificationColumns + " FROM notifications WHERE id = $1"
+	args := []any{notificationID}
+	row := r.db.QueryRowContext(ctx, getNotificationByIDQuery, args...)
+	return collectNotificationRow(row)
+}
+
+func (r *notificationRepositoryImpl) Create(ctx context.Context, notification *entity.NotificationCreateRequest, maxAttempts int) error {
+	const createNotificationQuery string = `
+		INSERT INTO notifications (user_id, event, channel, recipient, subject, message, priority, max_attempts, expires_at, sensitive)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	args := []any{
+		notification.UserID,
+		notification.Event,
+		notification.Channel,
+		notification.Recipient,
+		notification.Subject,
+		notification.Message,
+		notification.Priority,
+		maxAttempts,
+		notification.ExpiresAt,
+		notification.Sensitive,
+	}
+	_, err := r.db.ExecContext(ctx, createNotificationQuery, args...)
+	return err
+}
+
+// Claim locks up to limit due notifications with at least minPriority and marks them as processing.
+// Rows left in processing longer than lease are considered abandoned by a crashed worker and are claimed again.
+func (r *notificationRepositoryImpl) Claim(ctx context.Context, minPriority, limit int, lease time.Duration) ([]model.Notification, error) {
+	claimNotificationsQuery := `
+		UPDATE notifications
+		SET
+		    status = 'processing',
+		    attempts = attempts + 1,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+		    SELECT id
+		    FROM notifications
+		    WHERE
+		        priority >= $1
+		        AND (
+		            (status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP)
+		            OR (status = 'processing' AND updated_at <= CURRENT_TIMESTAMP - make_interval(secs => $3))
+		        )
+		    ORDER BY priority DESC, next_attempt_at
+		    LIMIT $2
+		    FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + notificationColumns
+	args := []any{minPriority, limit, lease.Seconds()}
+	rows, err := r.db.QueryContext(ctx, claimNotificationsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectNotificationRows(rows)
+}
+
+func (r *notificationRepositoryImpl) MarkSent(ctx context.Context, notificationID string) error {
+	const markNotificationSentQuery string = `
+		UPDATE notifications
+		SET status = 'sent', last_error = NULL, sent_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+	args := []any{notificationID}
+	_, err := r.db.ExecContext(ctx, markNotificationSentQuery, args...)
+	return err
+}
+
+func (r *notificationRepositoryImpl) MarkFailed(ctx context.Context, notificationID, lastError string, nextAttemptAt time.Time) error {
+	const markNotificationFailedQuery string = `
+		UPDATE notifications
+		SET status = 'pending', last_error = $1, next_attempt_at = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`
+	args := []any{lastError, nextAttemptAt, notificationID}
+	_, err := r.db.ExecContext(ctx, markNotificationFailedQuery, args...)
+	return err
+}
+
+func (r *notificationRepositoryImpl) MarkDead(ctx context.Context, notificationID, lastError string) error {
+	const markNotificationDeadQuery string = `
+		UPDATE notifications
+		SET status = 'dead', last_error = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+	args := []any{lastError, notificationID}
+	_, err := r.db.ExecContext(ctx, markNotificationDeadQuery, args...)
+	return err
+}
+
+func (r *notificationRepositoryImpl) Requeue(ctx context.Context, notificationID string) error {
+	const requeueNotificationQuery string = `
+		UPDATE notifications
+		SET status = 'pending', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'dead'
+	`
+	args := []any{notificationID}
+	result, err := r.db.ExecContext(ctx, requeueNotificationQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func collectNotificationRows(rows *sql.Rows) ([]model.Notification, error) {
+	notifications := make([]model.Notification, 0)
+	for rows.Next() {
+		var notification model.Notification
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Event,
+			&notification.Channel,
+			&notification.Recipient,
+			&notification.Subject,
+			&notification.Message,
+			&notification.Sensitive,
+			&notification.Priority,
+			&notification.Status,
+			&notification.Attempts,
+			&notification.MaxAttempts,
+			&notification.LastError,
+			&notification.NextAttemptAt,
+			&notification.ExpiresAt,
+			&notification.SentAt,
+			&notification.CreatedAt,
+			&notification.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func collectNotificationRow(row *sql.Row) (*model.Notification, error) {
+	var notification model.Notification
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Event,
+		&notification.Channel,
+		&notification.Recipient,
+		&notification.Subject,
+		&notification.Message,
+		&notification.Sensitive,
+		&notification.Priority,
+		&notification.Status,
+		&notification.Attempts,
+		&notification.MaxAttempts,
+		&notification.LastError,
+		&notification.NextAttemptAt,
+		&notification.ExpiresAt,
+		&notification.SentAt,
+		&notification.CreatedAt,
+		&notification.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/gosimple/slug"
+	"github.com/lib/pq"
 )
 
 type productRepositoryImpl struct {
@@ -21,7 +23,9 @@ func NewProductRepository(db *sql.DB) domain.ProductRepository {
 	}
 }
 
-func (r *productRepositoryImpl) GetAll(ctx context.Context) ([]model.Products, error) {
+// GetAll lists live products. status narrows the list to one publication status and brandID to one brand, empty
+// values leave the list unfiltered.
+func (r *productRepositoryImpl) GetAll(ctx context.Context, status, brandID string) ([]model.Products, error) {
 	const getAllProductsQuery string = `
 		SELECT 
 		    p.id,
@@ -34,19 +38,458 @@ func (r *productRepositoryImpl) GetAll(ctx context.Context) ([]model.Products, e
 		    p.created_at,
 		    p.updated_at,
 		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
 		    COALESCE(AVG(pr.rating), 0) AS average_rating,
 	    	COUNT(pr.rating) AS rating_count,
-	    	pi.image_url AS main_image
+	    	pi.image_url AS main_image,
+	    	p.deleted_at
 		FROM
 		    products p
 		LEFT JOIN
 			product_ratings pr ON p.id = pr.product_id
 		LEFT JOIN
 		        product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.deleted_at IS NULL AND ($1 = '' OR p.status = $1) AND ($2 = '' OR p.brand_id = NULLIF($2, '')::INTEGER)
+		GROUP BY
+		    p.id, pi.image_url
+	`
+	args := []any{status, brandID}
+	rows, err := r.db.QueryContext(ctx, getAllProductsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetByIDs lists the live products among the given ids, ids that are missing or trashed are left out.
+func (r *productRepositoryImpl) GetByIDs(ctx context.Context, productIDs []string) ([]model.Products, error) {
+	const getProductsByIDsQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+	    	COUNT(pr.rating) AS rating_count,
+	    	pi.image_url AS main_image,
+	    	p.deleted_at
+		FROM
+		    products p
+		LEFT JOIN
+			product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		        product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.id = ANY($1::int[]) AND p.deleted_at IS NULL
+		GROUP BY
+		    p.id, pi.image_url
+	`
+	args := []any{pq.Array(productIDs)}
+	rows, err := r.db.QueryContext(ctx, getProductsByIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetByCategoryPath pages through the published products of the category with the given path and of all its live
+// descendants, newest first.
+func (r *productRepositoryImpl) GetByCategoryPath(ctx context.Context, categoryPath string, limit, offset int) ([]model.Products, error) {
+	const getProductsByCategoryPathQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    products p
+		JOIN
+		    categories c ON c.id = p.category_id AND c.deleted_at IS NULL AND c.path LIKE $1 || '%'
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    p.id, pi.image_url
+		ORDER BY
+		    p.created_at DESC, p.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{categoryPath, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductsByCategoryPathQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetByBrand pages through the published products of a brand, newest first.
+func (r *productRepositoryImpl) GetByBrand(ctx context.Context, brandID string, limit, offset int) ([]model.Products, error) {
+	const getProductsByBrandQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    products p
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.brand_id = $1 AND p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    p.id, pi.image_url
+		ORDER BY
+		    p.created_at DESC, p.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{brandID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductsByBrandQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetByTag pages through the published products carrying a tag, newest first.
+func (r *productRepositoryImpl) GetByTag(ctx context.Context, tagID string, limit, offset int) ([]model.Products, error) {
+	const getProductsByTagQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    products p
+		JOIN
+		    product_tags pt ON pt.product_id = p.id AND pt.tag_id = $1
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    p.id, pi.image_url
+		ORDER BY
+		    p.created_at DESC, p.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{tagID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductsByTagQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetByCollection pages through the published members of a manual collection in their curated order.
+func (r *productRepositoryImpl) GetByCollection(ctx context.Context, collectionID string, limit, offset int) ([]model.Products, error) {
+	const getProductsByCollectionQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    collection_products cp
+		JOIN
+		    products p ON p.id = cp.product_id
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    cp.collection_id = $1 AND p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    cp.position, p.id, pi.image_url
+		ORDER BY
+		    cp.position, p.id
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{collectionID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductsByCollectionQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetByCollectionRule pages through the published products matching every condition of the rule, newest first.
+// A rule whose category is trashed, or whose category, brand or tag is deleted, matches nothing.
+func (r *productRepositoryImpl) GetByCollectionRule(ctx context.Context, rule *model.CollectionRule, limit, offset int) ([]model.Products, error) {
+	if rule.Stale {
+		return make([]model.Products, 0), nil
+	}
+	const getProductsByCollectionRuleQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    products p
+		LEFT JOIN
+		    categories c ON c.id = p.category_id AND c.deleted_at IS NULL
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.deleted_at IS NULL AND p.status = 'published'
+		    AND ($1::INTEGER IS NULL OR c.path LIKE (SELECT path FROM categories WHERE id = $1 AND deleted_at IS NULL) || '%')
+		    AND ($2::INTEGER IS NULL OR p.brand_id = $2)
+		    AND ($3::INTEGER IS NULL OR EXISTS (SELECT 1 FROM product_tags pt WHERE pt.product_id = p.id AND pt.tag_id = $3))
+		    AND ($4::BIGINT IS NULL OR p.price >= $4)
+		    AND ($5::BIGINT IS NULL OR p.price <= $5)
 		GROUP BY
 		    p.id, pi.image_url
+		ORDER BY
+		    p.created_at DESC, p.id DESC
+		LIMIT $6 OFFSET $7
 	`
-	rows, err := r.db.QueryContext(ctx, getAllProductsQuery)
+	args := []any{rule.CategoryID, rule.BrandID, rule.TagID, rule.MinPrice, rule.MaxPrice, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductsByCollectionRuleQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetNewest lists the most recently published products.
+func (r *productRepositoryImpl) GetNewest(ctx context.Context, limit int) ([]model.Products, error) {
+	const getNewestProductsQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    products p
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    p.id, pi.image_url
+		ORDER BY
+		    COALESCE(p.published_at, p.created_at) DESC, p.id DESC
+		LIMIT $1
+	`
+	args := []any{limit}
+	rows, err := r.db.QueryContext(ctx, getNewestProductsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetBestRated lists the rated published products with the highest average rating, the more ratings the better
+// on a tie.
+func (r *productRepositoryImpl) GetBestRated(ctx context.Context, limit int) ([]model.Products, error) {
+	const getBestRatedProductsQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    products p
+		JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    p.id, pi.image_url
+		ORDER BY
+		    average_rating DESC, rating_count DESC, p.id DESC
+		LIMIT $1
+	`
+	args := []any{limit}
+	rows, err := r.db.QueryContext(ctx, getBestRatedProductsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetPopularByCategory lists the published products of a category, best rated first, leaving out excludeIDs.
+func (r *productRepositoryImpl) GetPopularByCategory(ctx context.Context, categoryID string, excludeIDs []string, limit int) ([]model.Products, error) {
+	const getPopularProductsByCategoryQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    products p
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.category_id = $1 AND NOT (p.id = ANY($2::int[])) AND p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    p.id, pi.image_url
+		ORDER BY
+		    rating_count DESC, average_rating DESC, p.id DESC
+		LIMIT $3
+	`
+	args := []any{categoryID, pq.Array(excludeIDs), limit}
+	rows, err := r.db.QueryContext(ctx, getPopularProductsByCategoryQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +510,11 @@ func (r *productRepositoryImpl) GetByID(ctx context.Context, productID string) (
 		    p.created_at,
 		    p.updated_at,
 		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
 			COALESCE(AVG(pr.rating), 0) AS average_rating,
 	    	COUNT(pr.rating) AS rating_count,
 	    	COALESCE(
@@ -77,7 +525,15 @@ func (r *productRepositoryImpl) GetByID(ctx context.Context, productID string) (
 						'is_main', pi.is_main
 					)
 				) FILTER (WHERE pi.id IS NOT NULL), '[]'
-			) AS images
+			) AS images,
+			COALESCE(
+				(
+					SELECT json_agg(json_build_object('id', t.id::text, 'name', t.name, 'slug', t.slug) ORDER BY t.name)
+					FROM product_tags pt
+					JOIN tags t ON t.id = pt.tag_id
+					WHERE pt.product_id = p.id
+				), '[]'
+			) AS tags
 		FROM
 		    products p
 		LEFT JOIN
@@ -85,7 +541,7 @@ func (r *productRepositoryImpl) GetByID(ctx context.Context, productID string) (
 		LEFT JOIN
 			product_images pi ON p.id = pi.product_id
 		WHERE
-		    p.id = $1
+		    p.id = $1 AND p.deleted_at IS NULL
 		GROUP BY
 		    p.id
 	`
@@ -107,6 +563,11 @@ func (r *productRepositoryImpl) GetBySlug(ctx context.Context, productSlug strin
 		    p.created_at,
 		    p.updated_at,
 		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
 			COALESCE(AVG(pr.rating), 0) AS average_rating,
 	    	COUNT(pr.rating) AS rating_count,
 	    	COALESCE(
@@ -117,7 +578,15 @@ func (r *productRepositoryImpl) GetBySlug(ctx context.Context, productSlug strin
 						'is_main', pi.is_main
 					)
 				) FILTER (WHERE pi.id IS NOT NULL), '[]'
-			) AS images
+			) AS images,
+			COALESCE(
+				(
+					SELECT json_agg(json_build_object('id', t.id::text, 'name', t.name, 'slug', t.slug) ORDER BY t.name)
+					FROM product_tags pt
+					JOIN tags t ON t.id = pt.tag_id
+					WHERE pt.product_id = p.id
+				), '[]'
+			) AS tags
 		FROM
 		    products p
 		LEFT JOIN
@@ -125,7 +594,7 @@ func (r *productRepositoryImpl) GetBySlug(ctx context.Context, productSlug strin
 		LEFT JOIN
 			product_images pi ON p.id = pi.product_id
 		WHERE
-		    p.slug = $1
+		    p.slug = $1 AND p.deleted_at IS NULL
 		GROUP BY
 		    p.id
 	`
@@ -134,29 +603,275 @@ func (r *productRepositoryImpl) GetBySlug(ctx context.Context, productSlug strin
 	return collectProductRow(row)
 }
 
-func (r *productRepositoryImpl) Create(ctx context.Context, product *entity.ProductCreateRequest) error {
-	const createProductQuery string = "INSERT INTO products (name, slug, description, short_description, price, quantity, category_id) VALUES ($1, $2, $3, $4, $5, $6, $7)"
-	args := []any{product.Name, slug.Make(product.Slug), product.Description, product.ShortDescription, product.Price, product.Quantity, product.CategoryID}
-	_, err := r.db.ExecContext(ctx, createProductQuery, args...)
-	return err
+// GetAllDeleted lists the trash, most recently deleted first.
+func (r *productRepositoryImpl) GetAllDeleted(ctx context.Context, limit, offset int) ([]model.Products, error) {
+	const getDeletedProductsQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+	    	COUNT(pr.rating) AS rating_count,
+	    	pi.image_url AS main_image,
+	    	p.deleted_at
+		FROM
+		    products p
+		LEFT JOIN
+			product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		        product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    p.deleted_at IS NOT NULL
+		GROUP BY
+		    p.id, pi.image_url
+		ORDER BY
+		    p.deleted_at DESC, p.id DESC
+		LIMIT $1 OFFSET $2
+	`
+	args := []any{limit, offset}
+	rows, err := r.db.QueryContext(ctx, getDeletedProductsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
 }
 
-func (r *productRepositoryImpl) Update(ctx context.Context, productID string, product *entity.ProductUpdateRequest) error {
-	const updateProductQuery string = "UPDATE products SET name = $1, slug = $2, description = $3, short_description = $4, price = $5, quantity = $6, category_id = $7 WHERE id = $8"
-	args := []any{product.Name, slug.Make(product.Slug), product.Description, product.ShortDescription, product.Price, product.Quantity, product.CategoryID, productID}
-	_, err := r.db.ExecContext(ctx, updateProductQuery, args...)
-	return err
+// Create inserts the product together with its first revision and price point and books the initial quantity as a restock.
+// Without a status the product starts as a draft.
+func (r *productRepositoryImpl) Create(ctx context.Context, editorID string, product *entity.ProductCreateRequest) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+	const createProductQuery string = `
+		INSERT INTO products (name, slug, description, short_description, price, category_id, status, publish_at, unpublish_at, published_at, brand_id)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($7, ''), 'draft'), $8, $9, CASE WHEN $7 = 'published' THEN CURRENT_TIMESTAMP END, $10)
+		RETURNING id
+	`
+	args := []any{product.Name, slug.Make(product.Slug), product.Description, product.ShortDescription, product.Price, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt, product.BrandID}
+	var productID string
+	if err := tx.QueryRowContext(ctx, createProductQuery, args...).Scan(&productID); err != nil {
+		return "", err
+	}
+	initialStock := entity.StockMovementRequest{Kind: model.StockMovementRestock, Delta: product.Quantity, Reason: "initial stock"}
+	if _, err := saveStockMovement(ctx, tx, productID, editorID, &initialStock); err != nil {
+		return "", err
+	}
+	if err := saveProductRevision(ctx, tx, productID, editorID, nil); err != nil {
+		return "", err
+	}
+	if err := saveProductPrice(ctx, tx, productID); err != nil {
+		return "", err
+	}
+	return productID, tx.Commit()
 }
 
+// Update overwrites the product, snapshots the result as a new revision and extends the price history when the price moved.
+// The quantity only changes through stock movements.
+func (r *productRepositoryImpl) Update(ctx context.Context, productID, editorID string, product *entity.ProductUpdateRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+	const updateProductQuery string = "UPDATE products SET name = $1, slug = $2, description = $3, short_description = $4, price = $5, category_id = $6, brand_id = $7, updated_at = CURRENT_TIMESTAMP WHERE id = $8"
+	args := []any{product.Name, slug.Make(product.Slug), product.Description, product.ShortDescription, product.Price, product.CategoryID, product.BrandID, productID}
+	if _, err := tx.ExecContext(ctx, updateProductQuery, args...); err != nil {
+		return mapUniqueViolation(err)
+	}
+	if err := saveProductRevision(ctx, tx, productID, editorID, nil); err != nil {
+		return err
+	}
+	if err := saveProductPrice(ctx, tx, productID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateStatus moves the product through the publication workflow. published_at keeps the time of the first publication.
+func (r *productRepositoryImpl) UpdateStatus(ctx context.Context, productID string, status *entity.ProductStatusUpdateRequest) error {
+	const updateProductStatusQuery string = `
+		UPDATE products
+		SET
+		    status = $1,
+		    publish_at = $2,
+		    unpublish_at = $3,
+		    published_at = CASE WHEN $1 = 'published' THEN COALESCE(published_at, CURRENT_TIMESTAMP) ELSE published_at END
+		WHERE
+		    id = $4 AND deleted_at IS NULL
+	`
+	args := []any{status.Status, status.PublishAt, status.UnpublishAt, productID}
+	result, err := r.db.ExecContext(ctx, updateProductStatusQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// PublishDue publishes the drafts whose publish_at has passed and returns their ids.
+func (r *productRepositoryImpl) PublishDue(ctx context.Context) ([]string, error) {
+	const publishDueProductsQuery string = `
+		UPDATE products
+		SET
+		    status = 'published',
+		    publish_at = NULL,
+		    published_at = COALESCE(published_at, CURRENT_TIMESTAMP)
+		WHERE
+		    status = 'draft' AND publish_at <= CURRENT_TIMESTAMP AND deleted_at IS NULL
+		RETURNING id
+	`
+	return r.collectIDs(ctx, publishDueProductsQuery)
+}
+
+// UnpublishDue archives the published products whose unpublish_at has passed and returns their ids.
+func (r *productRepositoryImpl) UnpublishDue(ctx context.Context) ([]string, error) {
+	const unpublishDueProductsQuery string = `
+		UPDATE products
+		SET
+		    status = 'archived',
+		    unpublish_at = NULL
+		WHERE
+		    status = 'published' AND unpublish_at <= CURRENT_TIMESTAMP AND deleted_at IS NULL
+		RETURNING id
+	`
+	return r.collectIDs(ctx, unpublishDueProductsQuery)
+}
+
+func (r *productRepositoryImpl) collectIDs(ctx context.Context, query string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectIDRows(rows)
+}
+
+// Rollback copies an earlier revision back onto the product, except for the quantity which belongs to the stock ledger.
+// The rollback itself is recorded as a new revision. A category that has since been trashed or a brand that has been
+// deleted is not restored, the product is left without one instead.
+func (r *productRepositoryImpl) Rollback(ctx context.Context, productID, editorID string, revision int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+	const rollbackProductQuery string = `
+		UPDATE products p
+		SET
+		    name = pr.name,
+		    slug = pr.slug,
+		    description = pr.description,
+		    short_description = pr.short_description,
+		    price = pr.price,
+		    category_id = c.id,
+		    brand_id = b.id,
+		    updated_at = CURRENT_TIMESTAMP
+		FROM
+		    product_revisions pr
+		LEFT JOIN
+		    categories c ON c.id = pr.category_id AND c.deleted_at IS NULL
+		LEFT JOIN
+		    brands b ON b.id = pr.brand_id
+		WHERE
+		    p.id = pr.product_id AND pr.product_id = $1 AND pr.revision = $2
+	`
+	args := []any{productID, revision}
+	result, err := tx.ExecContext(ctx, rollbackProductQuery, args...)
+	if err != nil {
+		return mapUniqueViolation(err)
+	}
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+	if err := saveProductRevision(ctx, tx, productID, editorID, &revision); err != nil {
+		return err
+	}
+	if err := saveProductPrice(ctx, tx, productID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetPriceHistory returns the price points since the given time, oldest first, led by the price that was in effect at that time.
+func (r *productRepositoryImpl) GetPriceHistory(ctx context.Context, productID string, since time.Time) ([]model.PriceHistory, error) {
+	const getPriceHistoryQuery string = `
+		(
+			SELECT price, changed_at FROM price_history
+			WHERE product_id = $1 AND changed_at < $2
+			ORDER BY changed_at DESC, id DESC
+			LIMIT 1
+		)
+		UNION ALL
+		(
+			SELECT price, changed_at FROM price_history
+			WHERE product_id = $1 AND changed_at >= $2
+		)
+		ORDER BY changed_at
+	`
+	args := []any{productID, since}
+	rows, err := r.db.QueryContext(ctx, getPriceHistoryQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	history := make([]model.PriceHistory, 0)
+	for rows.Next() {
+		var point model.PriceHistory
+		if err := rows.Scan(&point.Price, &point.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, point)
+	}
+	return history, rows.Err()
+}
+
+// Delete moves the product to the trash. Images, ratings and comments stay untouched until the purge.
 func (r *productRepositoryImpl) Delete(ctx context.Context, productID string) error {
-	const deleteProductQuery string = "DELETE FROM products WHERE id = $1"
+	const deleteProductQuery string = "UPDATE products SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL"
 	args := []any{productID}
-	_, err := r.db.ExecContext(ctx, deleteProductQuery, args...)
-	return err
+	result, err := r.db.ExecContext(ctx, deleteProductQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *productRepositoryImpl) Restore(ctx context.Context, productID string) error {
+	const restoreProductQuery string = "UPDATE products SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL"
+	args := []any{productID}
+	result, err := r.db.ExecContext(ctx, restoreProductQuery, args...)
+	if err != nil {
+		return mapUniqueViolation(err)
+	}
+	return requireAffected(result)
+}
+
+func (r *productRepositoryImpl) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purgeDeleted(ctx, r.db, "products", before)
 }
 
 func (r *productRepositoryImpl) Exists(ctx context.Context, productSlug string) (bool, error) {
-	const existsProductQuery string = "SELECT EXISTS (SELECT 1 FROM products WHERE slug = $1)"
+	const existsProductQuery string = "SELECT EXISTS (SELECT 1 FROM products WHERE slug = $1 AND deleted_at IS NULL)"
 	args := []any{productSlug}
 	var exists bool
 	if err := r.db.QueryRowContext(ctx, existsProductQuery, args...).Scan(&exists); err != nil {
@@ -180,9 +895,15 @@ func collectProductsRows(rows *sql.Rows) ([]model.Products, error) {
 			&product.CreatedAt,
 			&product.UpdatedAt,
 			&product.CategoryID,
+			&product.BrandID,
+			&product.Status,
+			&product.PublishAt,
+			&product.UnpublishAt,
+			&product.PublishedAt,
 			&product.AverageRating,
 			&product.RatingCount,
 			&product.MainImage,
+			&product.DeletedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -194,7 +915,7 @@ func collectProductsRows(rows *sql.Rows) ([]model.Products, error) {
 
 func collectProductRow(row *sql.Row) (*model.Product, error) {
 	var product model.Product
-	var imagesJSON []byte
+	var imagesJSON, tagsJSON []byte
 	err := row.Scan(
 		&product.ID,
 		&product.Name,
@@ -206,9 +927,15 @@ func collectProductRow(row *sql.Row) (*model.Product, error) {
 		&product.CreatedAt,
 		&product.UpdatedAt,
 		&product.CategoryID,
+		&product.BrandID,
+		&product.Status,
+		&product.PublishAt,
+		&product.UnpublishAt,
+		&product.PublishedAt,
 		&product.AverageRating,
 		&product.RatingCount,
 		&imagesJSON,
+		&tagsJSON,
 	)
 	if err != nil {
 		return nil, err
@@ -216,5 +943,73 @@ func collectProductRow(row *sql.Row) (*model.Product, error) {
 	if err := json.Unmarshal(imagesJSON, &product.Images); err != nil {
 		return nil, err
 	}
+	if err := json.Unmarshal(tagsJSON, &product.Tags); err != nil {
+		return nil, err
+	}
 	return &product, nil
 }
+
+// collectIDRows reads a single id column.
+func collectIDRows(rows *sql.Rows) ([]string, error) {
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// lockProduct locks a live product row for the rest of the transaction so revision numbers cannot race.
+func lockProduct(ctx context.Context, tx *sql.Tx, productID string) error {
+	const lockProductQuery string = "SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE"
+	args := []any{productID}
+	var id string
+	return tx.QueryRowContext(ctx, lockProductQuery, args...).Scan(&id)
+}
+
+func saveProductRevision(ctx context.Context, tx *sql.Tx, productID, editorID string, rolledBackFrom *int) error {
+	const saveProductRevisionQuery string = `
+		INSERT INTO product_revisions (product_id, revision, name, slug, description, short_description, price, quantity, category_id, brand_id, editor_id, rolled_back_from)
+		SELECT
+		    p.id,
+		    COALESCE((SELECT MAX(revision) FROM product_revisions WHERE product_id = p.id), 0) + 1,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.category_id,
+		    p.brand_id,
+		    NULLIF($2, '')::INTEGER,
+		    $3
+		FROM
+		    products p
+		WHERE
+		    p.id = $1
+	`
+	args := []any{productID, editorID, rolledBackFrom}
+	_, err := tx.ExecContext(ctx, saveProductRevisionQuery, args...)
+	return err
+}
+
+// saveProductPrice appends the current price to the history unless it equals the last recorded one.
+func saveProductPrice(ctx context.Context, tx *sql.Tx, productID string) error {
+	const saveProductPriceQuery string = `
+		INSERT INTO price_history (product_id, price)
+		SELECT p.id, p.price
+		FROM products p
+		WHERE p.id = $1 AND p.price IS DISTINCT FROM (
+			SELECT ph.price FROM price_history ph
+			WHERE ph.product_id = p.id
+			ORDER BY ph.changed_at DESC, ph.id DESC
+			LIMIT 1
+		)
+	`
+	args := []any{productID}
+	_, err := tx.ExecContext(ctx, saveProductPriceQuery, args...)
+	return err
+}
@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
+const productCommentColumns = "id, product_id, user_id, parent_id, comment, created_at, updated_at, deleted_at"
+
 type productCommentRepositoryImpl struct {
 	db *sql.DB
 }
@@ -20,12 +23,45 @@ func NewProductCommentRepository(db *sql.DB) domain.ProductCommentRepository {
 }
 
 func (r *productCommentRepositoryImpl) GetByID(ctx context.Context, productCommentID string) (*model.ProductComment, error) {
-	const getProductCommentQuery = `SELECT * FROM product_comments WHERE id = $1`
+	const getProductCommentQuery = `SELECT ` + productCommentColumns + ` FROM product_comments WHERE id = $1 AND deleted_at IS NULL`
 	args := []any{productCommentID}
 	row := r.db.QueryRowContext(ctx, getProductCommentQuery, args...)
 	return collectProductCommentRow(row)
 }
 
+func (r *productCommentRepositoryImpl) GetAllByUser(ctx context.Context, userID string, limit, offset int) ([]model.ProductComment, error) {
+	const getProductCommentsByUserQuery = `
+		SELECT ` + productCommentColumns + ` FROM product_comments
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{userID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductCommentsByUserQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductCommentRows(rows)
+}
+
+// GetAllDeleted lists the trash, most recently deleted first.
+func (r *productCommentRepositoryImpl) GetAllDeleted(ctx context.Context, limit, offset int) ([]model.ProductComment, error) {
+	const getDeletedProductCommentsQuery = `
+		SELECT ` + productCommentColumns + ` FROM product_comments
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+	args := []any{limit, offset}
+	rows, err := r.db.QueryContext(ctx, getDeletedProductCommentsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductCommentRows(rows)
+}
+
 func (r *productCommentRepositoryImpl) Create(ctx context.Context, productID, currentUserID string, comment *entity.ProductCommentCreateRequest) error {
 	const createProductCommentQuery = `INSERT INTO product_comments (product_id, user_id, parent_id, comment) VALUES ($1, $2, $3, $4)`
 	args := []any{productID, currentUserID, comment.ParentID, comment.Comment}
@@ -34,20 +70,38 @@ func (r *productCommentRepositoryImpl) Create(ctx context.Context, productID, cu
 }
 
 func (r *productCommentRepositoryImpl) Update(ctx context.Context, productCommentID string, comment *entity.ProductCommentUpdateRequest) error {
-	const updateProductCommentQuery = `UPDATE product_comments SET comment = $1 WHERE id = $2`
+	const updateProductCommentQuery = `UPDATE product_comments SET comment = $1 WHERE id = $2 AND deleted_at IS NULL`
 	args := []any{comment.Comment, productCommentID}
 	_, err := r.db.ExecContext(ctx, updateProductCommentQuery, args...)
 	return err
 }
 
+// Delete moves the comment and its replies to the trash.
 func (r *productCommentRepositoryImpl) Delete(ctx context.Context, productCommentID string) error {
-	const deleteProductCommentQuery = `DELETE FROM product_comments WHERE id = $1`
-	args := []any{productCommentID}
-	_, err := r.db.ExecContext(ctx, deleteProductCommentQuery, args...)
-	return err
+	return softDeleteTree(ctx, r.db, "product_comments", productCommentID)
+}
+
+func (r *productCommentRepositoryImpl) Restore(ctx context.Context, productCommentID string) error {
+	return restoreTree(ctx, r.db, "product_comments", productCommentID)
+}
+
+func (r *productCommentRepositoryImpl) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return purgeDeleted(ctx, r.db, "product_comments", before)
+}
+
+func collectProductCommentRows(rows *sql.Rows) ([]model.ProductComment, error) {
+	productComments := make([]model.ProductComment, 0)
+	for rows.Next() {
+		productComment, err := collectProductCommentRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		productComments = append(productComments, *productComment)
+	}
+	return productComments, rows.Err()
 }
 
-func collectProductCommentRow(row *sql.Row) (*model.ProductComment, error) {
+func collectProductCommentRow(row rowScanner) (*model.ProductComment, error) {
 	var productComment model.ProductComment
 	err := row.Scan(
 		&productComment.ID,
@@ -57,6 +111,7 @@ func collectProductCommentRow(row *sql.Row) (*model.ProductComment, error) {
 		&productComment.Comment,
 		&productComment.CreatedAt,
 		&productComment.UpdatedAt,
+		&productComment.DeletedAt,
 	)
 	if err != nil {
 		return nil, err
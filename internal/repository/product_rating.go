@@ -6,6 +6,7 @@ import (
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
 type productRatingRepositoryImpl struct {
@@ -18,6 +19,38 @@ func NewProductRatingRepository(db *sql.DB) domain.ProductRatingRepository {
 	}
 }
 
+func (r *productRatingRepositoryImpl) GetAllByUser(ctx context.Context, userID string, limit, offset int) ([]model.ProductRating, error) {
+	const getProductRatingsByUserQuery string = `
+		SELECT id, product_id, user_id, rating, created_at
+		FROM product_ratings
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{userID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductRatingsByUserQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	productRatings := make([]model.ProductRating, 0)
+	for rows.Next() {
+		var productRating model.ProductRating
+		err := rows.Scan(
+			&productRating.ID,
+			&productRating.ProductID,
+			&productRating.UserID,
+			&productRating.Rating,
+			&productRating.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		productRatings = append(productRatings, productRating)
+	}
+	return productRatings, rows.Err()
+}
+
 func (r *productRatingRepositoryImpl) CreateOrUpdate(ctx context.Context, productID, userID string, rate *entity.ProductRatingRequest) error {
 	const createOrUpdateProductRateQuery string = `
 		INSERT INTO product_ratings (product_id, user_id, rating)
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/lib/pq"
+)
+
+type productRelationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductRelationRepository(db *sql.DB) domain.ProductRelationRepository {
+	return &productRelationRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetPinnedIDs lists the products an admin related to the product by hand, in the order they were given.
+func (r *productRelationRepositoryImpl) GetPinnedIDs(ctx context.Context, productID string) ([]string, error) {
+	const getPinnedProductIDsQuery string = `
+		SELECT related_id FROM product_relations WHERE product_id = $1 ORDER BY position
+	`
+	args := []any{productID}
+	rows, err := r.db.QueryContext(ctx, getPinnedProductIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectIDRows(rows)
+}
+
+// GetRankedIDs ranks the published products that share the category of the product, sit in a sibling category or
+// match its name and description. Category matches give a fixed boost and the text rank breaks ties and lifts
+// matches from elsewhere in the catalog. The expression is the one idx_products_search is built on.
+func (r *productRelationRepositoryImpl) GetRankedIDs(ctx context.Context, productID string, limit int) ([]string, error) {
+	const getRankedProductIDsQuery string = `
+		WITH source AS (
+		    SELECT
+		        p.id,
+		        p.category_id,
+		        c.parent_id,
+		        websearch_to_tsquery(
+		            'english',
+		            array_to_string(tsvector_to_array(to_tsvector('english', p.name || ' ' || COALESCE(p.description, ''))), ' or ')
+		        ) AS query
+		    FROM
+		        products p
+		    LEFT JOIN
+		        categories c ON c.id = p.category_id
+		    WHERE
+		        p.id = $1
+		)
+		SELECT
+		    p.id
+		FROM
+		    products p
+		CROSS JOIN
+		    source s
+		LEFT JOIN
+		    categories c ON c.id = p.category_id AND c.deleted_at IS NULL
+		WHERE
+		    p.id <> s.id AND p.deleted_at IS NULL AND p.status = 'published' AND (
+		        p.category_id = s.category_id
+		        OR c.parent_id = s.parent_id
+		        OR to_tsvector('english', p.name || ' ' || COALESCE(p.description, '')) @@ s.query
+		    )
+		ORDER BY
+		    CASE WHEN p.category_id = s.category_id THEN 2 WHEN c.parent_id = s.parent_id THEN 1 ELSE 0 END
+		        + ts_rank(to_tsvector('english', p.name || ' ' || COALESCE(p.description, '')), s.query) * 4 DESC,
+		    p.id DESC
+		LIMIT $2
+	`
+	args := []any{productID, limit}
+	rows, err := r.db.QueryContext(ctx, getRankedProductIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectIDRows(rows)
+}
+
+// Replace swaps the pinned relations of the product for relatedIDs, keeping their order.
+func (r *productRelationRepositoryImpl) Replace(ctx context.Context, productID string, relatedIDs []int) error {
+	const deleteProductRelationsQuery string = "DELETE FROM product_relations WHERE product_id = $1"
+	const createProductRelationsQuery string = `
+		INSERT INTO product_relations (product_id, related_id, position)
+		SELECT $1, related.id, related.position FROM unnest($2::int[]) WITH ORDINALITY AS related(id, position)
+	`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, deleteProductRelationsQuery, productID); err != nil {
+		return err
+	}
+	args := []any{productID, pq.Array(relatedIDs)}
+	if _, err := tx.ExecContext(ctx, createProductRelationsQuery, args...); err != nil {
+		return mapForeignKeyViolation(err)
+	}
+	return tx.Commit()
+}
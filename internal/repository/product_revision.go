@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+const productRevisionColumns = "id, product_id, revision, name, slug, COALESCE(description, ''), COALESCE(short_description, ''), price, quantity, category_id, brand_id, editor_id, rolled_back_from, created_at"
+
+type productRevisionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductRevisionRepository(db *sql.DB) domain.ProductRevisionRepository {
+	return &productRevisionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *productRevisionRepositoryImpl) GetAll(ctx context.Context, productID string, limit, offset int) ([]model.ProductRevision, error) {
+	const getProductRevisionsQuery = `
+		SELECT ` + productRevisionColumns + ` FROM product_revisions
+		WHERE product_id = $1
+		ORDER BY revision DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{productID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getProductRevisionsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	productRevisions := make([]model.ProductRevision, 0)
+	for rows.Next() {
+		productRevision, err := collectProductRevisionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		productRevisions = append(productRevisions, *productRevision)
+	}
+	return productRevisions, rows.Err()
+}
+
+func (r *productRevisionRepositoryImpl) GetByRevision(ctx context.Context, productID string, revision int) (*model.ProductRevision, error) {
+	const getProductRevisionQuery = `SELECT ` + productRevisionColumns + ` FROM product_revisions WHERE product_id = $1 AND revision = $2`
+	args := []any{productID, revision}
+	row := r.db.QueryRowContext(ctx, getProductRevisionQuery, args...)
+	return collectProductRevisionRow(row)
+}
+
+func (r *productRevisionRepositoryImpl) GetLatest(ctx context.Context, productID string) (*model.ProductRevision, error) {
+	const getLatestProductRevisionQuery = `SELECT ` + productRevisionColumns + ` FROM product_revisions WHERE product_id = $1 ORDER BY revision DESC LIMIT 1`
+	args := []any{productID}
+	row := r.db.QueryRowContext(ctx, getLatestProductRevisionQuery, args...)
+	return collectProductRevisionRow(row)
+}
+
+func collectProductRevisionRow(row rowScanner) (*model.ProductRevision, error) {
+	var productRevision model.ProductRevision
+	err := row.Scan(
+		&productRevision.ID,
+		&productRevision.ProductID,
+		&productRevision.Revision,
+		&productRevision.Name,
+		&productRevision.Slug,
+		&productRevision.Description,
+		&productRevision.ShortDescription,
+		&productRevision.Price,
+		&productRevision.Quantity,
+		&productRevision.CategoryID,
+		&productRevision.BrandID,
+		&productRevision.EditorID,
+		&productRevision.RolledBackFrom,
+		&productRevision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &productRevision, nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductRepositoryImpl_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	repo := NewProductRepository(db)
+	tests := []struct {
+		name        string
+		affected    int64
+		expectedErr error
+	}{
+		{name: "Success - moved to the trash", affected: 1},
+		{name: "Error - missing or already trashed", affected: 0, expectedErr: sql.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectExec("UPDATE products SET deleted_at = CURRENT_TIMESTAMP WHERE id = \\$1 AND deleted_at IS NULL").
+				WithArgs("product-1").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			err := repo.Delete(context.Background(), "product-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepositoryImpl_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	repo := NewProductRepository(db)
+	tests := []struct {
+		name        string
+		affected    int64
+		expectedErr error
+	}{
+		{name: "Success - restored", affected: 1},
+		{name: "Error - missing or not in the trash", affected: 0, expectedErr: sql.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectExec("UPDATE products SET deleted_at = NULL WHERE id = \\$1 AND deleted_at IS NOT NULL").
+				WithArgs("product-1").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			err := repo.Restore(context.Background(), "product-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepositoryImpl_Rollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	repo := NewProductRepository(db)
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "Success - trashed category is not restored",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+					WithArgs("product-1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("product-1"))
+				mock.ExpectExec("UPDATE products p (.+) LEFT JOIN categories c ON c.id = pr.category_id AND c.deleted_at IS NULL LEFT JOIN brands b ON b.id = pr.brand_id").
+					WithArgs("product-1", 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO product_revisions").
+					WithArgs("product-1", "7", 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO price_history").
+					WithArgs("product-1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Error - revision not found",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+					WithArgs("product-1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("product-1"))
+				mock.ExpectExec("UPDATE products p (.+) LEFT JOIN categories c ON c.id = pr.category_id AND c.deleted_at IS NULL").
+					WithArgs("product-1", 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.Rollback(context.Background(), "product-1", "7", 2)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
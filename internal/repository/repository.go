@@ -14,6 +14,20 @@ type repositoryImpl struct {
 	productImageRepository       domain.ProductImageRepository
 	productCommentRepository     domain.ProductCommentRepository
 	productCommentLikeRepository domain.ProductCommentLikeRepository
+	notificationRepository       domain.NotificationRepository
+	userAddressRepository        domain.UserAddressRepository
+	auditRepository              domain.AuditRepository
+	productRevisionRepository    domain.ProductRevisionRepository
+	discountRepository           domain.DiscountRepository
+	couponRepository             domain.CouponRepository
+	stockMovementRepository      domain.StockMovementRepository
+	stockSubscriptionRepository  domain.StockSubscriptionRepository
+	wishlistRepository           domain.WishlistRepository
+	productRelationRepository    domain.ProductRelationRepository
+	brandRepository              domain.BrandRepository
+	tagRepository                domain.TagRepository
+	collectionRepository         domain.CollectionRepository
+	homeRepository               domain.HomeRepository
 }
 
 func NewRepository(db *sql.DB) domain.Repository {
@@ -25,6 +39,20 @@ func NewRepository(db *sql.DB) domain.Repository {
 		productImageRepository:       NewProductImageRepository(db),
 		productCommentRepository:     NewProductCommentRepository(db),
 		productCommentLikeRepository: NewProductCommentLikeRepository(db),
+		notificationRepository:       NewNotificationRepository(db),
+		userAddressRepository:        NewUserAddressRepository(db),
+		auditRepository:              NewAuditRepository(db),
+		productRevisionRepository:    NewProductRevisionRepository(db),
+		discountRepository:           NewDiscountRepository(db),
+		couponRepository:             NewCouponRepository(db),
+		stockMovementRepository:      NewStockMovementRepository(db),
+		stockSubscriptionRepository:  NewStockSubscriptionRepository(db),
+		wishlistRepository:           NewWishlistRepository(db),
+		productRelationRepository:    NewProductRelationRepository(db),
+		brandRepository:              NewBrandRepository(db),
+		tagRepository:                NewTagRepository(db),
+		collectionRepository:         NewCollectionRepository(db),
+		homeRepository:               NewHomeRepository(db),
 	}
 }
 
@@ -55,3 +83,59 @@ func (r *repositoryImpl) ProductComment() domain.ProductCommentRepository {
 func (r *repositoryImpl) ProductCommentLike() domain.ProductCommentLikeRepository {
 	return r.productCommentLikeRepository
 }
+
+func (r *repositoryImpl) Notification() domain.NotificationRepository {
+	return r.notificationRepository
+}
+
+func (r *repositoryImpl) UserAddress() domain.UserAddressRepository {
+	return r.userAddressRepository
+}
+
+func (r *repositoryImpl) Audit() domain.AuditRepository {
+	return r.auditRepository
+}
+
+func (r *repositoryImpl) ProductRevision() domain.ProductRevisionRepository {
+	return r.productRevisionRepository
+}
+
+func (r *repositoryImpl) Discount() domain.DiscountRepository {
+	return r.discountRepository
+}
+
+func (r *repositoryImpl) Coupon() domain.CouponRepository {
+	return r.couponRepository
+}
+
+func (r *repositoryImpl) StockMovement() domain.StockMovementRepository {
+	return r.stockMovementRepository
+}
+
+func (r *repositoryImpl) StockSubscription() domain.StockSubscriptionRepository {
+	return r.stockSubscriptionRepository
+}
+
+func (r *repositoryImpl) Wishlist() domain.WishlistRepository {
+	return r.wishlistRepository
+}
+
+func (r *repositoryImpl) ProductRelation() domain.ProductRelationRepository {
+	return r.productRelationRepository
+}
+
+func (r *repositoryImpl) Brand() domain.BrandRepository {
+	return r.brandRepository
+}
+
+func (r *repositoryImpl) Tag() domain.TagRepository {
+	return r.tagRepository
+}
+
+func (r *repositoryImpl) Collection() domain.CollectionRepository {
+	return r.collectionRepository
+}
+
+func (r *repositoryImpl) Home() domain.HomeRepository {
+	return r.homeRepository
+}
@@ -0,0 +1,6 @@
+package repository
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows so one collect helper can serve single and list queries.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
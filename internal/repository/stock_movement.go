@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+const stockMovementColumns = "id, product_id, kind, delta, quantity_after, reason, actor_id, created_at"
+
+type stockMovementRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewStockMovementRepository(db *sql.DB) domain.StockMovementRepository {
+	return &stockMovementRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *stockMovementRepositoryImpl) GetAll(ctx context.Context, productID string, limit, offset int) ([]model.StockMovement, error) {
+	const getAllStockMovementsQuery string = `
+		SELECT ` + stockMovementColumns + ` FROM stock_movements
+		WHERE product_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{productID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getAllStockMovementsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	movements := make([]model.StockMovement, 0)
+	for rows.Next() {
+		movement, err := collectStockMovementRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, *movement)
+	}
+	return movements, rows.Err()
+}
+
+// GetLowStock lists live products that are not archived and hold threshold items or fewer, emptiest first.
+func (r *stockMovementRepositoryImpl) GetLowStock(ctx context.Context, threshold, limit, offset int) ([]model.LowStockProduct, error) {
+	const getLowStockQuery string = `
+		SELECT id, name, slug, status, quantity
+		FROM products
+		WHERE deleted_at IS NULL AND status <> 'archived' AND quantity <= $1
+		ORDER BY quantity, id
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{threshold, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getLowStockQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	products := make([]model.LowStockProduct, 0)
+	for rows.Next() {
+		var product model.LowStockProduct
+		if err := rows.Scan(&product.ID, &product.Name, &product.Slug, &product.Status, &product.Quantity); err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+// Create books a movement and applies it to the product quantity in one transaction. The quantity is changed
+// relative to the locked row, so concurrent movements add up instead of overwriting each other, and a movement
+// that would take the quantity below zero fails with domain.ErrOutOfStock.
+func (r *stockMovementRepositoryImpl) Create(ctx context.Context, productID, actorID string, movement *entity.StockMovementRequest) (*model.StockMovement, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return nil, err
+	}
+	saved, err := saveStockMovement(ctx, tx, productID, actorID, movement)
+	if err != nil {
+		return nil, err
+	}
+	return saved, tx.Commit()
+}
+
+// saveStockMovement moves the quantity of a product and writes the ledger line for it.
+func saveStockMovement(ctx context.Context, tx *sql.Tx, productID, actorID string, movement *entity.StockMovementRequest) (*model.StockMovement, error) {
+	const moveStockQuery string = `
+		UPDATE products SET quantity = quantity + $2
+		WHERE id = $1 AND deleted_at IS NULL AND quantity + $2 >= 0
+		RETURNING quantity
+	`
+	var quantity int
+	err := tx.QueryRowContext(ctx, moveStockQuery, productID, movement.Delta).Scan(&quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrOutOfStock
+	}
+	if err != nil {
+		return nil, err
+	}
+	const saveStockMovementQuery string = `
+		INSERT INTO stock_movements (product_id, kind, delta, quantity_after, reason, actor_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, '')::INTEGER)
+		RETURNING ` + stockMovementColumns
+	args := []any{productID, movement.Kind, movement.Delta, quantity, movement.Reason, actorID}
+	return collectStockMovementRow(tx.QueryRowContext(ctx, saveStockMovementQuery, args...))
+}
+
+func collectStockMovementRow(row rowScanner) (*model.StockMovement, error) {
+	var movement model.StockMovement
+	err := row.Scan(
+		&movement.ID,
+		&movement.ProductID,
+		&movement.Kind,
+		&movement.Delta,
+		&movement.QuantityAfter,
+		&movement.Reason,
+		&movement.ActorID,
+		&movement.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
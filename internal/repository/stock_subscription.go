@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/lib/pq"
+)
+
+type stockSubscriptionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewStockSubscriptionRepository(db *sql.DB) domain.StockSubscriptionRepository {
+	return &stockSubscriptionRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create subscribes the user to the product. Subscribing twice is not an error.
+func (r *stockSubscriptionRepositoryImpl) Create(ctx context.Context, userID, productID string) error {
+	const createStockSubscriptionQuery string = `
+		INSERT INTO stock_subscriptions (user_id, product_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, product_id) DO NOTHING
+	`
+	args := []any{userID, productID}
+	_, err := r.db.ExecContext(ctx, createStockSubscriptionQuery, args...)
+	return mapForeignKeyViolation(err)
+}
+
+func (r *stockSubscriptionRepositoryImpl) Delete(ctx context.Context, userID, productID string) error {
+	const deleteStockSubscriptionQuery string = "DELETE FROM stock_subscriptions WHERE user_id = $1 AND product_id = $2"
+	args := []any{userID, productID}
+	result, err := r.db.ExecContext(ctx, deleteStockSubscriptionQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// GetSubscribers returns the oldest subscriptions of a product with the phone to text.
+func (r *stockSubscriptionRepositoryImpl) GetSubscribers(ctx context.Context, productID string, limit int) ([]model.StockSubscriber, error) {
+	const getStockSubscribersQuery string = `
+		SELECT s.id, s.user_id, u.phone
+		FROM stock_subscriptions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.product_id = $1
+		ORDER BY s.id
+		LIMIT $2
+	`
+	args := []any{productID, limit}
+	rows, err := r.db.QueryContext(ctx, getStockSubscribersQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	subscribers := make([]model.StockSubscriber, 0)
+	for rows.Next() {
+		var subscriber model.StockSubscriber
+		if err := rows.Scan(&subscriber.SubscriptionID, &subscriber.UserID, &subscriber.Phone); err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, subscriber)
+	}
+	return subscribers, rows.Err()
+}
+
+func (r *stockSubscriptionRepositoryImpl) DeleteByIDs(ctx context.Context, subscriptionIDs []string) error {
+	const deleteStockSubscriptionsQuery string = "DELETE FROM stock_subscriptions WHERE id = ANY($1::int[])"
+	args := []any{pq.Array(subscriptionIDs)}
+	_, err := r.db.ExecContext(ctx, deleteStockSubscriptionsQuery, args...)
+	return err
+}
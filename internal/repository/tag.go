@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/gosimple/slug"
+	"github.com/lib/pq"
+)
+
+type tagRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) domain.TagRepository {
+	return &tagRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *tagRepositoryImpl) GetAll(ctx context.Context) ([]model.Tag, error) {
+	const getAllTagsQuery string = "SELECT id, name, slug FROM tags ORDER BY name"
+	rows, err := r.db.QueryContext(ctx, getAllTagsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tags := make([]model.Tag, 0)
+	for rows.Next() {
+		var tag model.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (r *tagRepositoryImpl) GetByID(ctx context.Context, tagID string) (*model.Tag, error) {
+	const getTagByIDQuery string = "SELECT id, name, slug FROM tags WHERE id = $1"
+	args := []any{tagID}
+	var tag model.Tag
+	if err := r.db.QueryRowContext(ctx, getTagByIDQuery, args...).Scan(&tag.ID, &tag.Name, &tag.Slug); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepositoryImpl) GetBySlug(ctx context.Context, tagSlug string) (*model.Tag, error) {
+	const getTagBySlugQuery string = "SELECT id, name, slug FROM tags WHERE slug = $1"
+	args := []any{tagSlug}
+	var tag model.Tag
+	if err := r.db.QueryRowContext(ctx, getTagBySlugQuery, args...).Scan(&tag.ID, &tag.Name, &tag.Slug); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepositoryImpl) GetProductTagIDs(ctx context.Context, productID string) ([]string, error) {
+	const getProductTagIDsQuery string = "SELECT tag_id FROM product_tags WHERE product_id = $1 ORDER BY tag_id"
+	args := []any{productID}
+	rows, err := r.db.QueryContext(ctx, getProductTagIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectIDRows(rows)
+}
+
+func (r *tagRepositoryImpl) Create(ctx context.Context, tag *entity.TagRequest) (string, error) {
+	const createTagQuery string = "INSERT INTO tags (name, slug) VALUES ($1, $2) RETURNING id"
+	args := []any{tag.Name, slug.Make(tag.Slug)}
+	var tagID string
+	if err := r.db.QueryRowContext(ctx, createTagQuery, args...).Scan(&tagID); err != nil {
+		return "", mapUniqueViolation(err)
+	}
+	return tagID, nil
+}
+
+func (r *tagRepositoryImpl) Update(ctx context.Context, tagID string, tag *entity.TagRequest) error {
+	const updateTagQuery string = "UPDATE tags SET name = $1, slug = $2 WHERE id = $3"
+	args := []any{tag.Name, slug.Make(tag.Slug), tagID}
+	result, err := r.db.ExecContext(ctx, updateTagQuery, args...)
+	if err != nil {
+		return mapUniqueViolation(err)
+	}
+	return requireAffected(result)
+}
+
+// Delete removes the tag from every product and drops it.
+func (r *tagRepositoryImpl) Delete(ctx context.Context, tagID string) error {
+	const deleteTagQuery string = "DELETE FROM tags WHERE id = $1"
+	args := []any{tagID}
+	result, err := r.db.ExecContext(ctx, deleteTagQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// ReplaceProductTags swaps the tags of a live product for tagIDs, sql.ErrNoRows when the product or a tag is missing.
+func (r *tagRepositoryImpl) ReplaceProductTags(ctx context.Context, productID string, tagIDs []int) error {
+	const deleteProductTagsQuery string = "DELETE FROM product_tags WHERE product_id = $1"
+	const createProductTagsQuery string = "INSERT INTO product_tags (product_id, tag_id) SELECT $1, unnest($2::int[])"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, deleteProductTagsQuery, productID); err != nil {
+		return err
+	}
+	args := []any{productID, pq.Array(tagIDs)}
+	if _, err := tx.ExecContext(ctx, createProductTagsQuery, args...); err != nil {
+		return mapForeignKeyViolation(err)
+	}
+	return tx.Commit()
+}
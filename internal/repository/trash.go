@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+)
+
+// softDeleteTree and restoreTree serve tables that nest rows through parent_id, which today is only
+// product comments. Categories trash and restore their subtree through path instead. purgeDeleted serves
+// every soft deleted table. Table names are package constants, never user input.
+
+// softDeleteTree moves a row and its live descendants to the trash. They all share one deleted_at
+// because CURRENT_TIMESTAMP is fixed for the statement, which is what restoreTree keys on.
+func softDeleteTree(ctx context.Context, db *sql.DB, table, id string) error {
+	softDeleteTreeQuery := fmt.Sprintf(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM %[1]s WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id FROM %[1]s t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		UPDATE %[1]s SET deleted_at = CURRENT_TIMESTAMP WHERE id IN (SELECT id FROM subtree)
+	`, table)
+	args := []any{id}
+	result, err := db.ExecContext(ctx, softDeleteTreeQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// restoreTree brings back a trashed row together with the descendants that were trashed with it.
+// Descendants deleted on their own earlier stay in the trash. A row whose parent is still trashed
+// cannot be restored on its own.
+func restoreTree(ctx context.Context, db *sql.DB, table, id string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	getTrashedQuery := fmt.Sprintf(`
+		SELECT t.deleted_at, p.deleted_at IS NOT NULL
+		FROM %[1]s t
+		LEFT JOIN %[1]s p ON p.id = t.parent_id
+		WHERE t.id = $1
+		FOR UPDATE OF t
+	`, table)
+	var deletedAt sql.NullTime
+	var parentDeleted bool
+	if err := tx.QueryRowContext(ctx, getTrashedQuery, id).Scan(&deletedAt, &parentDeleted); err != nil {
+		return err
+	}
+	if !deletedAt.Valid {
+		return sql.ErrNoRows
+	}
+	if parentDeleted {
+		return domain.ErrParentDeleted
+	}
+	restoreTreeQuery := fmt.Sprintf(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM %[1]s WHERE id = $1
+			UNION ALL
+			SELECT t.id FROM %[1]s t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at = $2
+		)
+		UPDATE %[1]s SET deleted_at = NULL WHERE id IN (SELECT id FROM subtree)
+	`, table)
+	args := []any{id, deletedAt.Time}
+	if _, err := tx.ExecContext(ctx, restoreTreeQuery, args...); err != nil {
+		return mapUniqueViolation(err)
+	}
+	return tx.Commit()
+}
+
+// purgeDeleted hard deletes rows that have been in the trash since before the given time.
+func purgeDeleted(ctx context.Context, db *sql.DB, table string, before time.Time) (int64, error) {
+	purgeQuery := fmt.Sprintf("DELETE FROM %s WHERE deleted_at < $1", table)
+	args := []any{before}
+	result, err := db.ExecContext(ctx, purgeQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
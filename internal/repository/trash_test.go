@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDeleteTree(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "Success - row and replies trashed",
+			setupMock: func() {
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) UPDATE product_comments SET deleted_at = CURRENT_TIMESTAMP WHERE id IN \\(SELECT id FROM subtree\\)").
+					WithArgs("comment-1").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+		{
+			name: "Error - missing or already trashed",
+			setupMock: func() {
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) UPDATE product_comments SET deleted_at = CURRENT_TIMESTAMP").
+					WithArgs("comment-1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name: "Error - database failure",
+			setupMock: func() {
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) UPDATE product_comments SET deleted_at = CURRENT_TIMESTAMP").
+					WithArgs("comment-1").
+					WillReturnError(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := softDeleteTree(context.Background(), db, "product_comments", "comment-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestoreTree(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	deletedAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "Success - restored with the replies trashed alongside",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.deleted_at, p.deleted_at IS NOT NULL FROM product_comments t (.+) FOR UPDATE OF t").
+					WithArgs("comment-1").
+					WillReturnRows(sqlmock.NewRows([]string{"deleted_at", "parent_deleted"}).AddRow(deletedAt, false))
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) WHERE t.deleted_at = \\$2 (.+) UPDATE product_comments SET deleted_at = NULL").
+					WithArgs("comment-1", deletedAt).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Error - parent still trashed",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.deleted_at, p.deleted_at IS NOT NULL FROM product_comments t (.+) FOR UPDATE OF t").
+					WithArgs("comment-1").
+					WillReturnRows(sqlmock.NewRows([]string{"deleted_at", "parent_deleted"}).AddRow(deletedAt, true))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrParentDeleted,
+		},
+		{
+			name: "Error - not in the trash",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.deleted_at, p.deleted_at IS NOT NULL FROM product_comments t (.+) FOR UPDATE OF t").
+					WithArgs("comment-1").
+					WillReturnRows(sqlmock.NewRows([]string{"deleted_at", "parent_deleted"}).AddRow(nil, false))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name: "Error - missing",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.deleted_at, p.deleted_at IS NOT NULL FROM product_comments t (.+) FOR UPDATE OF t").
+					WithArgs("comment-1").
+					WillReturnRows(sqlmock.NewRows([]string{"deleted_at", "parent_deleted"}))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := restoreTree(context.Background(), db, "product_comments", "comment-1")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,12 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
+const userColumns = "id, phone, created_at, is_admin, email, notify_sms, notify_email, first_name, last_name, birthday, national_id, session_version, is_banned, banned_at"
+
 type userRepositoryImpl struct {
 	db *sql.DB
 }
@@ -20,7 +25,7 @@ func NewUserRepository(db *sql.DB) domain.UserRepository {
 }
 
 func (r *userRepositoryImpl) GetByID(ctx context.Context, userID string) (*model.User, error) {
-	const getUserByIDQuery string = "SELECT * FROM users WHERE id = $1"
+	const getUserByIDQuery string = "SELECT " + userColumns + " FROM users WHERE id = $1"
 	args := []any{userID}
 	row := r.db.QueryRowContext(ctx, getUserByIDQuery, args...)
 	if err := row.Err(); err != nil {
@@ -30,7 +35,7 @@ func (r *userRepositoryImpl) GetByID(ctx context.Context, userID string) (*model
 }
 
 func (r *userRepositoryImpl) GetByPhone(ctx context.Context, phone string) (*model.User, error) {
-	const getUserByPhoneQuery string = "SELECT * FROM users WHERE phone = $1"
+	const getUserByPhoneQuery string = "SELECT " + userColumns + " FROM users WHERE phone = $1"
 	args := []any{phone}
 	row := r.db.QueryRowContext(ctx, getUserByPhoneQuery, args...)
 	if err := row.Err(); err != nil {
@@ -39,20 +44,260 @@ func (r *userRepositoryImpl) GetByPhone(ctx context.Context, phone string) (*mod
 	return collectUserRow(row)
 }
 
-func (r *userRepositoryImpl) Create(ctx context.Context, user *entity.UserAuthRequest) error {
+func (r *userRepositoryImpl) GetAll(ctx context.Context, params *entity.UserQueryParamRequest) ([]model.User, error) {
+	conditions := make([]string, 0, 3)
+	args := make([]any, 0, 5)
+	if params.Phone != "" {
+		args = append(args, params.Phone)
+		conditions = append(conditions, fmt.Sprintf("phone LIKE $%d || '%%'", len(args)))
+	}
+	if params.CreatedFrom != "" {
+		args = append(args, params.CreatedFrom)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d::date", len(args)))
+	}
+	if params.CreatedTo != "" {
+		args = append(args, params.CreatedTo)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d::date + 1", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, params.Limit, params.Offset)
+	query := fmt.Sprintf(
+		"SELECT %s FROM users %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
+		userColumns, where, len(args)-1, len(args),
+	)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	users := make([]model.User, 0)
+	for rows.Next() {
+		user, err := collectUserRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, rows.Err()
+}
+
+// GetAdminIDs lists the admins that are not banned, the audience of operational alerts.
+func (r *userRepositoryImpl) GetAdminIDs(ctx context.Context) ([]string, error) {
+	const getAdminIDsQuery string = "SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE ORDER BY id"
+	rows, err := r.db.QueryContext(ctx, getAdminIDsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Create inserts the user if the phone is not registered yet and reports whether a new row was created.
+func (r *userRepositoryImpl) Create(ctx context.Context, user *entity.UserAuthRequest) (bool, error) {
 	const createUserQuery string = "INSERT INTO users (phone) VALUES ($1) ON CONFLICT DO NOTHING "
 	args := []any{user.Phone}
-	_, err := r.db.ExecContext(ctx, createUserQuery, args...)
+	result, err := r.db.ExecContext(ctx, createUserQuery, args...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *userRepositoryImpl) UpdateNotificationPreference(ctx context.Context, userID string, preference *entity.UserNotificationPreferenceRequest) error {
+	const updateNotificationPreferenceQuery string = "UPDATE users SET notify_sms = $1, notify_email = $2 WHERE id = $3"
+	args := []any{*preference.NotifySms, *preference.NotifyEmail, userID}
+	_, err := r.db.ExecContext(ctx, updateNotificationPreferenceQuery, args...)
 	return err
 }
 
-func collectUserRow(row *sql.Row) (*model.User, error) {
+func (r *userRepositoryImpl) UpdateProfile(ctx context.Context, userID string, profile *entity.UserProfileUpdateRequest) error {
+	const updateUserProfileQuery string = "UPDATE users SET first_name = $1, last_name = $2, birthday = $3, national_id = $4 WHERE id = $5"
+	args := []any{profile.FirstName, profile.LastName, profile.Birthday, profile.NationalID, userID}
+	_, err := r.db.ExecContext(ctx, updateUserProfileQuery, args...)
+	return mapUniqueViolation(err)
+}
+
+func (r *userRepositoryImpl) UpdateEmail(ctx context.Context, userID, email string) error {
+	const updateUserEmailQuery string = "UPDATE users SET email = $1 WHERE id = $2"
+	args := []any{email, userID}
+	_, err := r.db.ExecContext(ctx, updateUserEmailQuery, args...)
+	return mapUniqueViolation(err)
+}
+
+// UpdatePhone swaps the phone only if it still matches oldPhone and revokes issued tokens in the same statement,
+// so a concurrent change or a stale session can never observe a half applied swap.
+func (r *userRepositoryImpl) UpdatePhone(ctx context.Context, userID, oldPhone, newPhone string) error {
+	const updateUserPhoneQuery string = `
+		UPDATE users
+		SET phone = $1, session_version = session_version + 1
+		WHERE id = $2 AND phone = $3
+	`
+	args := []any{newPhone, userID, oldPhone}
+	result, err := r.db.ExecContext(ctx, updateUserPhoneQuery, args...)
+	if err != nil {
+		return mapUniqueViolation(err)
+	}
+	return requireAffected(result)
+}
+
+func (r *userRepositoryImpl) IncrementSessionVersion(ctx context.Context, userID string) error {
+	const incrementSessionVersionQuery string = "UPDATE users SET session_version = session_version + 1 WHERE id = $1"
+	args := []any{userID}
+	result, err := r.db.ExecContext(ctx, incrementSessionVersionQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// SetBanned bans or unbans the user. Banning also revokes issued tokens in the same statement. Banning the last
+// active admin fails with domain.ErrLastAdmin, like demoting them.
+func (r *userRepositoryImpl) SetBanned(ctx context.Context, userID string, banned bool) error {
+	const setUserBannedQuery string = `
+		UPDATE users
+		SET
+		    is_banned = $1,
+		    banned_at = CASE WHEN $1 THEN CURRENT_TIMESTAMP END,
+		    session_version = session_version + CASE WHEN $1 THEN 1 ELSE 0 END
+		WHERE id = $2
+	`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if banned {
+		if err := requireOtherAdmin(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+	args := []any{banned, userID}
+	result, err := tx.ExecContext(ctx, setUserBannedQuery, args...)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetAdmin changes the role of the user and revokes issued tokens since they carry the old role. Demoting the
+// last active admin fails with domain.ErrLastAdmin.
+func (r *userRepositoryImpl) SetAdmin(ctx context.Context, userID string, isAdmin bool) error {
+	const setUserAdminQuery string = "UPDATE users SET is_admin = $1, session_version = session_version + 1 WHERE id = $2"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if !isAdmin {
+		if err := requireOtherAdmin(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+	args := []any{isAdmin, userID}
+	result, err := tx.ExecContext(ctx, setUserAdminQuery, args...)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Delete removes the user account. Comments and ratings are kept for the product but detached from the user,
+// addresses go with the account and the delivery history is dropped because it holds the phone and email.
+// The last active admin cannot delete their account, that fails with domain.ErrLastAdmin.
+func (r *userRepositoryImpl) Delete(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := requireOtherAdmin(ctx, tx, userID); err != nil {
+		return err
+	}
+	queries := []string{
+		"UPDATE product_comments SET user_id = NULL WHERE user_id = $1",
+		"UPDATE product_ratings SET user_id = NULL WHERE user_id = $1",
+		"DELETE FROM notifications WHERE user_id = $1",
+		"DELETE FROM users WHERE id = $1",
+	}
+	args := []any{userID}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// requireOtherAdmin is called before the user stops being an active admin. It locks the other active admins for
+// the rest of the transaction, so concurrent demotions, bans or deletions cannot race past each other, and fails
+// with domain.ErrLastAdmin when the user is an active admin and none is left.
+func requireOtherAdmin(ctx context.Context, tx *sql.Tx, userID string) error {
+	const lockOtherAdminsQuery string = "SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> $1 ORDER BY id FOR UPDATE"
+	const isActiveAdminQuery string = "SELECT is_admin AND NOT is_banned FROM users WHERE id = $1"
+	args := []any{userID}
+	rows, err := tx.QueryContext(ctx, lockOtherAdminsQuery, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	adminIDs, err := collectIDRows(rows)
+	if err != nil {
+		return err
+	}
+	if len(adminIDs) > 0 {
+		return nil
+	}
+	var activeAdmin bool
+	if err := tx.QueryRowContext(ctx, isActiveAdminQuery, args...).Scan(&activeAdmin); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if activeAdmin {
+		return domain.ErrLastAdmin
+	}
+	return nil
+}
+
+func collectUserRow(row rowScanner) (*model.User, error) {
 	var user model.User
 	err := row.Scan(
 		&user.ID,
 		&user.Phone,
 		&user.CreatedAt,
 		&user.IsAdmin,
+		&user.Email,
+		&user.NotifySms,
+		&user.NotifyEmail,
+		&user.FirstName,
+		&user.LastName,
+		&user.Birthday,
+		&user.NationalID,
+		&user.SessionVersion,
+		&user.IsBanned,
+		&user.BannedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type userAddressRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewUserAddressRepository(db *sql.DB) domain.UserAddressRepository {
+	return &userAddressRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *userAddressRepositoryImpl) GetAll(ctx context.Context, userID string) ([]model.UserAddress, error) {
+	const getAllUserAddressesQuery string = `
+		SELECT
+		    id,
+		    user_id,
+		    title,
+		    receiver_name,
+		    receiver_phone,
+		    province,
+		    city,
+		    postal_code,
+		    address,
+		    is_default,
+		    created_at,
+		    updated_at
+		FROM
+		    user_addresses
+		WHERE
+		    user_id = $1
+		ORDER BY
+		    is_default DESC, created_at DESC
+	`
+	args := []any{userID}
+	rows, err := r.db.QueryContext(ctx, getAllUserAddressesQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectUserAddressRows(rows)
+}
+
+func (r *userAddressRepositoryImpl) Create(ctx context.Context, userID string, address *entity.UserAddressRequest) error {
+	const createUserAddressQuery string = `
+		INSERT INTO user_addresses (user_id, title, receiver_name, receiver_phone, province, city, postal_code, address, is_default)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if address.IsDefault {
+		if err := clearDefaultUserAddress(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+	args := []any{
+		userID,
+		address.Title,
+		address.ReceiverName,
+		address.ReceiverPhone,
+		address.Province,
+		address.City,
+		address.PostalCode,
+		address.Address,
+		address.IsDefault,
+	}
+	if _, err := tx.ExecContext(ctx, createUserAddressQuery, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *userAddressRepositoryImpl) Update(ctx context.Context, addressID, userID string, address *entity.UserAddressRequest) error {
+	const updateUserAddressQuery string = `
+		UPDATE user_addresses
+		SET
+		    title = $1,
+		    receiver_name = $2,
+		    receiver_phone = $3,
+		    province = $4,
+		    city = $5,
+		    postal_code = $6,
+		    address = $7,
+		    is_default = $8,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE
+		    id = $9 AND user_id = $10
+	`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if address.IsDefault {
+		if err := clearDefaultUserAddress(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+	args := []any{
+		address.Title,
+		address.ReceiverName,
+		address.ReceiverPhone,
+		address.Province,
+		address.City,
+		address.PostalCode,
+		address.Address,
+		address.IsDefault,
+		addressID,
+		userID,
+	}
+	result, err := tx.ExecContext(ctx, updateUserAddressQuery, args...)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *userAddressRepositoryImpl) Delete(ctx context.Context, addressID, userID string) error {
+	const deleteUserAddressQuery string = "DELETE FROM user_addresses WHERE id = $1 AND user_id = $2"
+	args := []any{addressID, userID}
+	result, err := r.db.ExecContext(ctx, deleteUserAddressQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func clearDefaultUserAddress(ctx context.Context, tx *sql.Tx, userID string) error {
+	const clearDefaultUserAddressQuery string = "UPDATE user_addresses SET is_default = FALSE WHERE user_id = $1 AND is_default"
+	_, err := tx.ExecContext(ctx, clearDefaultUserAddressQuery, userID)
+	return err
+}
+
+func collectUserAddressRows(rows *sql.Rows) ([]model.UserAddress, error) {
+	addresses := make([]model.UserAddress, 0)
+	for rows.Next() {
+		var address model.UserAddress
+		err := rows.Scan(
+			&address.ID,
+			&address.UserID,
+			&address.Title,
+			&address.ReceiverName,
+			&address.ReceiverPhone,
+			&address.Province,
+			&address.City,
+			&address.PostalCode,
+			&address.Address,
+			&address.IsDefault,
+			&address.CreatedAt,
+			&address.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, rows.Err()
+}
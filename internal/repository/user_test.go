@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -31,9 +32,9 @@ func TestUserRepositoryImpl_GetByID(t *testing.T) {
 			name:   "Success - user found",
 			userID: "user-123",
 			setupMock: func() {
-				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin"}).
-					AddRow("user-123", "1234567890", fixedTime, false)
-				mock.ExpectQuery("SELECT \\* FROM users WHERE id = \\$1").
+				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin", "email", "notify_sms", "notify_email", "first_name", "last_name", "birthday", "national_id", "session_version", "is_banned", "banned_at"}).
+					AddRow("user-123", "1234567890", fixedTime, false, nil, true, false, nil, nil, nil, nil, 0, false, nil)
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
 					WithArgs("user-123").
 					WillReturnRows(rows)
 			},
@@ -42,6 +43,7 @@ func TestUserRepositoryImpl_GetByID(t *testing.T) {
 				Phone:     "1234567890",
 				CreatedAt: fixedTime,
 				IsAdmin:   false,
+				NotifySms: true,
 			},
 			expectedErr: nil,
 		},
@@ -49,7 +51,7 @@ func TestUserRepositoryImpl_GetByID(t *testing.T) {
 			name:   "Error - user not found",
 			userID: "user-999",
 			setupMock: func() {
-				mock.ExpectQuery("SELECT \\* FROM users WHERE id = \\$1").
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
 					WithArgs("user-999").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -73,6 +75,61 @@ func TestUserRepositoryImpl_GetByID(t *testing.T) {
 	}
 }
 
+func TestUserRepositoryImpl_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	repo := NewUserRepository(db)
+	fixedTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		params        *entity.UserQueryParamRequest
+		setupMock     func()
+		expectedUsers []model.User
+	}{
+		{
+			name:   "Success - no filters",
+			params: &entity.UserQueryParamRequest{Limit: 20, Offset: 0},
+			setupMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin", "email", "notify_sms", "notify_email", "first_name", "last_name", "birthday", "national_id", "session_version", "is_banned", "banned_at"}).
+					AddRow("user-123", "+989120000000", fixedTime, false, nil, true, false, nil, nil, nil, nil, 0, false, nil)
+				mock.ExpectQuery("SELECT (.+) FROM users ORDER BY created_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
+					WithArgs(20, 0).
+					WillReturnRows(rows)
+			},
+			expectedUsers: []model.User{
+				{ID: "user-123", Phone: "+989120000000", CreatedAt: fixedTime, NotifySms: true},
+			},
+		},
+		{
+			name: "Success - phone prefix and created range",
+			params: &entity.UserQueryParamRequest{
+				Phone:       "+98912",
+				CreatedFrom: "2022-01-01",
+				CreatedTo:   "2022-01-31",
+				Limit:       10,
+				Offset:      10,
+			},
+			setupMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin", "email", "notify_sms", "notify_email", "first_name", "last_name", "birthday", "national_id", "session_version", "is_banned", "banned_at"})
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE phone LIKE \\$1 \\|\\| '%' AND created_at >= \\$2::date AND created_at < \\$3::date \\+ 1 ORDER BY (.+) LIMIT \\$4 OFFSET \\$5").
+					WithArgs("+98912", "2022-01-01", "2022-01-31", 10, 10).
+					WillReturnRows(rows)
+			},
+			expectedUsers: []model.User{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			users, err := repo.GetAll(context.Background(), tt.params)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedUsers, users)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestUserRepositoryImpl_GetByPhone(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err, "failed to create mock database")
@@ -90,9 +147,9 @@ func TestUserRepositoryImpl_GetByPhone(t *testing.T) {
 			name:  "Success - user found",
 			phone: "1234567890",
 			setupMock: func() {
-				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin"}).
-					AddRow("user-1234", "1234567890", fixedTime, false)
-				mock.ExpectQuery("SELECT \\* FROM users WHERE phone = \\$1").
+				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin", "email", "notify_sms", "notify_email", "first_name", "last_name", "birthday", "national_id", "session_version", "is_banned", "banned_at"}).
+					AddRow("user-1234", "1234567890", fixedTime, false, nil, true, false, nil, nil, nil, nil, 0, false, nil)
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE phone = \\$1").
 					WithArgs("1234567890").
 					WillReturnRows(rows)
 			},
@@ -101,6 +158,7 @@ func TestUserRepositoryImpl_GetByPhone(t *testing.T) {
 				Phone:     "1234567890",
 				CreatedAt: fixedTime,
 				IsAdmin:   false,
+				NotifySms: true,
 			},
 			expectedErr: nil,
 		},
@@ -108,7 +166,7 @@ func TestUserRepositoryImpl_GetByPhone(t *testing.T) {
 			name:  "Error - user not found",
 			phone: "1234567891",
 			setupMock: func() {
-				mock.ExpectQuery("SELECT \\* FROM users WHERE phone = \\$1").
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE phone = \\$1").
 					WithArgs("1234567891").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -138,10 +196,11 @@ func TestUserRepositoryImpl_Create(t *testing.T) {
 	defer db.Close()
 	repo := NewUserRepository(db)
 	tests := []struct {
-		name        string
-		user        *entity.UserAuthRequest
-		setupMock   func()
-		expectedErr error
+		name            string
+		user            *entity.UserAuthRequest
+		setupMock       func()
+		expectedCreated bool
+		expectedErr     error
 	}{
 		{
 			name: "Success - user created",
@@ -153,7 +212,8 @@ func TestUserRepositoryImpl_Create(t *testing.T) {
 					WithArgs("+1234567890").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
-			expectedErr: nil,
+			expectedCreated: true,
+			expectedErr:     nil,
 		},
 		{
 			name: "Success - phone already exists (conflict handled)",
@@ -165,7 +225,8 @@ func TestUserRepositoryImpl_Create(t *testing.T) {
 					WithArgs("+1234567890").
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
-			expectedErr: nil,
+			expectedCreated: false,
+			expectedErr:     nil,
 		},
 	}
 	for _, tt := range tests {
@@ -173,7 +234,7 @@ func TestUserRepositoryImpl_Create(t *testing.T) {
 			if tt.setupMock != nil {
 				tt.setupMock()
 			}
-			err := repo.Create(context.Background(), tt.user)
+			created, err := repo.Create(context.Background(), tt.user)
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				if !errors.Is(tt.expectedErr, assert.AnError) {
@@ -181,6 +242,7 @@ func TestUserRepositoryImpl_Create(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCreated, created)
 			}
 			if tt.setupMock != nil {
 				assert.NoError(t, mock.ExpectationsWereMet())
@@ -189,6 +251,233 @@ func TestUserRepositoryImpl_Create(t *testing.T) {
 	}
 }
 
+func TestUserRepositoryImpl_SetBanned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	repo := NewUserRepository(db)
+	tests := []struct {
+		name        string
+		banned      bool
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name:   "Success - unbanned",
+			banned: false,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users SET (.+) WHERE id = \\$2").
+					WithArgs(false, "user-123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Success - customer banned while no other admin is active",
+			banned: true,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> \\$1 ORDER BY id FOR UPDATE").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT is_admin AND NOT is_banned FROM users WHERE id = \\$1").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"active_admin"}).AddRow(false))
+				mock.ExpectExec("UPDATE users SET (.+) WHERE id = \\$2").
+					WithArgs(true, "user-123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Success - admin banned with another admin left",
+			banned: true,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> \\$1 ORDER BY id FOR UPDATE").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user-456"))
+				mock.ExpectExec("UPDATE users SET (.+) WHERE id = \\$2").
+					WithArgs(true, "user-123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Error - last active admin",
+			banned: true,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> \\$1 ORDER BY id FOR UPDATE").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT is_admin AND NOT is_banned FROM users WHERE id = \\$1").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"active_admin"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrLastAdmin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.SetBanned(context.Background(), "user-123", tt.banned)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepositoryImpl_SetAdmin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	repo := NewUserRepository(db)
+	tests := []struct {
+		name        string
+		userID      string
+		isAdmin     bool
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name:    "Success - promoted",
+			userID:  "user-123",
+			isAdmin: true,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users SET is_admin = \\$1, session_version = session_version \\+ 1 WHERE id = \\$2").
+					WithArgs(true, "user-123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "Success - demoted with another admin left",
+			userID:  "user-123",
+			isAdmin: false,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> \\$1 ORDER BY id FOR UPDATE").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user-456"))
+				mock.ExpectExec("UPDATE users SET is_admin = \\$1, session_version = session_version \\+ 1 WHERE id = \\$2").
+					WithArgs(false, "user-123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "Error - last active admin",
+			userID:  "user-123",
+			isAdmin: false,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> \\$1 ORDER BY id FOR UPDATE").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT is_admin AND NOT is_banned FROM users WHERE id = \\$1").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"active_admin"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrLastAdmin,
+		},
+		{
+			name:    "Error - user not found",
+			userID:  "missing",
+			isAdmin: true,
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users SET is_admin = \\$1, session_version = session_version \\+ 1 WHERE id = \\$2").
+					WithArgs(true, "missing").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.SetAdmin(context.Background(), tt.userID, tt.isAdmin)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepositoryImpl_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "failed to create mock database")
+	defer db.Close()
+	repo := NewUserRepository(db)
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+	}{
+		{
+			name: "Success - deleted with another admin left",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> \\$1 ORDER BY id FOR UPDATE").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user-456"))
+				mock.ExpectExec("UPDATE product_comments SET user_id = NULL WHERE user_id = \\$1").
+					WithArgs("user-123").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE product_ratings SET user_id = NULL WHERE user_id = \\$1").
+					WithArgs("user-123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM notifications WHERE user_id = \\$1").
+					WithArgs("user-123").
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
+					WithArgs("user-123").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Error - last active admin",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users WHERE is_admin = TRUE AND is_banned = FALSE AND id <> \\$1 ORDER BY id FOR UPDATE").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT is_admin AND NOT is_banned FROM users WHERE id = \\$1").
+					WithArgs("user-123").
+					WillReturnRows(sqlmock.NewRows([]string{"active_admin"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrLastAdmin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.Delete(context.Background(), "user-123")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestCollectUserRow(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err, "failed to create mock database")
@@ -202,9 +491,9 @@ func TestCollectUserRow(t *testing.T) {
 		{
 			name: "Success - collect user row",
 			setupMock: func() {
-				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin"}).
-					AddRow("user-123", "+1234567890", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), true)
-				mock.ExpectQuery("SELECT \\* FROM users WHERE id = \\$1").
+				rows := sqlmock.NewRows([]string{"id", "phone", "created_at", "is_admin", "email", "notify_sms", "notify_email", "first_name", "last_name", "birthday", "national_id", "session_version", "is_banned", "banned_at"}).
+					AddRow("user-123", "+1234567890", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), true, nil, true, false, nil, nil, nil, nil, 0, false, nil)
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
 					WithArgs("user-123").
 					WillReturnRows(rows)
 			},
@@ -213,6 +502,7 @@ func TestCollectUserRow(t *testing.T) {
 				Phone:     "+1234567890",
 				CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 				IsAdmin:   true,
+				NotifySms: true,
 			},
 			expectedError: nil,
 		},
@@ -221,7 +511,7 @@ func TestCollectUserRow(t *testing.T) {
 			setupMock: func() {
 				rows := sqlmock.NewRows([]string{"id", "phone", "created_at"}).
 					AddRow("user-123", "+1234567890", time.Now())
-				mock.ExpectQuery("SELECT \\* FROM users WHERE id = \\$1").
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
 					WithArgs("user-123").
 					WillReturnRows(rows)
 			},
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/lib/pq"
+)
+
+type wishlistRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewWishlistRepository(db *sql.DB) domain.WishlistRepository {
+	return &wishlistRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetAll lists the live, published products in the wishlist of the user, latest addition first.
+func (r *wishlistRepositoryImpl) GetAll(ctx context.Context, userID string, limit, offset int) ([]model.Products, error) {
+	const getWishlistQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    wishlists w
+		JOIN
+		    products p ON p.id = w.product_id
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    w.user_id = $1 AND p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    w.id, p.id, pi.image_url
+		ORDER BY
+		    w.created_at DESC, w.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{userID, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getWishlistQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+func (r *wishlistRepositoryImpl) GetShared(ctx context.Context, token string, limit, offset int) ([]model.Products, error) {
+	const getSharedWishlistQuery string = `
+		SELECT 
+		    p.id,
+		    p.name,
+		    p.slug,
+		    p.description,
+		    p.short_description,
+		    p.price,
+		    p.quantity,
+		    p.created_at,
+		    p.updated_at,
+		    p.category_id,
+		    p.brand_id,
+		    p.status,
+		    p.publish_at,
+		    p.unpublish_at,
+		    p.published_at,
+		    COALESCE(AVG(pr.rating), 0) AS average_rating,
+		    COUNT(pr.rating) AS rating_count,
+		    pi.image_url AS main_image,
+		    p.deleted_at
+		FROM
+		    wishlists w
+		JOIN
+		    wishlist_shares ws ON ws.user_id = w.user_id
+		JOIN
+		    products p ON p.id = w.product_id
+		LEFT JOIN
+		    product_ratings pr ON p.id = pr.product_id
+		LEFT JOIN
+		    product_images pi ON p.id = pi.product_id AND pi.is_main = true
+		WHERE
+		    ws.token = $1 AND p.deleted_at IS NULL AND p.status = 'published'
+		GROUP BY
+		    w.id, p.id, pi.image_url
+		ORDER BY
+		    w.created_at DESC, w.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	args := []any{token, limit, offset}
+	rows, err := r.db.QueryContext(ctx, getSharedWishlistQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectProductsRows(rows)
+}
+
+// GetWishlistedIDs returns which of the given products the user has in the wishlist.
+func (r *wishlistRepositoryImpl) GetWishlistedIDs(ctx context.Context, userID string, productIDs []string) ([]string, error) {
+	const getWishlistedIDsQuery string = "SELECT product_id FROM wishlists WHERE user_id = $1 AND product_id = ANY($2::int[])"
+	args := []any{userID, pq.Array(productIDs)}
+	rows, err := r.db.QueryContext(ctx, getWishlistedIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectIDRows(rows)
+}
+
+// GetUserIDs returns the users who have the product in their wishlist.
+func (r *wishlistRepositoryImpl) GetUserIDs(ctx context.Context, productID string) ([]string, error) {
+	const getWishlistUserIDsQuery string = "SELECT user_id FROM wishlists WHERE product_id = $1"
+	args := []any{productID}
+	rows, err := r.db.QueryContext(ctx, getWishlistUserIDsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectIDRows(rows)
+}
+
+// Create adds the product to the wishlist. Adding it twice is not an error.
+func (r *wishlistRepositoryImpl) Create(ctx context.Context, userID, productID string) error {
+	const createWishlistQuery string = `
+		INSERT INTO wishlists (user_id, product_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, product_id) DO NOTHING
+	`
+	args := []any{userID, productID}
+	_, err := r.db.ExecContext(ctx, createWishlistQuery, args...)
+	return mapForeignKeyViolation(err)
+}
+
+func (r *wishlistRepositoryImpl) Delete(ctx context.Context, userID, productID string) error {
+	const deleteWishlistQuery string = "DELETE FROM wishlists WHERE user_id = $1 AND product_id = $2"
+	args := []any{userID, productID}
+	result, err := r.db.ExecContext(ctx, deleteWishlistQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+// CreateShare stores the token as the share link of the user. A wishlist that is already shared keeps its token.
+func (r *wishlistRepositoryImpl) CreateShare(ctx context.Context, userID, token string) (*model.WishlistShare, error) {
+	const createWishlistShareQuery string = `
+		INSERT INTO wishlist_shares (user_id, token) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING token, created_at
+	`
+	args := []any{userID, token}
+	var share model.WishlistShare
+	if err := r.db.QueryRowContext(ctx, createWishlistShareQuery, args...).Scan(&share.Token, &share.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+func (r *wishlistRepositoryImpl) DeleteShare(ctx context.Context, userID string) error {
+	const deleteWishlistShareQuery string = "DELETE FROM wishlist_shares WHERE user_id = $1"
+	args := []any{userID}
+	result, err := r.db.ExecContext(ctx, deleteWishlistShareQuery, args...)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
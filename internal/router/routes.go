@@ -1,25 +1,19 @@
 package router
 
 import (
-	"database/sql"
-	"log/slog"
 	"net/http"
 
 	_ "github.com/arshamroshannejad/squidshop-backend/api"
 	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/handler"
 	"github.com/arshamroshannejad/squidshop-backend/internal/middleware"
-	"github.com/arshamroshannejad/squidshop-backend/internal/repository"
-	"github.com/arshamroshannejad/squidshop-backend/internal/service"
-	"github.com/redis/go-redis/v9"
 	"github.com/rs/cors"
 	swagger "github.com/swaggo/http-swagger"
 )
 
-func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *config.Config) http.Handler {
+func SetupRoutes(services domain.Service, cfg *config.Config) http.Handler {
 	mux := http.NewServeMux()
-	repositories := repository.NewRepository(db)
-	services := service.NewService(repositories, redisDB, logger, cfg)
 	handlers := handler.NewHandler(services)
 	mux.Handle(
 		"POST /api/v1/auth",
@@ -31,15 +25,139 @@ func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *co
 	)
 	mux.Handle(
 		"GET /api/v1/user/profile",
-		middleware.RequireAuth(cfg)(http.HandlerFunc(handlers.User().UserProfileHandler)),
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().UserProfileHandler)),
 	)
-	mux.HandleFunc(
+	mux.Handle(
+		"PUT /api/v1/user/profile",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().UpdateUserProfileHandler)),
+	)
+	mux.Handle(
+		"DELETE /api/v1/user/profile",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().DeleteUserHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/user/profile/delete",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().RequestUserDeletionHandler)),
+	)
+	mux.Handle(
+		"PUT /api/v1/user/profile/email",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().RequestEmailChangeHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/user/profile/email/verify",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().VerifyEmailChangeHandler)),
+	)
+	mux.Handle(
+		"PUT /api/v1/user/profile/phone",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().RequestPhoneChangeHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/user/profile/phone/verify",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().VerifyPhoneChangeHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/user/phone/override/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().OverridePhoneChangeHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/user/phone/override/verify/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().VerifyOverridePhoneChangeHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/user/address",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.UserAddress().GetAllUserAddressesHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/user/address",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.UserAddress().CreateUserAddressHandler)),
+	)
+	mux.Handle(
+		"PUT /api/v1/user/address/{id}",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.UserAddress().UpdateUserAddressHandler)),
+	)
+	mux.Handle(
+		"DELETE /api/v1/user/address/{id}",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.UserAddress().DeleteUserAddressHandler)),
+	)
+	mux.Handle(
+		"GET /api/v1/user",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().GetAllUsersHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/user/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().GetUserByIDHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/user/comment/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().GetUserCommentsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/user/rating/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().GetUserRatingsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/user/ban/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().BanUserHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/user/ban/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().UnbanUserHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/user/role/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.User().UpdateUserRoleHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/user/notification/preference",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().UpdateNotificationPreferenceHandler)),
+	)
+	mux.Handle(
+		"GET /api/v1/user/notification",
+		middleware.RequireAuth(cfg, services.Session())(http.HandlerFunc(handlers.User().UserNotificationsHandler)),
+	)
+	mux.Handle(
 		"GET /api/v1/category",
-		handlers.Category().GetAllCategoriesHandler,
+		middleware.HTTPCache(cfg, "categories")(http.HandlerFunc(handlers.Category().GetAllCategoriesHandler)),
 	)
 	mux.Handle(
 		"POST /api/v1/category",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Category().CreateCategoryHandler),
 			),
@@ -47,7 +165,7 @@ func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *co
 	)
 	mux.Handle(
 		"PUT /api/v1/category/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Category().UpdateCategoryHandler),
 			),
@@ -55,35 +173,61 @@ func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *co
 	)
 	mux.Handle(
 		"DELETE /api/v1/category/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Category().DeleteCategoryHandler),
 			),
 		),
 	)
+	mux.Handle(
+		"GET /api/v1/category/trash",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Category().GetDeletedCategoriesHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/category/restore/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Category().RestoreCategoryHandler),
+			),
+		),
+	)
 	mux.Handle(
 		"GET /api/v1/category/exists",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Category().ExistsCategoryHandler),
 			),
 		),
 	)
-	mux.HandleFunc(
+	mux.Handle(
 		"GET /api/v1/product",
-		handlers.Product().GetAllProductsHandler,
+		middleware.HTTPCache(cfg, "products")(
+			middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Product().GetAllProductsHandler)),
+		),
 	)
-	mux.HandleFunc(
+	mux.Handle(
 		"GET /api/v1/product/id/{id}",
-		handlers.Product().GetProductByIDHandler,
+		middleware.HTTPCache(cfg, "product")(
+			middleware.DeviceID(
+				middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Product().GetProductByIDHandler)),
+			),
+		),
 	)
-	mux.HandleFunc(
+	mux.Handle(
 		"GET /api/v1/product/slug/{slug}",
-		handlers.Product().GetProductBySlugHandler,
+		middleware.HTTPCache(cfg, "product")(
+			middleware.DeviceID(
+				middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Product().GetProductBySlugHandler)),
+			),
+		),
 	)
 	mux.Handle(
 		"POST /api/v1/product",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Product().CreateProductHandler),
 			),
@@ -91,23 +235,71 @@ func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *co
 	)
 	mux.Handle(
 		"PUT /api/v1/product/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Product().UpdateProductHandler),
 			),
 		),
 	)
+	mux.Handle(
+		"PUT /api/v1/product/status/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Product().UpdateProductStatusHandler),
+			),
+		),
+	)
 	mux.Handle(
 		"DELETE /api/v1/product/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Product().DeleteProductHandler),
 			),
 		),
 	)
+	mux.Handle(
+		"GET /api/v1/product/trash",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Product().GetDeletedProductsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/product/restore/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Product().RestoreProductHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/product/revision/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.ProductRevision().GetProductRevisionsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/product/revision/diff/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.ProductRevision().DiffProductRevisionsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/product/revision/rollback/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.ProductRevision().RollbackProductHandler),
+			),
+		),
+	)
 	mux.Handle(
 		"GET /api/v1/product/exists/{slug}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.Product().ExistsProductHandler),
 			),
@@ -115,19 +307,19 @@ func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *co
 	)
 	mux.Handle(
 		"POST /api/v1/product/rating/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductRating().CreateOrUpdateProductRatingHandler),
 		),
 	)
 	mux.Handle(
 		"DELETE /api/v1/product/rating/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductRating().DeleteProductRatingHandler),
 		),
 	)
 	mux.Handle(
 		"POST /api/v1/product/image/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			middleware.RequireAdmin(
 				http.HandlerFunc(handlers.ProductImage().CreateProductImageHandler),
 			),
@@ -135,40 +327,474 @@ func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *co
 	)
 	mux.Handle(
 		"POST /api/v1/product/comment/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductComment().CreateProductCommentHandler),
 		),
 	)
 	mux.Handle(
 		"PUT /api/v1/product/comment/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductComment().UpdateProductCommentHandler),
 		),
 	)
 	mux.Handle(
 		"DELETE /api/v1/product/comment/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductComment().DeleteProductCommentHandler),
 		),
 	)
+	mux.Handle(
+		"GET /api/v1/product/comment/trash",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.ProductComment().GetDeletedProductCommentsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/product/comment/restore/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.ProductComment().RestoreProductCommentHandler),
+			),
+		),
+	)
 	mux.Handle(
 		"POST /api/v1/product/comment/like/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductCommentLike().CreateProductCommentLikeHandler),
 		),
 	)
 	mux.Handle(
 		"PUT /api/v1/product/comment/like/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductCommentLike().UpdateProductCommentLikeHandler),
 		),
 	)
 	mux.Handle(
 		"DELETE /api/v1/product/comment/like/{id}",
-		middleware.RequireAuth(cfg)(
+		middleware.RequireAuth(cfg, services.Session())(
 			http.HandlerFunc(handlers.ProductCommentLike().DeleteProductCommentLikeHandler),
 		),
 	)
+	mux.Handle(
+		"GET /api/v1/notification",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Notification().GetAllNotificationsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/notification/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Notification().GetNotificationByIDHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/notification/retry/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Notification().RetryNotificationHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/audit",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Audit().GetAllAuditLogsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/discount",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Discount().GetAllDiscountsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/discount/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Discount().GetDiscountByIDHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/discount",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Discount().CreateDiscountHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/discount/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Discount().UpdateDiscountHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/discount/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Discount().DeleteDiscountHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/coupon",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Coupon().GetAllCouponsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/coupon/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Coupon().GetCouponByIDHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/coupon",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Coupon().CreateCouponHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/coupon/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Coupon().UpdateCouponHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/coupon/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Coupon().DeleteCouponHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/coupon/preview",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.Coupon().PreviewCouponHandler),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/coupon/redeem",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.Coupon().RedeemCouponHandler),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/product/stock/low",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.StockMovement().GetLowStockProductsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/product/stock/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.StockMovement().GetStockMovementsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/product/stock/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.StockMovement().MoveStockHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/product/notify-me/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.StockSubscription().SubscribeHandler),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/product/notify-me/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.StockSubscription().UnsubscribeHandler),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/user/wishlist",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.Wishlist().GetWishlistHandler),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/user/wishlist/share",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.Wishlist().ShareWishlistHandler),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/user/wishlist/share",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.Wishlist().UnshareWishlistHandler),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/user/wishlist/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.Wishlist().AddToWishlistHandler),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/user/wishlist/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			http.HandlerFunc(handlers.Wishlist().RemoveFromWishlistHandler),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/wishlist/shared/{token}",
+		middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Wishlist().GetSharedWishlistHandler)),
+	)
+	mux.Handle(
+		"GET /api/v1/product/recently-viewed",
+		middleware.DeviceID(
+			middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.ProductView().GetRecentlyViewedHandler)),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/product/also-viewed/{id}",
+		middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.ProductView().GetAlsoViewedHandler)),
+	)
+	mux.Handle(
+		"GET /api/v1/product/related/{id}",
+		middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.ProductRelation().GetRelatedProductsHandler)),
+	)
+	mux.Handle(
+		"PUT /api/v1/product/related/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.ProductRelation().SetRelatedProductsHandler),
+			),
+		),
+	)
+	mux.HandleFunc(
+		"GET /api/v1/category/breadcrumb/{id}",
+		handlers.Category().GetCategoryBreadcrumbHandler,
+	)
+	mux.Handle(
+		"GET /api/v1/product/breadcrumb/{id}",
+		middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Category().GetProductBreadcrumbHandler)),
+	)
+	mux.Handle(
+		"GET /api/v1/category/{slug}",
+		middleware.HTTPCache(cfg, "category")(
+			middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Category().GetCategoryBySlugHandler)),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/category/delete-preview/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Category().GetCategoryImpactHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/category/merge/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Category().MergeCategoryHandler),
+			),
+		),
+	)
+	mux.HandleFunc(
+		"GET /api/v1/brand",
+		handlers.Brand().GetAllBrandsHandler,
+	)
+	mux.Handle(
+		"GET /api/v1/brand/{slug}",
+		middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Brand().GetBrandBySlugHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/brand",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Brand().CreateBrandHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/brand/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Brand().UpdateBrandHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/brand/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Brand().DeleteBrandHandler),
+			),
+		),
+	)
+	mux.HandleFunc(
+		"GET /api/v1/tag",
+		handlers.Tag().GetAllTagsHandler,
+	)
+	mux.Handle(
+		"GET /api/v1/tag/{slug}",
+		middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Tag().GetTagBySlugHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/tag",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Tag().CreateTagHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/tag/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Tag().UpdateTagHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/tag/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Tag().DeleteTagHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/product/tags/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Tag().SetProductTagsHandler),
+			),
+		),
+	)
+	mux.HandleFunc(
+		"GET /api/v1/collection",
+		handlers.Collection().GetAllCollectionsHandler,
+	)
+	mux.Handle(
+		"GET /api/v1/collection/{slug}",
+		middleware.OptionalAuth(cfg, services.Session())(http.HandlerFunc(handlers.Collection().GetCollectionBySlugHandler)),
+	)
+	mux.Handle(
+		"POST /api/v1/collection",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Collection().CreateCollectionHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/collection/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Collection().UpdateCollectionHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/collection/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Collection().DeleteCollectionHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/collection/products/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Collection().SetCollectionProductsHandler),
+			),
+		),
+	)
+	mux.HandleFunc(
+		"GET /api/v1/home",
+		handlers.Home().GetHomeHandler,
+	)
+	mux.Handle(
+		"GET /api/v1/home/section",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Home().GetHomeSectionsHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/home/section",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Home().CreateHomeSectionHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"PUT /api/v1/home/section/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Home().UpdateHomeSectionHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/home/section/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Home().DeleteHomeSectionHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"POST /api/v1/home/banner/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Home().CreateHomeBannerHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"DELETE /api/v1/home/banner/{id}",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Home().DeleteHomeBannerHandler),
+			),
+		),
+	)
+	mux.Handle(
+		"GET /api/v1/cache/stats",
+		middleware.RequireAuth(cfg, services.Session())(
+			middleware.RequireAdmin(
+				http.HandlerFunc(handlers.Cache().GetCacheStatsHandler),
+			),
+		),
+	)
 	mux.Handle("/docs/", swagger.Handler(
 		swagger.URL("doc.json"),
 		swagger.DeepLinking(true),
@@ -176,6 +802,6 @@ func SetupRoutes(db *sql.DB, redisDB *redis.Client, logger *slog.Logger, cfg *co
 		swagger.DomID("swagger-ui"),
 	))
 	return cors.Default().Handler(
-		middleware.Logger(middleware.Timeout(mux)),
+		middleware.RequestID(cfg)(middleware.Logger(middleware.Timeout(mux))),
 	)
 }
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type auditServiceImpl struct {
+	auditRepository domain.AuditRepository
+	logger          *slog.Logger
+}
+
+func NewAuditService(auditRepository domain.AuditRepository, logger *slog.Logger) domain.AuditService {
+	return &auditServiceImpl{
+		auditRepository: auditRepository,
+		logger:          logger,
+	}
+}
+
+func (s *auditServiceImpl) GetAllAuditLogs(ctx context.Context, params *entity.AuditLogQueryParamRequest) ([]model.AuditLog, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	auditLogs, err := s.auditRepository.GetAll(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to get audit logs", "error", err)
+		return nil, err
+	}
+	return auditLogs, nil
+}
+
+// Record stores the entry. Actor, client ip and request id are taken from the request context when not set.
+func (s *auditServiceImpl) Record(ctx context.Context, entry *entity.AuditLogCreateRequest) error {
+	if entry.ActorID == nil {
+		if actorID, ok := ctx.Value(helper.CtxUserID).(string); ok {
+			entry.ActorID = &actorID
+		}
+	}
+	if entry.IP == nil {
+		if ip, ok := ctx.Value(helper.CtxClientIP).(string); ok {
+			entry.IP = &ip
+		}
+	}
+	if entry.RequestID == nil {
+		if requestID, ok := ctx.Value(helper.CtxRequestID).(string); ok {
+			entry.RequestID = &requestID
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 3*time.Second)
+	defer cancel()
+	if err := s.auditRepository.Create(ctx, entry); err != nil {
+		s.logger.Error(
+			"failed to record audit log",
+			"action", entry.Action,
+			"entity_type", entry.EntityType,
+			"entity_id", entry.EntityID,
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
+
+// Track is the hook every admin mutation goes through. It runs mutate and records the fields that changed.
+// entityID is empty for creates, mutate then reports the id the entity was assigned.
+// snapshot is called before and after the mutation and may be nil for changes without a readable state.
+// A failed mutation is not recorded, a failed snapshot or audit write is logged but never undoes the mutation.
+func (s *auditServiceImpl) Track(ctx context.Context, action, entityType, entityID string, snapshot domain.AuditSnapshot, mutate domain.AuditMutation) error {
+	var before any
+	if entityID != "" && snapshot != nil {
+		before = s.loadSnapshot(ctx, snapshot, entityID)
+	}
+	mutatedID, err := mutate(ctx)
+	if err != nil {
+		return err
+	}
+	if mutatedID != "" {
+		entityID = mutatedID
+	}
+	var after any
+	if snapshot != nil {
+		after = s.loadSnapshot(ctx, snapshot, entityID)
+	}
+	entry := entity.AuditLogCreateRequest{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	beforeDiff, afterDiff := diffAuditState(before, after)
+	if beforeDiff != nil {
+		entry.Before = beforeDiff
+	}
+	if afterDiff != nil {
+		entry.After = afterDiff
+	}
+	_ = s.Record(ctx, &entry)
+	return nil
+}
+
+func (s *auditServiceImpl) loadSnapshot(ctx context.Context, snapshot domain.AuditSnapshot, entityID string) any {
+	state, err := snapshot(ctx, entityID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Warn("failed to load audit snapshot", "entity_id", entityID, "error", err)
+		}
+		return nil
+	}
+	return state
+}
+
+// diffAuditState reduces two snapshots to the top level fields that differ.
+// A missing side (create or delete) keeps the other side whole.
+func diffAuditState(before, after any) (map[string]any, map[string]any) {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+	if beforeFields == nil || afterFields == nil {
+		return beforeFields, afterFields
+	}
+	beforeDiff := make(map[string]any)
+	afterDiff := make(map[string]any)
+	for key, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[key]) {
+			beforeDiff[key] = value
+		}
+	}
+	for key, value := range afterFields {
+		if !reflect.DeepEqual(value, beforeFields[key]) {
+			afterDiff[key] = value
+		}
+	}
+	return beforeDiff, afterDiff
+}
+
+// auditFields decodes a snapshot through its json form so the diff matches what the api exposes.
+func auditFields(state any) map[string]any {
+	if state == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return map[string]any{"value": state}
+	}
+	return fields
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffAuditState(t *testing.T) {
+	tests := []struct {
+		name           string
+		before         any
+		after          any
+		expectedBefore map[string]any
+		expectedAfter  map[string]any
+	}{
+		{
+			name:           "create keeps the whole new state",
+			before:         nil,
+			after:          helper.M{"name": "Laptop", "slug": "laptop"},
+			expectedBefore: nil,
+			expectedAfter:  map[string]any{"name": "Laptop", "slug": "laptop"},
+		},
+		{
+			name:           "delete keeps the whole old state",
+			before:         helper.M{"name": "Laptop"},
+			after:          nil,
+			expectedBefore: map[string]any{"name": "Laptop"},
+			expectedAfter:  nil,
+		},
+		{
+			name:           "update keeps only changed fields",
+			before:         helper.M{"name": "Laptop", "price": 100, "parent_id": nil},
+			after:          helper.M{"name": "Laptop", "price": 90, "parent_id": "2"},
+			expectedBefore: map[string]any{"price": float64(100), "parent_id": nil},
+			expectedAfter:  map[string]any{"price": float64(90), "parent_id": "2"},
+		},
+		{
+			name:           "added and removed fields",
+			before:         helper.M{"old": true},
+			after:          helper.M{"new": true},
+			expectedBefore: map[string]any{"old": true},
+			expectedAfter:  map[string]any{"new": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := diffAuditState(tt.before, tt.after)
+			assert.Equal(t, tt.expectedBefore, before)
+			assert.Equal(t, tt.expectedAfter, after)
+		})
+	}
+}
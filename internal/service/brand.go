@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type brandServiceImpl struct {
+	brandRepository   domain.BrandRepository
+	productRepository domain.ProductRepository
+	pricingService    domain.PricingService
+	wishlistService   domain.WishlistService
+	cacheService      domain.CacheService
+	auditService      domain.AuditService
+	logger            *slog.Logger
+	cfg               *config.Config
+}
+
+func NewBrandService(
+	brandRepository domain.BrandRepository,
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.BrandService {
+	return &brandServiceImpl{
+		brandRepository:   brandRepository,
+		productRepository: productRepository,
+		pricingService:    pricingService,
+		wishlistService:   wishlistService,
+		cacheService:      cacheService,
+		auditService:      auditService,
+		logger:            logger,
+		cfg:               cfg,
+	}
+}
+
+func (s *brandServiceImpl) GetAllBrands(ctx context.Context) ([]model.Brand, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	brands, err := s.brandRepository.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("failed to get all brands", "error", err)
+		return nil, err
+	}
+	for i := range brands {
+		if brands[i].Logo != nil {
+			brands[i].Logo = helper.BuildMediaURL(s.cfg, brands[i].Logo)
+		}
+	}
+	return brands, nil
+}
+
+// GetBrandBySlug returns the storefront page of a brand with one page of its published products.
+func (s *brandServiceImpl) GetBrandBySlug(ctx context.Context, brandSlug string, limit, offset int) (*model.BrandDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	brand, err := s.brandRepository.GetBySlug(ctx, brandSlug)
+	if err != nil {
+		s.logger.Error("failed to get brand by slug", "error", err)
+		return nil, err
+	}
+	products, err := s.productRepository.GetByBrand(ctx, brand.ID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get brand products", "error", err)
+		return nil, err
+	}
+	if brand.Logo != nil {
+		brand.Logo = helper.BuildMediaURL(s.cfg, brand.Logo)
+	}
+	presentProducts(ctx, s.pricingService, s.wishlistService, s.cfg, products)
+	return &model.BrandDetail{Brand: *brand, Products: products}, nil
+}
+
+func (s *brandServiceImpl) CreateBrand(ctx context.Context, brand *entity.BrandRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionBrandCreated, model.AuditEntityBrand, "", s.brandSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.brandRepository.Create(ctx, brand)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to create brand", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *brandServiceImpl) UpdateBrand(ctx context.Context, brandID string, brand *entity.BrandRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionBrandUpdated, model.AuditEntityBrand, brandID, s.brandSnapshot,
+		func(ctx context.Context) (string, error) {
+			return brandID, s.brandRepository.Update(ctx, brandID, brand)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update brand", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *brandServiceImpl) DeleteBrand(ctx context.Context, brandID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionBrandDeleted, model.AuditEntityBrand, brandID, s.brandSnapshot,
+		func(ctx context.Context) (string, error) {
+			return brandID, s.brandRepository.Delete(ctx, brandID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to delete brand", "error", err)
+		return err
+	}
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, cacheTagProductDetails)
+	return nil
+}
+
+func (s *brandServiceImpl) brandSnapshot(ctx context.Context, brandID string) (any, error) {
+	return s.brandRepository.GetByID(ctx, brandID)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const cacheEpochKey = "cache:epoch"
+
+// Cache tags of the catalog reads. Product lists carry cacheTagProducts, a product detail carries
+// cacheTagProductDetails and its productCacheTag, and the category tree carries cacheTagCategories.
+const (
+	cacheTagProducts       = "products"
+	cacheTagProductDetails = "product_details"
+	cacheTagCategories     = "categories"
+)
+
+type cacheCall struct {
+	wg    sync.WaitGroup
+	value []byte
+	err   error
+}
+
+type cacheCounters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	shared atomic.Uint64
+}
+
+type cacheServiceImpl struct {
+	redisDB  *redis.Client
+	logger   *slog.Logger
+	cfg      *config.Config
+	mu       sync.Mutex
+	calls    map[string]*cacheCall
+	counters sync.Map
+}
+
+func NewCacheService(redisDB *redis.Client, logger *slog.Logger, cfg *config.Config) domain.CacheService {
+	return &cacheServiceImpl{
+		redisDB: redisDB,
+		logger:  logger,
+		cfg:     cfg,
+		calls:   make(map[string]*cacheCall),
+	}
+}
+
+// Remember decodes the cached value of key into dst, loading and caching it on a miss. Concurrent misses of the
+// same key in this process share one load. Redis failures fall back to loading, errors of the load are not cached.
+func (s *cacheServiceImpl) Remember(ctx context.Context, key string, dst any, load domain.CacheLoader) error {
+	counters := s.namespaceCounters(key)
+	cached, err := s.redisDB.Get(ctx, cacheEntryKey(key)).Bytes()
+	if err == nil {
+		if err := json.Unmarshal(cached, dst); err == nil {
+			counters.hits.Add(1)
+			return nil
+		}
+		s.logger.Warn("failed to decode cached entry", "key", key, "error", err)
+	} else if !errors.Is(err, redis.Nil) {
+		s.logger.Warn("failed to read cached entry", "key", key, "error", err)
+	}
+	value, shared, err := s.do(key, func() ([]byte, error) {
+		return s.load(ctx, key, load)
+	})
+	if shared {
+		counters.shared.Add(1)
+	} else {
+		counters.misses.Add(1)
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(value, dst)
+}
+
+// InvalidateTags drops every entry carrying one of the tags. The epoch is bumped first so that loads already in
+// flight do not store what they read before the write.
+func (s *cacheServiceImpl) InvalidateTags(ctx context.Context, tags ...string) {
+	if err := s.redisDB.Incr(ctx, cacheEpochKey).Err(); err != nil {
+		s.logger.Warn("failed to bump cache epoch", "error", err)
+	}
+	for _, tag := range tags {
+		keys, err := s.redisDB.SMembers(ctx, cacheTagKey(tag)).Result()
+		if err != nil {
+			s.logger.Warn("failed to read cache tag", "tag", tag, "error", err)
+			continue
+		}
+		if err := s.redisDB.Del(ctx, append(keys, cacheTagKey(tag))...).Err(); err != nil {
+			s.logger.Warn("failed to invalidate cache tag", "tag", tag, "error", err)
+		}
+	}
+}
+
+func (s *cacheServiceImpl) Stats() []model.CacheStats {
+	stats := make([]model.CacheStats, 0)
+	s.counters.Range(func(namespace, value any) bool {
+		counters := value.(*cacheCounters)
+		stat := model.CacheStats{
+			Namespace: namespace.(string),
+			Hits:      counters.hits.Load(),
+			Misses:    counters.misses.Load(),
+			Shared:    counters.shared.Load(),
+		}
+		if total := stat.Hits + stat.Misses + stat.Shared; total > 0 {
+			stat.HitRatio = float64(stat.Hits) / float64(total)
+		}
+		stats = append(stats, stat)
+		return true
+	})
+	slices.SortFunc(stats, func(a, b model.CacheStats) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	return stats
+}
+
+// load runs the loader and stores its encoded value with its tags, unless an invalidation happened since the
+// load started.
+func (s *cacheServiceImpl) load(ctx context.Context, key string, load domain.CacheLoader) ([]byte, error) {
+	epoch, err := s.redisDB.Get(ctx, cacheEpochKey).Int64()
+	cacheable := err == nil || errors.Is(err, redis.Nil)
+	if !cacheable {
+		s.logger.Warn("failed to read cache epoch", "error", err)
+	}
+	value, tags, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		s.store(ctx, key, tags, epoch, encoded)
+	}
+	return encoded, nil
+}
+
+func (s *cacheServiceImpl) store(ctx context.Context, key string, tags []string, epoch int64, encoded []byte) {
+	err := s.redisDB.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, cacheEpochKey).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if current != epoch {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, cacheEntryKey(key), encoded, s.cfg.Cache.TTL)
+			for _, tag := range tags {
+				pipe.SAdd(ctx, cacheTagKey(tag), cacheEntryKey(key))
+				pipe.Expire(ctx, cacheTagKey(tag), s.cfg.Cache.TTL)
+			}
+			return nil
+		})
+		return err
+	}, cacheEpochKey)
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		s.logger.Warn("failed to cache entry", "key", key, "error", err)
+	}
+}
+
+// do runs fn once for concurrent calls with the same key and hands every caller its result. shared reports
+// whether the caller waited for another one.
+func (s *cacheServiceImpl) do(key string, fn func() ([]byte, error)) (value []byte, shared bool, err error) {
+	s.mu.Lock()
+	if call, ok := s.calls[key]; ok {
+		s.mu.Unlock()
+		call.wg.Wait()
+		return call.value, true, call.err
+	}
+	call := new(cacheCall)
+	call.wg.Add(1)
+	s.calls[key] = call
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.calls, key)
+		s.mu.Unlock()
+		call.wg.Done()
+	}()
+	call.value, call.err = fn()
+	return call.value, false, call.err
+}
+
+func (s *cacheServiceImpl) namespaceCounters(key string) *cacheCounters {
+	namespace, _, _ := strings.Cut(key, ":")
+	counters, _ := s.counters.LoadOrStore(namespace, new(cacheCounters))
+	return counters.(*cacheCounters)
+}
+
+func cacheEntryKey(key string) string {
+	return "cache:entry:" + key
+}
+
+func cacheTagKey(tag string) string {
+	return "cache:tag:" + tag
+}
+
+func productCacheTag(productID string) string {
+	return "product:" + productID
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheDoSharesConcurrentLoads(t *testing.T) {
+	s := NewCacheService(nil, nil, nil).(*cacheServiceImpl)
+	release := make(chan struct{})
+	var loads, shared atomic.Int32
+	fn := func() ([]byte, error) {
+		loads.Add(1)
+		<-release
+		return []byte(`"laptop"`), nil
+	}
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, isShared, err := s.do("product:slug:laptop", fn)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte(`"laptop"`), value)
+			if isShared {
+				shared.Add(1)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Equal(t, int32(4), shared.Load())
+	assert.Empty(t, s.calls)
+}
+
+func TestCacheStats(t *testing.T) {
+	s := NewCacheService(nil, nil, nil).(*cacheServiceImpl)
+	s.namespaceCounters("products:published:").hits.Add(3)
+	s.namespaceCounters("products:draft:").misses.Add(1)
+	s.namespaceCounters("categories:tree").shared.Add(2)
+	assert.Equal(t, []string{"categories", "products"}, []string{s.Stats()[0].Namespace, s.Stats()[1].Namespace})
+	assert.Equal(t, 0.75, s.Stats()[1].HitRatio)
+	assert.Equal(t, 0.0, s.Stats()[0].HitRatio)
+}
@@ -2,30 +2,65 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"log/slog"
+	"strconv"
 	"time"
 
+	"github.com/arshamroshannejad/squidshop-backend/config"
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
 type categoryServiceImpl struct {
 	categoryRepository domain.CategoryRepository
+	productRepository  domain.ProductRepository
+	pricingService     domain.PricingService
+	wishlistService    domain.WishlistService
+	homeService        domain.HomeService
+	cacheService       domain.CacheService
+	auditService       domain.AuditService
 	logger             *slog.Logger
+	cfg                *config.Config
 }
 
-func NewCategoryService(categoryRepository domain.CategoryRepository, logger *slog.Logger) domain.CategoryService {
+func NewCategoryService(
+	categoryRepository domain.CategoryRepository,
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	homeService domain.HomeService,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.CategoryService {
 	return &categoryServiceImpl{
 		categoryRepository: categoryRepository,
+		productRepository:  productRepository,
+		pricingService:     pricingService,
+		wishlistService:    wishlistService,
+		homeService:        homeService,
+		cacheService:       cacheService,
+		auditService:       auditService,
 		logger:             logger,
+		cfg:                cfg,
 	}
 }
 
 func (s *categoryServiceImpl) GetAllCategories(ctx context.Context) ([]model.Category, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	categories, err := s.categoryRepository.GetAll(ctx)
+	var categories []model.Category
+	err := s.cacheService.Remember(
+		ctx, "categories:tree", &categories,
+		func(ctx context.Context) (any, []string, error) {
+			categories, err := s.categoryRepository.GetAll(ctx)
+			return categories, []string{cacheTagCategories}, err
+		},
+	)
 	if err != nil {
 		s.logger.Error("failed to get all categories", "error", err)
 		return nil, err
@@ -33,33 +68,185 @@ func (s *categoryServiceImpl) GetAllCategories(ctx context.Context) ([]model.Cat
 	return categories, nil
 }
 
+func (s *categoryServiceImpl) GetDeletedCategories(ctx context.Context, limit, offset int) ([]model.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	categories, err := s.categoryRepository.GetAllDeleted(ctx, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get deleted categories", "error", err)
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetCategoryBySlug returns the storefront page of a category with its direct sub categories and one page of the
+// products of its whole subtree.
+func (s *categoryServiceImpl) GetCategoryBySlug(ctx context.Context, categorySlug string, limit, offset int) (*model.CategoryDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	category, err := s.categoryRepository.GetBySlug(ctx, categorySlug)
+	if err != nil {
+		s.logger.Error("failed to get category by slug", "error", err)
+		return nil, err
+	}
+	if category.SubCategories, err = s.categoryRepository.GetChildren(ctx, category.ID); err != nil {
+		s.logger.Error("failed to get sub categories", "error", err)
+		return nil, err
+	}
+	if category.Products, err = s.productRepository.GetByCategoryPath(ctx, category.Path, limit, offset); err != nil {
+		s.logger.Error("failed to get category products", "error", err)
+		return nil, err
+	}
+	if category.BannerImage != nil {
+		category.BannerImage = helper.BuildMediaURL(s.cfg, category.BannerImage)
+	}
+	presentProducts(ctx, s.pricingService, s.wishlistService, s.cfg, category.Products)
+	return category, nil
+}
+
+// GetCategoryBreadcrumb returns the way down to a live category, sql.ErrNoRows when there is none.
+func (s *categoryServiceImpl) GetCategoryBreadcrumb(ctx context.Context, categoryID string) ([]model.Breadcrumb, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	breadcrumb, err := s.categoryRepository.GetBreadcrumb(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("failed to get category breadcrumb", "error", err)
+		return nil, err
+	}
+	if len(breadcrumb) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return breadcrumb, nil
+}
+
+// GetProductBreadcrumb returns the way down through the categories of a product, ending with the product itself.
+// A product without a live category only has its own step.
+func (s *categoryServiceImpl) GetProductBreadcrumb(ctx context.Context, productID string) ([]model.Breadcrumb, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	product, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product for breadcrumb", "error", err)
+		return nil, err
+	}
+	if !visibleProduct(ctx, product) {
+		return nil, sql.ErrNoRows
+	}
+	breadcrumb := make([]model.Breadcrumb, 0)
+	if product.CategoryID != nil {
+		if breadcrumb, err = s.categoryRepository.GetBreadcrumb(ctx, *product.CategoryID); err != nil {
+			s.logger.Error("failed to get product breadcrumb", "error", err)
+			return nil, err
+		}
+	}
+	breadcrumb = append(breadcrumb, model.Breadcrumb{
+		Kind: model.BreadcrumbKindProduct,
+		ID:   product.ID,
+		Name: product.Name,
+		Slug: product.Slug,
+	})
+	return breadcrumb, nil
+}
+
 func (s *categoryServiceImpl) CreateCategory(ctx context.Context, category *entity.CategoryCreateRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.categoryRepository.Create(ctx, category); err != nil {
+	err := s.auditService.Track(
+		ctx, model.AuditActionCategoryCreated, model.AuditEntityCategory, "", s.categorySnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.categoryRepository.Create(ctx, category)
+		},
+	)
+	if err != nil {
 		s.logger.Error("failed to create category", "error", err)
 		return err
 	}
+	s.cacheService.InvalidateTags(ctx, cacheTagCategories)
 	return nil
 }
 
 func (s *categoryServiceImpl) UpdateCategory(ctx context.Context, categoryID string, category *entity.CategoryUpdateRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.categoryRepository.Update(ctx, categoryID, category); err != nil {
+	err := s.auditService.Track(
+		ctx, model.AuditActionCategoryUpdated, model.AuditEntityCategory, categoryID, s.categorySnapshot,
+		func(ctx context.Context) (string, error) {
+			return categoryID, s.categoryRepository.Update(ctx, categoryID, category)
+		},
+	)
+	if err != nil {
 		s.logger.Error("failed to update category", "error", err)
 		return err
 	}
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagCategories)
 	return nil
 }
 
 func (s *categoryServiceImpl) DeleteCategory(ctx context.Context, categoryID string) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.categoryRepository.Delete(ctx, categoryID); err != nil {
+	err := s.auditService.Track(
+		ctx, model.AuditActionCategoryDeleted, model.AuditEntityCategory, categoryID, s.categorySnapshot,
+		func(ctx context.Context) (string, error) {
+			return categoryID, s.categoryRepository.Delete(ctx, categoryID)
+		},
+	)
+	if err != nil {
 		s.logger.Error("failed to delete category", "error", err)
 		return err
 	}
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagCategories)
+	return nil
+}
+
+// GetCategoryImpact previews what deleting or merging the category would touch.
+func (s *categoryServiceImpl) GetCategoryImpact(ctx context.Context, categoryID string) (*model.CategoryImpact, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	impact, err := s.categoryRepository.GetImpact(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("failed to get category impact", "error", err)
+		return nil, err
+	}
+	return impact, nil
+}
+
+// MergeCategory folds the category into the target, see CategoryRepository.Merge.
+func (s *categoryServiceImpl) MergeCategory(ctx context.Context, categoryID string, merge *entity.CategoryMergeRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCategoryMerged, model.AuditEntityCategory, categoryID, s.categorySnapshot,
+		func(ctx context.Context) (string, error) {
+			return categoryID, s.categoryRepository.Merge(ctx, categoryID, strconv.Itoa(merge.TargetID))
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to merge category", "error", err)
+		return err
+	}
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagCategories, cacheTagProducts, cacheTagProductDetails)
+	return nil
+}
+
+func (s *categoryServiceImpl) RestoreCategory(ctx context.Context, categoryID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCategoryRestored, model.AuditEntityCategory, categoryID, s.categorySnapshot,
+		func(ctx context.Context) (string, error) {
+			return categoryID, s.categoryRepository.Restore(ctx, categoryID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to restore category", "error", err)
+		return err
+	}
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagCategories)
 	return nil
 }
 
@@ -73,3 +260,21 @@ func (s *categoryServiceImpl) ExistsCategory(ctx context.Context, category *enti
 	}
 	return exists, nil
 }
+
+// categorySnapshot spells the fields out because model.Category hides parent_id from json.
+func (s *categoryServiceImpl) categorySnapshot(ctx context.Context, categoryID string) (any, error) {
+	category, err := s.categoryRepository.GetByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return helper.M{
+		"name":             category.Name,
+		"slug":             category.Slug,
+		"parent_id":        category.ParentID,
+		"position":         category.Position,
+		"description":      category.Description,
+		"banner_image":     category.BannerImage,
+		"seo_title":        category.SeoTitle,
+		"meta_description": category.MetaDescription,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type collectionServiceImpl struct {
+	collectionRepository domain.CollectionRepository
+	productRepository    domain.ProductRepository
+	pricingService       domain.PricingService
+	wishlistService      domain.WishlistService
+	homeService          domain.HomeService
+	auditService         domain.AuditService
+	logger               *slog.Logger
+	cfg                  *config.Config
+}
+
+func NewCollectionService(
+	collectionRepository domain.CollectionRepository,
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	homeService domain.HomeService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.CollectionService {
+	return &collectionServiceImpl{
+		collectionRepository: collectionRepository,
+		productRepository:    productRepository,
+		pricingService:       pricingService,
+		wishlistService:      wishlistService,
+		homeService:          homeService,
+		auditService:         auditService,
+		logger:               logger,
+		cfg:                  cfg,
+	}
+}
+
+func (s *collectionServiceImpl) GetAllCollections(ctx context.Context) ([]model.Collection, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	collections, err := s.collectionRepository.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("failed to get all collections", "error", err)
+		return nil, err
+	}
+	return collections, nil
+}
+
+// GetCollectionBySlug returns the storefront page of a collection. Manual collections list their members in the
+// curated order and rule based ones the matching products, newest first.
+func (s *collectionServiceImpl) GetCollectionBySlug(ctx context.Context, collectionSlug string, limit, offset int) (*model.CollectionDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	collection, err := s.collectionRepository.GetBySlug(ctx, collectionSlug)
+	if err != nil {
+		s.logger.Error("failed to get collection by slug", "error", err)
+		return nil, err
+	}
+	products, err := collectionProducts(ctx, s.productRepository, collection, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get collection products", "error", err)
+		return nil, err
+	}
+	presentProducts(ctx, s.pricingService, s.wishlistService, s.cfg, products)
+	return &model.CollectionDetail{Collection: *collection, Products: products}, nil
+}
+
+func (s *collectionServiceImpl) CreateCollection(ctx context.Context, collection *entity.CollectionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCollectionCreated, model.AuditEntityCollection, "", s.collectionSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.collectionRepository.Create(ctx, collection)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to create collection", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *collectionServiceImpl) UpdateCollection(ctx context.Context, collectionID string, collection *entity.CollectionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCollectionUpdated, model.AuditEntityCollection, collectionID, s.collectionSnapshot,
+		func(ctx context.Context) (string, error) {
+			return collectionID, s.collectionRepository.Update(ctx, collectionID, collection)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update collection", "error", err)
+		return err
+	}
+	s.homeService.Invalidate(ctx)
+	return nil
+}
+
+func (s *collectionServiceImpl) DeleteCollection(ctx context.Context, collectionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCollectionDeleted, model.AuditEntityCollection, collectionID, s.collectionSnapshot,
+		func(ctx context.Context) (string, error) {
+			return collectionID, s.collectionRepository.Delete(ctx, collectionID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to delete collection", "error", err)
+		return err
+	}
+	s.homeService.Invalidate(ctx)
+	return nil
+}
+
+// SetCollectionProducts replaces the members of a manual collection in display order, an empty list empties it.
+func (s *collectionServiceImpl) SetCollectionProducts(ctx context.Context, collectionID string, products *entity.CollectionProductRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCollectionProductsSet, model.AuditEntityCollection, collectionID, s.collectionProductsSnapshot,
+		func(ctx context.Context) (string, error) {
+			return collectionID, s.collectionRepository.ReplaceProducts(ctx, collectionID, products.ProductIDs)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to set collection products", "error", err)
+		return err
+	}
+	s.homeService.Invalidate(ctx)
+	return nil
+}
+
+func (s *collectionServiceImpl) collectionSnapshot(ctx context.Context, collectionID string) (any, error) {
+	return s.collectionRepository.GetByID(ctx, collectionID)
+}
+
+func (s *collectionServiceImpl) collectionProductsSnapshot(ctx context.Context, collectionID string) (any, error) {
+	productIDs, err := s.collectionRepository.GetProductIDs(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	return helper.M{"product_ids": productIDs}, nil
+}
+
+// collectionProducts pages through the products of a collection by its rule or its curated order.
+func collectionProducts(ctx context.Context, productRepository domain.ProductRepository, collection *model.Collection, limit, offset int) ([]model.Products, error) {
+	if collection.Rule != nil {
+		return productRepository.GetByCollectionRule(ctx, collection.Rule, limit, offset)
+	}
+	return productRepository.GetByCollection(ctx, collection.ID, limit, offset)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type couponServiceImpl struct {
+	couponRepository   domain.CouponRepository
+	productRepository  domain.ProductRepository
+	categoryRepository domain.CategoryRepository
+	pricingService     domain.PricingService
+	auditService       domain.AuditService
+	logger             *slog.Logger
+}
+
+func NewCouponService(
+	couponRepository domain.CouponRepository,
+	productRepository domain.ProductRepository,
+	categoryRepository domain.CategoryRepository,
+	pricingService domain.PricingService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+) domain.CouponService {
+	return &couponServiceImpl{
+		couponRepository:   couponRepository,
+		productRepository:  productRepository,
+		categoryRepository: categoryRepository,
+		pricingService:     pricingService,
+		auditService:       auditService,
+		logger:             logger,
+	}
+}
+
+func (s *couponServiceImpl) GetAllCoupons(ctx context.Context, limit, offset int) ([]model.Coupon, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	coupons, err := s.couponRepository.GetAll(ctx, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get all coupons", "error", err)
+		return nil, err
+	}
+	return coupons, nil
+}
+
+func (s *couponServiceImpl) GetCouponByID(ctx context.Context, couponID string) (*model.Coupon, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	coupon, err := s.couponRepository.GetByID(ctx, couponID)
+	if err != nil {
+		s.logger.Error("failed to get coupon by id", "error", err)
+		return nil, err
+	}
+	return coupon, nil
+}
+
+func (s *couponServiceImpl) CreateCoupon(ctx context.Context, coupon *entity.CouponRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCouponCreated, model.AuditEntityCoupon, "", s.couponSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.couponRepository.Create(ctx, coupon)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to create coupon", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *couponServiceImpl) UpdateCoupon(ctx context.Context, couponID string, coupon *entity.CouponRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCouponUpdated, model.AuditEntityCoupon, couponID, s.couponSnapshot,
+		func(ctx context.Context) (string, error) {
+			return couponID, s.couponRepository.Update(ctx, couponID, coupon)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update coupon", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *couponServiceImpl) DeleteCoupon(ctx context.Context, couponID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionCouponDeleted, model.AuditEntityCoupon, couponID, s.couponSnapshot,
+		func(ctx context.Context) (string, error) {
+			return couponID, s.couponRepository.Delete(ctx, couponID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to delete coupon", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *couponServiceImpl) PreviewCoupon(ctx context.Context, userID string, basket *entity.CouponBasketRequest) (*model.CouponPreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	_, preview, err := s.preview(ctx, userID, basket)
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// RedeemCoupon prices the basket like PreviewCoupon and records the redemption. The repository checks the limits
+// again under a lock, the preview checks only give a friendly answer early.
+func (s *couponServiceImpl) RedeemCoupon(ctx context.Context, userID string, basket *entity.CouponBasketRequest) (*model.CouponPreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	coupon, preview, err := s.preview(ctx, userID, basket)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.couponRepository.Redeem(ctx, coupon.ID, userID, preview.Discount); err != nil {
+		if !isCouponError(err) {
+			s.logger.Error("failed to redeem coupon", "error", err)
+		}
+		return nil, err
+	}
+	return preview, nil
+}
+
+func (s *couponServiceImpl) preview(ctx context.Context, userID string, basket *entity.CouponBasketRequest) (*model.Coupon, *model.CouponPreview, error) {
+	coupon, err := s.couponRepository.GetActiveByCode(ctx, basket.Code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, domain.ErrCouponInvalid
+		}
+		s.logger.Error("failed to get coupon by code", "error", err)
+		return nil, nil, err
+	}
+	usage, err := s.couponRepository.GetUsage(ctx, coupon.ID, userID)
+	if err != nil {
+		s.logger.Error("failed to get coupon usage", "error", err)
+		return nil, nil, err
+	}
+	if err := checkCouponUsage(coupon, usage); err != nil {
+		return nil, nil, err
+	}
+	items, err := s.basketItems(ctx, coupon, basket.Items)
+	if err != nil {
+		return nil, nil, err
+	}
+	preview, err := applyCoupon(coupon, items)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coupon, preview, nil
+}
+
+// basketItems prices every basket line at the price the customer pays right now and marks the lines the coupon
+// counts against. A product that is not on sale fails the whole basket with sql.ErrNoRows.
+func (s *couponServiceImpl) basketItems(ctx context.Context, coupon *model.Coupon, basket []entity.CouponBasketItemRequest) ([]model.CouponPreviewItem, error) {
+	productIDs := make([]string, 0, len(basket))
+	for _, item := range basket {
+		productIDs = append(productIDs, strconv.Itoa(item.ProductID))
+	}
+	products, err := s.productRepository.GetByIDs(ctx, productIDs)
+	if err != nil {
+		s.logger.Error("failed to get basket products", "error", err)
+		return nil, err
+	}
+	if err := s.pricingService.PriceProducts(ctx, products); err != nil {
+		return nil, err
+	}
+	parents := make(map[string]*string)
+	if coupon.Scope == model.DiscountScopeCategory {
+		categories, err := s.categoryRepository.GetAll(ctx)
+		if err != nil {
+			s.logger.Error("failed to get categories for coupon", "error", err)
+			return nil, err
+		}
+		collectCategoryParents(categories, parents)
+	}
+	byID := make(map[string]model.Products, len(products))
+	for _, product := range products {
+		if product.Status == model.ProductStatusPublished {
+			byID[product.ID] = product
+		}
+	}
+	items := make([]model.CouponPreviewItem, 0, len(basket))
+	for i, item := range basket {
+		product, ok := byID[productIDs[i]]
+		if !ok {
+			return nil, sql.ErrNoRows
+		}
+		items = append(items, model.CouponPreviewItem{
+			ProductID: product.ID,
+			Quantity:  item.Quantity,
+			UnitPrice: product.EffectivePrice,
+			LineTotal: product.EffectivePrice.Mul(item.Quantity),
+			Eligible:  inScope(parents, coupon.Scope, coupon.ProductID, coupon.CategoryID, product.ID, product.CategoryID),
+		})
+	}
+	return items, nil
+}
+
+func (s *couponServiceImpl) couponSnapshot(ctx context.Context, couponID string) (any, error) {
+	return s.couponRepository.GetByID(ctx, couponID)
+}
+
+func checkCouponUsage(coupon *model.Coupon, usage *model.CouponUsage) error {
+	switch {
+	case coupon.UsageLimit != nil && coupon.RedemptionCount >= *coupon.UsageLimit:
+		return domain.ErrCouponUsedUp
+	case coupon.PerUserLimit != nil && usage.CouponRedemptions >= *coupon.PerUserLimit:
+		return domain.ErrCouponUsedUp
+	case coupon.FirstOrderOnly && usage.TotalRedemptions > 0:
+		return domain.ErrCouponFirstOrder
+	}
+	return nil
+}
+
+// applyCoupon totals the basket and takes the coupon off the eligible lines. The minimum basket value counts the
+// whole basket, the discount never goes above the eligible lines or max_discount.
+func applyCoupon(coupon *model.Coupon, items []model.CouponPreviewItem) (*model.CouponPreview, error) {
+	preview := &model.CouponPreview{Code: coupon.Code, Items: items}
+	for _, item := range items {
+		preview.Subtotal += item.LineTotal
+		if item.Eligible {
+			preview.EligibleSubtotal += item.LineTotal
+		}
+	}
+	if preview.Subtotal < coupon.MinBasket {
+		return nil, domain.ErrCouponMinBasket
+	}
+	if preview.EligibleSubtotal == 0 {
+		return nil, domain.ErrCouponNotApplicable
+	}
+	var discount money.Money
+	switch {
+	case coupon.Kind == model.CouponKindPercentage && coupon.Percent != nil:
+		discount = preview.EligibleSubtotal.Percent(*coupon.Percent)
+	case coupon.Kind == model.CouponKindFixed && coupon.Amount != nil:
+		discount = *coupon.Amount
+	}
+	discount = min(discount, preview.EligibleSubtotal)
+	if coupon.MaxDiscount != nil {
+		discount = min(discount, *coupon.MaxDiscount)
+	}
+	preview.Discount = discount
+	preview.Total = preview.Subtotal - preview.Discount
+	return preview, nil
+}
+
+func isCouponError(err error) bool {
+	return errors.Is(err, domain.ErrCouponInvalid) ||
+		errors.Is(err, domain.ErrCouponNotApplicable) ||
+		errors.Is(err, domain.ErrCouponMinBasket) ||
+		errors.Is(err, domain.ErrCouponUsedUp) ||
+		errors.Is(err, domain.ErrCouponFirstOrder)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCoupon(t *testing.T) {
+	items := []model.CouponPreviewItem{
+		{ProductID: "1", Quantity: 2, UnitPrice: 200000, LineTotal: 400000, Eligible: true},
+		{ProductID: "2", Quantity: 1, UnitPrice: 600000, LineTotal: 600000, Eligible: false},
+	}
+	tests := []struct {
+		name             string
+		coupon           model.Coupon
+		items            []model.CouponPreviewItem
+		expectedDiscount money.Money
+		expectedTotal    money.Money
+		expectedErr      error
+	}{
+		{
+			name:             "percentage counts only eligible lines",
+			coupon:           model.Coupon{Kind: model.CouponKindPercentage, Percent: ptr(10.0)},
+			items:            items,
+			expectedDiscount: 40000,
+			expectedTotal:    960000,
+		},
+		{
+			name:             "percentage is capped by max discount",
+			coupon:           model.Coupon{Kind: model.CouponKindPercentage, Percent: ptr(50.0), MaxDiscount: ptr(money.Money(150000))},
+			items:            items,
+			expectedDiscount: 150000,
+			expectedTotal:    850000,
+		},
+		{
+			name:             "fixed amount never goes above the eligible lines",
+			coupon:           model.Coupon{Kind: model.CouponKindFixed, Amount: ptr(money.Money(700000))},
+			items:            items,
+			expectedDiscount: 400000,
+			expectedTotal:    600000,
+		},
+		{
+			name:        "minimum basket counts the whole basket",
+			coupon:      model.Coupon{Kind: model.CouponKindFixed, Amount: ptr(money.Money(50000)), MinBasket: 1000010},
+			items:       items,
+			expectedErr: domain.ErrCouponMinBasket,
+		},
+		{
+			name:        "nothing in scope",
+			coupon:      model.Coupon{Kind: model.CouponKindFixed, Amount: ptr(money.Money(50000))},
+			items:       items[1:],
+			expectedErr: domain.ErrCouponNotApplicable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := applyCoupon(&tt.coupon, tt.items)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDiscount, preview.Discount)
+			assert.Equal(t, tt.expectedTotal, preview.Total)
+		})
+	}
+}
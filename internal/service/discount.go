@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type discountServiceImpl struct {
+	discountRepository domain.DiscountRepository
+	auditService       domain.AuditService
+	logger             *slog.Logger
+}
+
+func NewDiscountService(discountRepository domain.DiscountRepository, auditService domain.AuditService, logger *slog.Logger) domain.DiscountService {
+	return &discountServiceImpl{
+		discountRepository: discountRepository,
+		auditService:       auditService,
+		logger:             logger,
+	}
+}
+
+func (s *discountServiceImpl) GetAllDiscounts(ctx context.Context, limit, offset int) ([]model.Discount, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	discounts, err := s.discountRepository.GetAll(ctx, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get all discounts", "error", err)
+		return nil, err
+	}
+	return discounts, nil
+}
+
+func (s *discountServiceImpl) GetDiscountByID(ctx context.Context, discountID string) (*model.Discount, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	discount, err := s.discountRepository.GetByID(ctx, discountID)
+	if err != nil {
+		s.logger.Error("failed to get discount by id", "error", err)
+		return nil, err
+	}
+	return discount, nil
+}
+
+func (s *discountServiceImpl) CreateDiscount(ctx context.Context, discount *entity.DiscountRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionDiscountCreated, model.AuditEntityDiscount, "", s.discountSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.discountRepository.Create(ctx, discount)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to create discount", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *discountServiceImpl) UpdateDiscount(ctx context.Context, discountID string, discount *entity.DiscountRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionDiscountUpdated, model.AuditEntityDiscount, discountID, s.discountSnapshot,
+		func(ctx context.Context) (string, error) {
+			return discountID, s.discountRepository.Update(ctx, discountID, discount)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update discount", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *discountServiceImpl) DeleteDiscount(ctx context.Context, discountID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionDiscountDeleted, model.AuditEntityDiscount, discountID, s.discountSnapshot,
+		func(ctx context.Context) (string, error) {
+			return discountID, s.discountRepository.Delete(ctx, discountID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to delete discount", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *discountServiceImpl) discountSnapshot(ctx context.Context, discountID string) (any, error) {
+	return s.discountRepository.GetByID(ctx, discountID)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+)
+
+type emailServiceImpl struct {
+	logger *slog.Logger
+	cfg    *config.Config
+}
+
+func NewEmailService(logger *slog.Logger, cfg *config.Config) domain.EmailService {
+	return &emailServiceImpl{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// Send delivers a plain text email. The SMTP conversation is bound to ctx, its deadline applies to the connection
+// and cancelling ctx closes it, so a hung server fails the attempt instead of blocking the caller.
+func (s *emailServiceImpl) Send(ctx context.Context, subject, body, to string) error {
+	if s.cfg.App.Debug {
+		s.logger.Info("debug mode is enabled", "to", to, "subject", subject, "body", body)
+		return nil
+	}
+	var msg strings.Builder
+	msg.WriteString("From: " + s.cfg.Email.From + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+	if err := s.sendMail(ctx, to, []byte(msg.String())); err != nil {
+		s.logger.Error("failed to send email", "error", err)
+		return err
+	}
+	return nil
+}
+
+// sendMail follows smtp.SendMail, upgrading to TLS when the server offers STARTTLS, over a connection dialed
+// with ctx.
+func (s *emailServiceImpl) sendMail(ctx context.Context, to string, msg []byte) error {
+	addr := fmt.Sprintf("%s:%d", s.cfg.Email.Host, s.cfg.Email.Port)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+	client, err := smtp.NewClient(conn, s.cfg.Email.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Email.Host}); err != nil {
+			return err
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok {
+		auth := smtp.PlainAuth("", s.cfg.Email.Username, s.cfg.Email.Password, s.cfg.Email.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.cfg.Email.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
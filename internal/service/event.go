@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type eventBusImpl struct {
+	mu       sync.RWMutex
+	handlers map[string][]domain.EventHandler
+	logger   *slog.Logger
+}
+
+func NewEventBus(logger *slog.Logger) domain.EventBus {
+	return &eventBusImpl{
+		handlers: make(map[string][]domain.EventHandler),
+		logger:   logger,
+	}
+}
+
+func (b *eventBusImpl) Subscribe(name string, handler domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish hands the event to its subscribers in the background so a slow or failing subscriber
+// never affects the request that raised the event. Subscriber errors are only logged.
+func (b *eventBusImpl) Publish(ctx context.Context, event *model.Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	b.mu.RLock()
+	handlers := b.handlers[event.Name]
+	b.mu.RUnlock()
+	ctx = context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		go func() {
+			if err := handler(ctx, event); err != nil {
+				b.logger.Error("failed to handle event", "event", event.Name, "error", err)
+			}
+		}()
+	}
+}
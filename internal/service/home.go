@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const homeCacheKey = "home"
+
+type homeServiceImpl struct {
+	homeRepository       domain.HomeRepository
+	collectionRepository domain.CollectionRepository
+	categoryRepository   domain.CategoryRepository
+	productRepository    domain.ProductRepository
+	pricingService       domain.PricingService
+	auditService         domain.AuditService
+	redisDB              *redis.Client
+	logger               *slog.Logger
+	cfg                  *config.Config
+}
+
+func NewHomeService(
+	homeRepository domain.HomeRepository,
+	collectionRepository domain.CollectionRepository,
+	categoryRepository domain.CategoryRepository,
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	auditService domain.AuditService,
+	redisDB *redis.Client,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.HomeService {
+	return &homeServiceImpl{
+		homeRepository:       homeRepository,
+		collectionRepository: collectionRepository,
+		categoryRepository:   categoryRepository,
+		productRepository:    productRepository,
+		pricingService:       pricingService,
+		auditService:         auditService,
+		redisDB:              redisDB,
+		logger:               logger,
+		cfg:                  cfg,
+	}
+}
+
+// GetHome returns the resolved homepage from the cache, resolving and caching it on a miss. Redis failures fall
+// back to resolving. The page is the same for every visitor, so it carries no wishlist flags. Edits to sections
+// and products drop the cache, ratings, stock and discount windows show up once it expires.
+func (s *homeServiceImpl) GetHome(ctx context.Context) (*model.Home, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	cached, err := s.redisDB.Get(ctx, homeCacheKey).Bytes()
+	if err == nil {
+		var home model.Home
+		if err := json.Unmarshal(cached, &home); err == nil {
+			return &home, nil
+		}
+		s.logger.Warn("failed to decode cached home", "error", err)
+	} else if !errors.Is(err, redis.Nil) {
+		s.logger.Warn("failed to read cached home", "error", err)
+	}
+	home, err := s.resolveHome(ctx)
+	if err != nil {
+		s.logger.Error("failed to resolve home", "error", err)
+		return nil, err
+	}
+	if encoded, err := json.Marshal(home); err == nil {
+		if err := s.redisDB.Set(ctx, homeCacheKey, encoded, s.cfg.Home.CacheTTL).Err(); err != nil {
+			s.logger.Warn("failed to cache home", "error", err)
+		}
+	}
+	return home, nil
+}
+
+func (s *homeServiceImpl) GetHomeSections(ctx context.Context) ([]model.HomeSection, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	sections, err := s.homeRepository.GetSections(ctx)
+	if err != nil {
+		s.logger.Error("failed to get home sections", "error", err)
+		return nil, err
+	}
+	for i := range sections {
+		s.resolveBannerImages(sections[i].Banners)
+	}
+	return sections, nil
+}
+
+func (s *homeServiceImpl) CreateHomeSection(ctx context.Context, section *entity.HomeSectionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionHomeSectionCreated, model.AuditEntityHomeSection, "", s.sectionSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.homeRepository.CreateSection(ctx, section)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to create home section", "error", err)
+		return err
+	}
+	s.Invalidate(ctx)
+	return nil
+}
+
+func (s *homeServiceImpl) UpdateHomeSection(ctx context.Context, sectionID string, section *entity.HomeSectionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionHomeSectionUpdated, model.AuditEntityHomeSection, sectionID, s.sectionSnapshot,
+		func(ctx context.Context) (string, error) {
+			return sectionID, s.homeRepository.UpdateSection(ctx, sectionID, section)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update home section", "error", err)
+		return err
+	}
+	s.Invalidate(ctx)
+	return nil
+}
+
+func (s *homeServiceImpl) DeleteHomeSection(ctx context.Context, sectionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionHomeSectionDeleted, model.AuditEntityHomeSection, sectionID, s.sectionSnapshot,
+		func(ctx context.Context) (string, error) {
+			return sectionID, s.homeRepository.DeleteSection(ctx, sectionID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to delete home section", "error", err)
+		return err
+	}
+	s.Invalidate(ctx)
+	return nil
+}
+
+func (s *homeServiceImpl) CreateHomeBanner(ctx context.Context, sectionID string, banner *entity.HomeBannerRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionHomeBannerCreated, model.AuditEntityHomeBanner, "", s.bannerSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.homeRepository.CreateBanner(ctx, sectionID, banner)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to create home banner", "error", err)
+		return err
+	}
+	s.Invalidate(ctx)
+	return nil
+}
+
+func (s *homeServiceImpl) DeleteHomeBanner(ctx context.Context, bannerID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionHomeBannerDeleted, model.AuditEntityHomeBanner, bannerID, s.bannerSnapshot,
+		func(ctx context.Context) (string, error) {
+			return bannerID, s.homeRepository.DeleteBanner(ctx, bannerID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to delete home banner", "error", err)
+		return err
+	}
+	s.Invalidate(ctx)
+	return nil
+}
+
+// Invalidate drops the cached homepage so the next visit resolves it again.
+func (s *homeServiceImpl) Invalidate(ctx context.Context) {
+	if err := s.redisDB.Del(ctx, homeCacheKey).Err(); err != nil {
+		s.logger.Warn("failed to invalidate home", "error", err)
+	}
+}
+
+// resolveHome fills the visible sections in order. Sections that would show nothing, such as an empty carousel
+// or a deleted collection, are left out.
+func (s *homeServiceImpl) resolveHome(ctx context.Context) (*model.Home, error) {
+	sections, err := s.homeRepository.GetSections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	home := model.Home{Sections: make([]model.HomeBlock, 0, len(sections))}
+	for _, section := range sections {
+		if section.Hidden {
+			continue
+		}
+		block := model.HomeBlock{ID: section.ID, Kind: section.Kind, Title: section.Title}
+		switch section.Kind {
+		case model.HomeSectionBanner:
+			s.resolveBannerImages(section.Banners)
+			block.Banners = section.Banners
+		case model.HomeSectionCollection:
+			if section.CollectionID == nil {
+				continue
+			}
+			collection, err := s.collectionRepository.GetByID(ctx, *section.CollectionID)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			block.Collection = collection
+			if block.Products, err = collectionProducts(ctx, s.productRepository, collection, section.Limit, 0); err != nil {
+				return nil, err
+			}
+		case model.HomeSectionNewest:
+			if block.Products, err = s.productRepository.GetNewest(ctx, section.Limit); err != nil {
+				return nil, err
+			}
+		case model.HomeSectionBestRated:
+			if block.Products, err = s.productRepository.GetBestRated(ctx, section.Limit); err != nil {
+				return nil, err
+			}
+		case model.HomeSectionCategories:
+			if block.Categories, err = s.categoryRepository.GetByIDs(ctx, section.CategoryIDs); err != nil {
+				return nil, err
+			}
+			for i := range block.Categories {
+				if block.Categories[i].BannerImage != nil {
+					block.Categories[i].BannerImage = helper.BuildMediaURL(s.cfg, block.Categories[i].BannerImage)
+				}
+			}
+		}
+		if len(block.Banners) == 0 && len(block.Products) == 0 && len(block.Categories) == 0 {
+			continue
+		}
+		_ = s.pricingService.PriceProducts(ctx, block.Products)
+		for i := range block.Products {
+			if block.Products[i].MainImage != nil {
+				block.Products[i].MainImage = helper.BuildMediaURL(s.cfg, block.Products[i].MainImage)
+			}
+		}
+		home.Sections = append(home.Sections, block)
+	}
+	return &home, nil
+}
+
+func (s *homeServiceImpl) resolveBannerImages(banners []model.HomeBanner) {
+	for i := range banners {
+		banners[i].Image = *helper.BuildMediaURL(s.cfg, &banners[i].Image)
+	}
+}
+
+func (s *homeServiceImpl) sectionSnapshot(ctx context.Context, sectionID string) (any, error) {
+	return s.homeRepository.GetSectionByID(ctx, sectionID)
+}
+
+func (s *homeServiceImpl) bannerSnapshot(ctx context.Context, bannerID string) (any, error) {
+	return s.homeRepository.GetBannerByID(ctx, bannerID)
+}
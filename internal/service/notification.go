@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type notificationServiceImpl struct {
+	notificationRepository domain.NotificationRepository
+	smsService             domain.SmsService
+	emailService           domain.EmailService
+	auditService           domain.AuditService
+	logger                 *slog.Logger
+	cfg                    *config.Config
+	wakePriority           chan struct{}
+	wakeNormal             chan struct{}
+}
+
+func NewNotificationService(
+	notificationRepository domain.NotificationRepository,
+	smsService domain.SmsService,
+	emailService domain.EmailService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.NotificationService {
+	return &notificationServiceImpl{
+		notificationRepository: notificationRepository,
+		smsService:             smsService,
+		emailService:           emailService,
+		auditService:           auditService,
+		logger:                 logger,
+		cfg:                    cfg,
+		wakePriority:           make(chan struct{}, 1),
+		wakeNormal:             make(chan struct{}, 1),
+	}
+}
+
+func (s *notificationServiceImpl) Enqueue(ctx context.Context, notification *entity.NotificationCreateRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.notificationRepository.Create(ctx, notification, s.cfg.Notification.MaxAttempts); err != nil {
+		s.logger.Error("failed to enqueue notification", "error", err)
+		return err
+	}
+	if notification.Priority >= model.NotificationPriorityHigh {
+		wake(s.wakePriority)
+	} else {
+		wake(s.wakeNormal)
+	}
+	return nil
+}
+
+func (s *notificationServiceImpl) GetAllNotifications(ctx context.Context, params *entity.NotificationQueryParamRequest) ([]model.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	notifications, err := s.notificationRepository.GetAll(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to get all notifications", "error", err)
+		return nil, err
+	}
+	for i := range notifications {
+		redactNotification(&notifications[i])
+	}
+	return notifications, nil
+}
+
+func (s *notificationServiceImpl) GetNotificationByID(ctx context.Context, notificationID string) (*model.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	notification, err := s.notificationRepository.GetByID(ctx, notificationID)
+	if err != nil {
+		s.logger.Error("failed to get notification by id", "error", err)
+		return nil, err
+	}
+	redactNotification(notification)
+	return notification, nil
+}
+
+func (s *notificationServiceImpl) RetryNotification(ctx context.Context, notificationID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionNotificationRetried, model.AuditEntityNotification, notificationID, s.notificationSnapshot,
+		func(ctx context.Context) (string, error) {
+			return notificationID, s.notificationRepository.Requeue(ctx, notificationID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to requeue notification", "error", err)
+		return err
+	}
+	wake(s.wakeNormal)
+	return nil
+}
+
+func (s *notificationServiceImpl) notificationSnapshot(ctx context.Context, notificationID string) (any, error) {
+	notification, err := s.notificationRepository.GetByID(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	redactNotification(notification)
+	return notification, nil
+}
+
+// Run starts the worker pool and blocks until ctx is cancelled.
+// Priority workers only pick high priority messages (e.g. OTP codes) so they never wait behind bulk traffic,
+// while normal workers drain everything ordered by priority.
+func (s *notificationServiceImpl) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < s.cfg.Notification.PriorityWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx, model.NotificationPriorityHigh, s.wakePriority)
+		}()
+	}
+	for i := 0; i < s.cfg.Notification.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx, model.NotificationPriorityNormal, s.wakeNormal)
+		}()
+	}
+	s.logger.Info(
+		"notification workers started",
+		"priority_workers", s.cfg.Notification.PriorityWorkers,
+		"workers", s.cfg.Notification.Workers,
+	)
+	wg.Wait()
+	s.logger.Info("notification workers stopped")
+}
+
+func (s *notificationServiceImpl) work(ctx context.Context, minPriority int, wakeUp <-chan struct{}) {
+	ticker := time.NewTicker(s.cfg.Notification.PollInterval)
+	defer ticker.Stop()
+	for {
+		for ctx.Err() == nil {
+			if s.processBatch(ctx, minPriority) == 0 {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wakeUp:
+		}
+	}
+}
+
+func (s *notificationServiceImpl) processBatch(ctx context.Context, minPriority int) int {
+	claimCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	notifications, err := s.notificationRepository.Claim(
+		claimCtx, minPriority, s.cfg.Notification.BatchSize, s.cfg.Notification.ProcessingLease,
+	)
+	cancel()
+	if err != nil {
+		s.logger.Error("failed to claim notifications", "error", err)
+		return 0
+	}
+	for i := range notifications {
+		s.process(ctx, &notifications[i])
+	}
+	return len(notifications)
+}
+
+// process delivers one claimed notification and records the outcome. Delivery and bookkeeping get a deadline each,
+// so a hung provider fails the attempt instead of stalling the lane or leaving the outcome unrecorded.
+func (s *notificationServiceImpl) process(ctx context.Context, notification *model.Notification) {
+	ctx = context.WithoutCancel(ctx)
+	if notification.ExpiresAt != nil && time.Now().After(*notification.ExpiresAt) {
+		ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		defer cancel()
+		if err := s.notificationRepository.MarkDead(ctx, notification.ID, "expired before delivery"); err != nil {
+			s.logger.Error("failed to mark notification as dead", "id", notification.ID, "error", err)
+		}
+		return
+	}
+	deliveryCtx, cancelDelivery := context.WithTimeout(ctx, 3*time.Second)
+	deliveryErr := s.deliver(deliveryCtx, notification)
+	cancelDelivery()
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if deliveryErr == nil {
+		if err := s.notificationRepository.MarkSent(ctx, notification.ID); err != nil {
+			s.logger.Error("failed to mark notification as sent", "id", notification.ID, "error", err)
+		}
+		return
+	}
+	if notification.Attempts >= notification.MaxAttempts {
+		s.logger.Error(
+			"notification moved to dead letter",
+			"id", notification.ID,
+			"attempts", notification.Attempts,
+			"error", deliveryErr,
+		)
+		if err := s.notificationRepository.MarkDead(ctx, notification.ID, deliveryErr.Error()); err != nil {
+			s.logger.Error("failed to mark notification as dead", "id", notification.ID, "error", err)
+		}
+		return
+	}
+	delay := backoff(s.cfg.Notification.BaseBackoff, s.cfg.Notification.MaxBackoff, notification.Attempts)
+	s.logger.Warn(
+		"notification delivery failed, retrying",
+		"id", notification.ID,
+		"attempts", notification.Attempts,
+		"retry_in", delay.String(),
+		"error", deliveryErr,
+	)
+	if err := s.notificationRepository.MarkFailed(ctx, notification.ID, deliveryErr.Error(), time.Now().Add(delay)); err != nil {
+		s.logger.Error("failed to reschedule notification", "id", notification.ID, "error", err)
+	}
+}
+
+func (s *notificationServiceImpl) deliver(ctx context.Context, notification *model.Notification) error {
+	switch notification.Channel {
+	case model.NotificationChannelSms:
+		return s.smsService.Send(ctx, notification.Message, notification.Recipient)
+	case model.NotificationChannelEmail:
+		subject := ""
+		if notification.Subject != nil {
+			subject = *notification.Subject
+		}
+		return s.emailService.Send(ctx, subject, notification.Message, notification.Recipient)
+	default:
+		return fmt.Errorf("unsupported notification channel: %s", notification.Channel)
+	}
+}
+
+// redactNotification hides the message of a sensitive notification before it is handed to an admin or the
+// audit log, since a live code there would let any admin take over the login it guards.
+func redactNotification(notification *model.Notification) {
+	if notification.Sensitive {
+		notification.Message = model.NotificationRedactedMessage
+	}
+}
+
+// backoff returns base * 2^(attempt-1) capped at maxDelay.
+func backoff(base, maxDelay time.Duration, attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return min(delay, maxDelay)
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
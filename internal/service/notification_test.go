@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempt  int
+		expected time.Duration
+	}{
+		{name: "first attempt uses base delay", attempt: 1, expected: 5 * time.Second},
+		{name: "zero attempt is treated as first", attempt: 0, expected: 5 * time.Second},
+		{name: "delay doubles per attempt", attempt: 3, expected: 20 * time.Second},
+		{name: "delay is capped", attempt: 10, expected: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, backoff(5*time.Second, time.Minute, tt.attempt))
+		})
+	}
+}
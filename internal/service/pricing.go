@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type pricingServiceImpl struct {
+	discountRepository domain.DiscountRepository
+	categoryRepository domain.CategoryRepository
+	logger             *slog.Logger
+}
+
+func NewPricingService(discountRepository domain.DiscountRepository, categoryRepository domain.CategoryRepository, logger *slog.Logger) domain.PricingService {
+	return &pricingServiceImpl{
+		discountRepository: discountRepository,
+		categoryRepository: categoryRepository,
+		logger:             logger,
+	}
+}
+
+// PriceProducts fills the pricing of every product. When discounts cannot be loaded the products keep their list price
+// and the error is returned so the caller can decide whether that is acceptable.
+func (s *pricingServiceImpl) PriceProducts(ctx context.Context, products []model.Products) error {
+	p, err := s.load(ctx)
+	for i := range products {
+		products[i].Pricing = p.price(products[i].Price, products[i].ID, products[i].CategoryID)
+	}
+	return err
+}
+
+func (s *pricingServiceImpl) PriceProduct(ctx context.Context, product *model.Product) error {
+	p, err := s.load(ctx)
+	product.Pricing = p.price(product.Price, product.ID, product.CategoryID)
+	return err
+}
+
+// load reads the running discounts and, when one of them targets a category, the category tree to resolve subcategories.
+// It always returns a usable pricer, an empty one prices everything at list price.
+func (s *pricingServiceImpl) load(ctx context.Context) (*pricer, error) {
+	discounts, err := s.discountRepository.GetActive(ctx)
+	if err != nil {
+		s.logger.Error("failed to get active discounts", "error", err)
+		return &pricer{}, err
+	}
+	p := &pricer{discounts: discounts, parents: make(map[string]*string)}
+	for _, discount := range discounts {
+		if discount.Scope != model.DiscountScopeCategory {
+			continue
+		}
+		categories, err := s.categoryRepository.GetAll(ctx)
+		if err != nil {
+			s.logger.Error("failed to get categories for pricing", "error", err)
+			return &pricer{}, err
+		}
+		collectCategoryParents(categories, p.parents)
+		break
+	}
+	return p, nil
+}
+
+func collectCategoryParents(categories []model.Category, parents map[string]*string) {
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+		collectCategoryParents(category.SubCategories, parents)
+	}
+}
+
+// pricer picks the discount that gives the customer the lowest price. Discounts never stack.
+type pricer struct {
+	discounts []model.Discount
+	parents   map[string]*string
+}
+
+func (p *pricer) price(price money.Money, productID string, categoryID *string) model.Pricing {
+	pricing := model.Pricing{EffectivePrice: price}
+	for _, discount := range p.discounts {
+		if !p.applies(discount, productID, categoryID) {
+			continue
+		}
+		discounted := discountedPrice(price, discount)
+		if discounted >= pricing.EffectivePrice {
+			continue
+		}
+		pricing.EffectivePrice = discounted
+		pricing.SaleEndsAt = discount.EndsAt
+	}
+	if pricing.EffectivePrice < price && price > 0 {
+		percent := math.Round(float64(price-pricing.EffectivePrice)/float64(price)*10000) / 100
+		pricing.DiscountPercent = &percent
+	}
+	return pricing
+}
+
+func (p *pricer) applies(discount model.Discount, productID string, categoryID *string) bool {
+	return inScope(p.parents, discount.Scope, discount.ProductID, discount.CategoryID, productID, categoryID)
+}
+
+// inScope reports whether a product falls under a product, category or catalog scope. parents maps every category
+// to its parent so a scope on a category covers all of its subcategories.
+func inScope(parents map[string]*string, scope string, scopeProductID, scopeCategoryID *string, productID string, categoryID *string) bool {
+	switch scope {
+	case model.DiscountScopeCatalog:
+		return true
+	case model.DiscountScopeProduct:
+		return scopeProductID != nil && *scopeProductID == productID
+	case model.DiscountScopeCategory:
+		if scopeCategoryID == nil {
+			return false
+		}
+		for seen := 0; categoryID != nil && seen <= len(parents); seen++ {
+			if *categoryID == *scopeCategoryID {
+				return true
+			}
+			categoryID = parents[*categoryID]
+		}
+	}
+	return false
+}
+
+func discountedPrice(price money.Money, discount model.Discount) money.Money {
+	var discounted money.Money
+	switch {
+	case discount.Kind == model.DiscountKindPercentage && discount.Percent != nil:
+		discounted = price - price.Percent(*discount.Percent)
+	case discount.Kind == model.DiscountKindFixed && discount.Amount != nil:
+		discounted = price - *discount.Amount
+	case discount.Kind == model.DiscountKindPrice && discount.Amount != nil:
+		discounted = *discount.Amount
+	default:
+		return price
+	}
+	return max(discounted, 0)
+}
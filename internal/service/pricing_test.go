@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricerPrice(t *testing.T) {
+	laptops, gaming := "1", "2"
+	productID := "7"
+	endsAt := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	parents := map[string]*string{laptops: nil, gaming: &laptops}
+	tests := []struct {
+		name            string
+		discounts       []model.Discount
+		categoryID      *string
+		expectedPrice   money.Money
+		expectedPercent *float64
+		expectedEndsAt  *time.Time
+	}{
+		{
+			name:          "no discount keeps the list price",
+			categoryID:    &gaming,
+			expectedPrice: 2000000,
+		},
+		{
+			name: "parent category discount covers subcategories",
+			discounts: []model.Discount{
+				{Kind: model.DiscountKindPercentage, Percent: ptr(10.0), Scope: model.DiscountScopeCategory, CategoryID: &laptops, EndsAt: &endsAt},
+			},
+			categoryID:      &gaming,
+			expectedPrice:   1800000,
+			expectedPercent: ptr(10.0),
+			expectedEndsAt:  &endsAt,
+		},
+		{
+			name: "subcategory discount does not reach the parent",
+			discounts: []model.Discount{
+				{Kind: model.DiscountKindPercentage, Percent: ptr(10.0), Scope: model.DiscountScopeCategory, CategoryID: &gaming},
+			},
+			categoryID:    &laptops,
+			expectedPrice: 2000000,
+		},
+		{
+			name: "lowest price wins and discounts do not stack",
+			discounts: []model.Discount{
+				{Kind: model.DiscountKindFixed, Amount: ptr(money.Money(300000)), Scope: model.DiscountScopeCatalog},
+				{Kind: model.DiscountKindPrice, Amount: ptr(money.Money(1500000)), Scope: model.DiscountScopeProduct, ProductID: &productID, EndsAt: &endsAt},
+				{Kind: model.DiscountKindPercentage, Percent: ptr(5.0), Scope: model.DiscountScopeCatalog},
+			},
+			categoryID:      &gaming,
+			expectedPrice:   1500000,
+			expectedPercent: ptr(25.0),
+			expectedEndsAt:  &endsAt,
+		},
+		{
+			name: "sale price above the list price is ignored",
+			discounts: []model.Discount{
+				{Kind: model.DiscountKindPrice, Amount: ptr(money.Money(2500000)), Scope: model.DiscountScopeProduct, ProductID: &productID},
+			},
+			expectedPrice: 2000000,
+		},
+		{
+			name: "fixed discount never goes below zero",
+			discounts: []model.Discount{
+				{Kind: model.DiscountKindFixed, Amount: ptr(money.Money(5000000)), Scope: model.DiscountScopeCatalog},
+			},
+			expectedPrice:   0,
+			expectedPercent: ptr(100.0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pricer{discounts: tt.discounts, parents: parents}
+			pricing := p.price(2000000, productID, tt.categoryID)
+			assert.Equal(t, tt.expectedPrice, pricing.EffectivePrice)
+			assert.Equal(t, tt.expectedPercent, pricing.DiscountPercent)
+			assert.Equal(t, tt.expectedEndsAt, pricing.SaleEndsAt)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
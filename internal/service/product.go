@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"log/slog"
 	"time"
 
@@ -12,28 +13,70 @@ import (
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
+const priceHistoryWindow = 30 * 24 * time.Hour
+
 type productServiceImpl struct {
-	productRepository domain.ProductRepository
-	logger            *slog.Logger
-	config            *config.Config
+	productRepository      domain.ProductRepository
+	pricingService         domain.PricingService
+	wishlistService        domain.WishlistService
+	productViewService     domain.ProductViewService
+	productRelationService domain.ProductRelationService
+	homeService            domain.HomeService
+	cacheService           domain.CacheService
+	auditService           domain.AuditService
+	logger                 *slog.Logger
+	config                 *config.Config
 }
 
-func NewProductService(productRepository domain.ProductRepository, logger *slog.Logger, config *config.Config) domain.ProductService {
+func NewProductService(
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	productViewService domain.ProductViewService,
+	productRelationService domain.ProductRelationService,
+	homeService domain.HomeService,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+	config *config.Config,
+) domain.ProductService {
 	return &productServiceImpl{
-		productRepository: productRepository,
-		logger:            logger,
-		config:            config,
+		productRepository:      productRepository,
+		pricingService:         pricingService,
+		wishlistService:        wishlistService,
+		productViewService:     productViewService,
+		productRelationService: productRelationService,
+		homeService:            homeService,
+		cacheService:           cacheService,
+		auditService:           auditService,
+		logger:                 logger,
+		config:                 config,
 	}
 }
 
-func (s *productServiceImpl) GetAllProducts(ctx context.Context) ([]model.Products, error) {
+// GetAllProducts lists published products, narrowed to one brand with params.BrandID. Admins see every status and
+// may narrow the list with params.Status.
+func (s *productServiceImpl) GetAllProducts(ctx context.Context, params *entity.ProductQueryParamRequest) ([]model.Products, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	products, err := s.productRepository.GetAll(ctx)
+	status := model.ProductStatusPublished
+	if isAdmin, _ := ctx.Value(helper.CtxIsAdmin).(bool); isAdmin {
+		status = params.Status
+	}
+	var products []model.Products
+	err := s.cacheService.Remember(
+		ctx, "products:"+status+":"+params.BrandID, &products,
+		func(ctx context.Context) (any, []string, error) {
+			products, err := s.productRepository.GetAll(ctx, status, params.BrandID)
+			return products, []string{cacheTagProducts}, err
+		},
+	)
 	if err != nil {
 		s.logger.Error("failed to get all products", "error", err)
 		return nil, err
 	}
+	_ = s.pricingService.PriceProducts(ctx, products)
+	s.wishlistService.MarkProducts(ctx, products)
 	for i := range products {
 		if products[i].MainImage != nil {
 			products[i].MainImage = helper.BuildMediaURL(s.config, products[i].MainImage)
@@ -45,62 +88,191 @@ func (s *productServiceImpl) GetAllProducts(ctx context.Context) ([]model.Produc
 func (s *productServiceImpl) GetProductByID(ctx context.Context, productID string) (*model.Product, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	product, err := s.productRepository.GetByID(ctx, productID)
+	var product *model.Product
+	err := s.cacheService.Remember(
+		ctx, "product:id:"+productID, &product,
+		func(ctx context.Context) (any, []string, error) {
+			product, err := s.productRepository.GetByID(ctx, productID)
+			if err != nil {
+				return nil, nil, err
+			}
+			return product, []string{cacheTagProductDetails, productCacheTag(product.ID)}, nil
+		},
+	)
 	if err != nil {
 		s.logger.Error("failed to get product by id", "error", err)
 		return nil, err
 	}
+	if !visibleProduct(ctx, product) {
+		return nil, sql.ErrNoRows
+	}
 	if product.Images != nil {
 		for i := range product.Images {
 			product.Images[i].ImageURL = *helper.BuildMediaURL(s.config, &product.Images[i].ImageURL)
 		}
 	}
+	_ = s.pricingService.PriceProduct(ctx, product)
+	s.wishlistService.MarkProduct(ctx, product)
+	s.attachPriceHistory(ctx, product)
+	if product.Status == model.ProductStatusPublished {
+		s.productViewService.Record(ctx, product.ID)
+	}
 	return product, nil
 }
 
 func (s *productServiceImpl) GetProductBySlug(ctx context.Context, productSlug string) (*model.Product, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	product, err := s.productRepository.GetBySlug(ctx, productSlug)
+	var product *model.Product
+	err := s.cacheService.Remember(
+		ctx, "product:slug:"+productSlug, &product,
+		func(ctx context.Context) (any, []string, error) {
+			product, err := s.productRepository.GetBySlug(ctx, productSlug)
+			if err != nil {
+				return nil, nil, err
+			}
+			return product, []string{cacheTagProductDetails, productCacheTag(product.ID)}, nil
+		},
+	)
 	if err != nil {
 		s.logger.Error("failed to get product by slug", "error", err)
 		return nil, err
 	}
+	if !visibleProduct(ctx, product) {
+		return nil, sql.ErrNoRows
+	}
 	if product.Images != nil {
 		for i := range product.Images {
 			product.Images[i].ImageURL = *helper.BuildMediaURL(s.config, &product.Images[i].ImageURL)
 		}
 	}
+	_ = s.pricingService.PriceProduct(ctx, product)
+	s.wishlistService.MarkProduct(ctx, product)
+	s.attachPriceHistory(ctx, product)
+	if product.Status == model.ProductStatusPublished {
+		s.productViewService.Record(ctx, product.ID)
+	}
 	return product, nil
 }
 
+func (s *productServiceImpl) GetDeletedProducts(ctx context.Context, limit, offset int) ([]model.Products, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	products, err := s.productRepository.GetAllDeleted(ctx, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get deleted products", "error", err)
+		return nil, err
+	}
+	for i := range products {
+		if products[i].MainImage != nil {
+			products[i].MainImage = helper.BuildMediaURL(s.config, products[i].MainImage)
+		}
+	}
+	return products, nil
+}
+
 func (s *productServiceImpl) CreateProduct(ctx context.Context, product *entity.ProductCreateRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.productRepository.Create(ctx, product); err != nil {
+	editorID, _ := ctx.Value(helper.CtxUserID).(string)
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductCreated, model.AuditEntityProduct, "", s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.productRepository.Create(ctx, editorID, product)
+		},
+	)
+	if err != nil {
 		s.logger.Error("failed to create product", "error", err)
 		return err
 	}
+	s.productRelationService.Invalidate(ctx)
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts)
 	return nil
 }
 
 func (s *productServiceImpl) UpdateProduct(ctx context.Context, productID string, product *entity.ProductUpdateRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.productRepository.Update(ctx, productID, product); err != nil {
+	editorID, _ := ctx.Value(helper.CtxUserID).(string)
+	updated, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product for update", "error", err)
+		return err
+	}
+	oldPrice := updated.Price
+	err = s.auditService.Track(
+		ctx, model.AuditActionProductUpdated, model.AuditEntityProduct, productID, s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.productRepository.Update(ctx, productID, editorID, product)
+		},
+	)
+	if err != nil {
 		s.logger.Error("failed to update product", "error", err)
 		return err
 	}
+	s.productRelationService.Invalidate(ctx)
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
+	updated.Name, updated.Price = product.Name, product.Price
+	s.wishlistService.NotifyPriceDrop(ctx, updated, oldPrice)
+	return nil
+}
+
+func (s *productServiceImpl) UpdateProductStatus(ctx context.Context, productID string, status *entity.ProductStatusUpdateRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductStatusChanged, model.AuditEntityProduct, productID, s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.productRepository.UpdateStatus(ctx, productID, status)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update product status", "error", err)
+		return err
+	}
+	s.productRelationService.Invalidate(ctx)
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
 	return nil
 }
 
 func (s *productServiceImpl) DeleteProduct(ctx context.Context, productID string) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.productRepository.Delete(ctx, productID); err != nil {
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductDeleted, model.AuditEntityProduct, productID, s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.productRepository.Delete(ctx, productID)
+		},
+	)
+	if err != nil {
 		s.logger.Error("failed to delete product", "error", err)
 		return err
 	}
+	s.productRelationService.Invalidate(ctx)
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
+	return nil
+}
+
+func (s *productServiceImpl) RestoreProduct(ctx context.Context, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductRestored, model.AuditEntityProduct, productID, s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.productRepository.Restore(ctx, productID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to restore product", "error", err)
+		return err
+	}
+	s.productRelationService.Invalidate(ctx)
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
 	return nil
 }
 
@@ -114,3 +286,35 @@ func (s *productServiceImpl) ExistsProduct(ctx context.Context, productSlug stri
 	}
 	return exists, nil
 }
+
+// visibleProduct hides drafts and archived products from everybody but admins, who use the public paths to preview them.
+func visibleProduct(ctx context.Context, product *model.Product) bool {
+	if product.Status == model.ProductStatusPublished {
+		return true
+	}
+	isAdmin, _ := ctx.Value(helper.CtxIsAdmin).(bool)
+	return isAdmin
+}
+
+// attachPriceHistory adds the price series of the last 30 days and its lowest price to the product detail.
+// The series starts with the price that was in effect when the window opened. A failure only drops the series.
+func (s *productServiceImpl) attachPriceHistory(ctx context.Context, product *model.Product) {
+	history, err := s.productRepository.GetPriceHistory(ctx, product.ID, time.Now().Add(-priceHistoryWindow))
+	if err != nil {
+		s.logger.Error("failed to get product price history", "error", err)
+		return
+	}
+	if len(history) == 0 {
+		return
+	}
+	lowest := history[0].Price
+	for _, point := range history[1:] {
+		lowest = min(lowest, point.Price)
+	}
+	product.PriceHistory = history
+	product.LowestPrice30d = &lowest
+}
+
+func (s *productServiceImpl) productSnapshot(ctx context.Context, productID string) (any, error) {
+	return s.productRepository.GetByID(ctx, productID)
+}
@@ -3,21 +3,35 @@ package service
 import (
 	"context"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
 type productCommentServiceImpl struct {
 	productCommentRepository domain.ProductCommentRepository
+	productRepository        domain.ProductRepository
+	auditService             domain.AuditService
+	eventBus                 domain.EventBus
 	logger                   *slog.Logger
 }
 
-func NewProductCommentService(productCommentRepository domain.ProductCommentRepository, logger *slog.Logger) domain.ProductCommentService {
+func NewProductCommentService(
+	productCommentRepository domain.ProductCommentRepository,
+	productRepository domain.ProductRepository,
+	auditService domain.AuditService,
+	eventBus domain.EventBus,
+	logger *slog.Logger,
+) domain.ProductCommentService {
 	return &productCommentServiceImpl{
 		productCommentRepository: productCommentRepository,
+		productRepository:        productRepository,
+		auditService:             auditService,
+		eventBus:                 eventBus,
 		logger:                   logger,
 	}
 }
@@ -33,6 +47,28 @@ func (s *productCommentServiceImpl) GetProductCommentByID(ctx context.Context, p
 	return productComment, nil
 }
 
+func (s *productCommentServiceImpl) GetUserProductComments(ctx context.Context, userID string, limit, offset int) ([]model.ProductComment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	productComments, err := s.productCommentRepository.GetAllByUser(ctx, userID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get user product comments", "error", err)
+		return nil, err
+	}
+	return productComments, nil
+}
+
+func (s *productCommentServiceImpl) GetDeletedProductComments(ctx context.Context, limit, offset int) ([]model.ProductComment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	productComments, err := s.productCommentRepository.GetAllDeleted(ctx, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get deleted product comments", "error", err)
+		return nil, err
+	}
+	return productComments, nil
+}
+
 func (s *productCommentServiceImpl) CreateProductComment(ctx context.Context, productID, currentUserID string, productComment *entity.ProductCommentCreateRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -40,9 +76,37 @@ func (s *productCommentServiceImpl) CreateProductComment(ctx context.Context, pr
 		s.logger.Error("failed to create product comment", "error", err)
 		return err
 	}
+	if productComment.ParentID != nil {
+		s.publishCommentReplied(ctx, productID, currentUserID, strconv.Itoa(*productComment.ParentID), productComment.Comment)
+	}
 	return nil
 }
 
+func (s *productCommentServiceImpl) publishCommentReplied(ctx context.Context, productID, currentUserID, parentID, reply string) {
+	parent, err := s.productCommentRepository.GetByID(ctx, parentID)
+	if err != nil {
+		s.logger.Error("failed to get parent comment", "error", err)
+		return
+	}
+	if parent.UserID == nil || *parent.UserID == currentUserID {
+		return
+	}
+	product, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get replied product", "error", err)
+		return
+	}
+	s.eventBus.Publish(ctx, &model.Event{
+		Name:   model.EventCommentReplied,
+		UserID: *parent.UserID,
+		Payload: map[string]string{
+			"product_id":   product.ID,
+			"product_name": product.Name,
+			"reply":        helper.Truncate(reply, 100),
+		},
+	})
+}
+
 func (s *productCommentServiceImpl) UpdateProductComment(ctx context.Context, productCommentID string, productComment *entity.ProductCommentUpdateRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -53,12 +117,49 @@ func (s *productCommentServiceImpl) UpdateProductComment(ctx context.Context, pr
 	return nil
 }
 
+// DeleteProductComment deletes the comment. Deleting somebody else's comment is a moderation action and gets audited.
 func (s *productCommentServiceImpl) DeleteProductComment(ctx context.Context, productCommentID string) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.productCommentRepository.Delete(ctx, productCommentID); err != nil {
+	productComment, err := s.productCommentRepository.GetByID(ctx, productCommentID)
+	if err != nil {
+		s.logger.Error("failed to get product comment", "error", err)
+		return err
+	}
+	currentUserID, _ := ctx.Value(helper.CtxUserID).(string)
+	if productComment.UserID != nil && *productComment.UserID == currentUserID {
+		err = s.productCommentRepository.Delete(ctx, productCommentID)
+	} else {
+		err = s.auditService.Track(
+			ctx, model.AuditActionProductCommentDeleted, model.AuditEntityProductComment, productCommentID, s.productCommentSnapshot,
+			func(ctx context.Context) (string, error) {
+				return productCommentID, s.productCommentRepository.Delete(ctx, productCommentID)
+			},
+		)
+	}
+	if err != nil {
 		s.logger.Error("failed to delete product comment", "error", err)
 		return err
 	}
 	return nil
 }
+
+func (s *productCommentServiceImpl) RestoreProductComment(ctx context.Context, productCommentID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductCommentRestored, model.AuditEntityProductComment, productCommentID, s.productCommentSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productCommentID, s.productCommentRepository.Restore(ctx, productCommentID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to restore product comment", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *productCommentServiceImpl) productCommentSnapshot(ctx context.Context, productCommentID string) (any, error) {
+	return s.productCommentRepository.GetByID(ctx, productCommentID)
+}
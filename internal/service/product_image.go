@@ -6,16 +6,29 @@ import (
 	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
 type productImageServiceImpl struct {
 	productImageRepository domain.ProductImageRepository
+	productRepository      domain.ProductRepository
+	cacheService           domain.CacheService
+	auditService           domain.AuditService
 	logger                 *slog.Logger
 }
 
-func NewProductImageService(productImageRepository domain.ProductImageRepository, logger *slog.Logger) domain.ProductImageService {
+func NewProductImageService(
+	productImageRepository domain.ProductImageRepository,
+	productRepository domain.ProductRepository,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+) domain.ProductImageService {
 	return &productImageServiceImpl{
 		productImageRepository: productImageRepository,
+		productRepository:      productRepository,
+		cacheService:           cacheService,
+		auditService:           auditService,
 		logger:                 logger,
 	}
 }
@@ -23,9 +36,20 @@ func NewProductImageService(productImageRepository domain.ProductImageRepository
 func (s *productImageServiceImpl) CreateProductImage(ctx context.Context, productID string, imagesURL []string) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.productImageRepository.Create(ctx, productID, imagesURL); err != nil {
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductImagesAdded, model.AuditEntityProduct, productID, s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.productImageRepository.Create(ctx, productID, imagesURL)
+		},
+	)
+	if err != nil {
 		s.logger.Error("failed to create product images", "error", err)
 		return err
 	}
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
 	return nil
 }
+
+func (s *productImageServiceImpl) productSnapshot(ctx context.Context, productID string) (any, error) {
+	return s.productRepository.GetByID(ctx, productID)
+}
@@ -7,20 +7,34 @@ import (
 
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 )
 
 type productRatingServiceImpl struct {
 	productRatingRepository domain.ProductRatingRepository
+	cacheService            domain.CacheService
 	logger                  *slog.Logger
 }
 
-func NewProductRatingService(productRatingRepository domain.ProductRatingRepository, logger *slog.Logger) domain.ProductRatingService {
+func NewProductRatingService(productRatingRepository domain.ProductRatingRepository, cacheService domain.CacheService, logger *slog.Logger) domain.ProductRatingService {
 	return &productRatingServiceImpl{
 		productRatingRepository: productRatingRepository,
+		cacheService:            cacheService,
 		logger:                  logger,
 	}
 }
 
+func (s *productRatingServiceImpl) GetUserProductRatings(ctx context.Context, userID string, limit, offset int) ([]model.ProductRating, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	productRatings, err := s.productRatingRepository.GetAllByUser(ctx, userID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get user product ratings", "error", err)
+		return nil, err
+	}
+	return productRatings, nil
+}
+
 func (s *productRatingServiceImpl) CreateOrUpdateProductRating(ctx context.Context, productID, userID string, rate *entity.ProductRatingRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -28,6 +42,7 @@ func (s *productRatingServiceImpl) CreateOrUpdateProductRating(ctx context.Conte
 		s.logger.Error("failed to create or update product rating", "error", err)
 		return err
 	}
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
 	return nil
 }
 
@@ -38,5 +53,6 @@ func (s *productRatingServiceImpl) DeleteProductRating(ctx context.Context, prod
 		s.logger.Error("failed to delete product rating", "error", err)
 		return err
 	}
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
 	return nil
 }
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const relatedProductsVersionKey = "related_products:version"
+
+type productRelationServiceImpl struct {
+	productRelationRepository domain.ProductRelationRepository
+	productRepository         domain.ProductRepository
+	pricingService            domain.PricingService
+	wishlistService           domain.WishlistService
+	auditService              domain.AuditService
+	redisDB                   *redis.Client
+	logger                    *slog.Logger
+	cfg                       *config.Config
+}
+
+func NewProductRelationService(
+	productRelationRepository domain.ProductRelationRepository,
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	auditService domain.AuditService,
+	redisDB *redis.Client,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.ProductRelationService {
+	return &productRelationServiceImpl{
+		productRelationRepository: productRelationRepository,
+		productRepository:         productRepository,
+		pricingService:            pricingService,
+		wishlistService:           wishlistService,
+		auditService:              auditService,
+		redisDB:                   redisDB,
+		logger:                    logger,
+		cfg:                       cfg,
+	}
+}
+
+// GetRelatedProducts lists the pinned relations of a product followed by the ranked ones. The ranking is cached,
+// prices and stock are always read fresh.
+func (s *productRelationServiceImpl) GetRelatedProducts(ctx context.Context, productID string, limit int) ([]model.Products, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	product, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product for related products", "error", err)
+		return nil, err
+	}
+	if product.Status != model.ProductStatusPublished {
+		return nil, sql.ErrNoRows
+	}
+	ids, err := s.relatedIDs(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to rank related products", "error", err)
+		return nil, err
+	}
+	products, err := publishedProductsInOrder(ctx, s.productRepository, ids)
+	if err != nil {
+		s.logger.Error("failed to get related products", "error", err)
+		return nil, err
+	}
+	products = products[:min(len(products), limit)]
+	presentProducts(ctx, s.pricingService, s.wishlistService, s.cfg, products)
+	return products, nil
+}
+
+// SetRelatedProducts replaces the pinned relations of a product, an empty list removes them all.
+func (s *productRelationServiceImpl) SetRelatedProducts(ctx context.Context, productID string, relation *entity.ProductRelationRequest) error {
+	if slices.ContainsFunc(relation.ProductIDs, func(id int) bool { return strconv.Itoa(id) == productID }) {
+		return domain.ErrRelatedToSelf
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductRelationsSet, model.AuditEntityProduct, productID, s.relationSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.productRelationRepository.Replace(ctx, productID, relation.ProductIDs)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to set related products", "error", err)
+		return err
+	}
+	s.Invalidate(ctx)
+	return nil
+}
+
+// Invalidate drops every cached ranking at once by moving to a new cache version, the old entries expire on their
+// own. A product change can move it into or out of the ranking of any other product, so there is nothing narrower
+// to drop. Changes that skip this, such as category moves, show up once the entries expire.
+func (s *productRelationServiceImpl) Invalidate(ctx context.Context) {
+	if err := s.redisDB.Incr(ctx, relatedProductsVersionKey).Err(); err != nil {
+		s.logger.Warn("failed to invalidate related products", "error", err)
+	}
+}
+
+// relatedIDs returns the cached ranking of the product, computing it on a miss. Redis failures fall back to computing.
+func (s *productRelationServiceImpl) relatedIDs(ctx context.Context, productID string) ([]string, error) {
+	version, err := s.redisDB.Get(ctx, relatedProductsVersionKey).Result()
+	if errors.Is(err, redis.Nil) {
+		version, err = "0", nil
+	}
+	if err != nil {
+		s.logger.Warn("failed to read related products cache version", "error", err)
+		return s.rankRelated(ctx, productID)
+	}
+	key := relatedProductsKey(version, productID)
+	cached, err := s.redisDB.Get(ctx, key).Result()
+	if err == nil {
+		if cached == "" {
+			return make([]string, 0), nil
+		}
+		return strings.Split(cached, ","), nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		s.logger.Warn("failed to read cached related products", "error", err)
+	}
+	ids, err := s.rankRelated(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redisDB.Set(ctx, key, strings.Join(ids, ","), s.cfg.Recommendation.RelatedTTL).Err(); err != nil {
+		s.logger.Warn("failed to cache related products", "error", err)
+	}
+	return ids, nil
+}
+
+func (s *productRelationServiceImpl) rankRelated(ctx context.Context, productID string) ([]string, error) {
+	pinned, err := s.productRelationRepository.GetPinnedIDs(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	ranked, err := s.productRelationRepository.GetRankedIDs(ctx, productID, s.cfg.Recommendation.RelatedSize)
+	if err != nil {
+		return nil, err
+	}
+	return mergeRelatedIDs(productID, pinned, ranked), nil
+}
+
+func (s *productRelationServiceImpl) relationSnapshot(ctx context.Context, productID string) (any, error) {
+	pinned, err := s.productRelationRepository.GetPinnedIDs(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return helper.M{"related_ids": pinned}, nil
+}
+
+// mergeRelatedIDs concatenates the sources in order of precedence, keeping the first occurrence of every id and
+// leaving out the product itself.
+func mergeRelatedIDs(productID string, sources ...[]string) []string {
+	seen := map[string]bool{productID: true}
+	merged := make([]string, 0)
+	for _, source := range sources {
+		for _, id := range source {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+func relatedProductsKey(version, productID string) string {
+	return "related_products:" + version + ":" + productID
+}
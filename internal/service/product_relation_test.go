@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRelatedIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  [][]string
+		expected []string
+	}{
+		{
+			name:     "pinned come first and duplicates keep their first position",
+			sources:  [][]string{{"7", "3"}, {"3", "9", "7", "4"}},
+			expected: []string{"7", "3", "9", "4"},
+		},
+		{
+			name:     "the product itself is left out",
+			sources:  [][]string{{"1", "2"}, {"2", "1"}},
+			expected: []string{"2"},
+		},
+		{
+			name:     "no sources",
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mergeRelatedIDs("1", tt.sources...))
+		})
+	}
+}
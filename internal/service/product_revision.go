@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type productRevisionServiceImpl struct {
+	productRevisionRepository domain.ProductRevisionRepository
+	productRepository         domain.ProductRepository
+	wishlistService           domain.WishlistService
+	productRelationService    domain.ProductRelationService
+	homeService               domain.HomeService
+	cacheService              domain.CacheService
+	auditService              domain.AuditService
+	logger                    *slog.Logger
+}
+
+func NewProductRevisionService(
+	productRevisionRepository domain.ProductRevisionRepository,
+	productRepository domain.ProductRepository,
+	wishlistService domain.WishlistService,
+	productRelationService domain.ProductRelationService,
+	homeService domain.HomeService,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+) domain.ProductRevisionService {
+	return &productRevisionServiceImpl{
+		productRevisionRepository: productRevisionRepository,
+		productRepository:         productRepository,
+		wishlistService:           wishlistService,
+		productRelationService:    productRelationService,
+		homeService:               homeService,
+		cacheService:              cacheService,
+		auditService:              auditService,
+		logger:                    logger,
+	}
+}
+
+func (s *productRevisionServiceImpl) GetProductRevisions(ctx context.Context, productID string, limit, offset int) ([]model.ProductRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	productRevisions, err := s.productRevisionRepository.GetAll(ctx, productID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get product revisions", "error", err)
+		return nil, err
+	}
+	return productRevisions, nil
+}
+
+// DiffProductRevisions compares two revisions of a product, to defaults to the latest revision when it is zero.
+func (s *productRevisionServiceImpl) DiffProductRevisions(ctx context.Context, productID string, from, to int) (*model.ProductRevisionDiff, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	fromRevision, err := s.productRevisionRepository.GetByRevision(ctx, productID, from)
+	if err != nil {
+		s.logger.Error("failed to get product revision", "error", err)
+		return nil, err
+	}
+	var toRevision *model.ProductRevision
+	if to == 0 {
+		toRevision, err = s.productRevisionRepository.GetLatest(ctx, productID)
+	} else {
+		toRevision, err = s.productRevisionRepository.GetByRevision(ctx, productID, to)
+	}
+	if err != nil {
+		s.logger.Error("failed to get product revision", "error", err)
+		return nil, err
+	}
+	before, after := diffAuditState(productRevisionState(fromRevision), productRevisionState(toRevision))
+	return &model.ProductRevisionDiff{
+		From:   fromRevision.Revision,
+		To:     toRevision.Revision,
+		Before: before,
+		After:  after,
+	}, nil
+}
+
+func (s *productRevisionServiceImpl) RollbackProduct(ctx context.Context, productID string, revision int) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	editorID, _ := ctx.Value(helper.CtxUserID).(string)
+	before, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product for rollback", "error", err)
+		return err
+	}
+	err = s.auditService.Track(
+		ctx, model.AuditActionProductRolledBack, model.AuditEntityProduct, productID, s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.productRepository.Rollback(ctx, productID, editorID, revision)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to rollback product", "error", err)
+		return err
+	}
+	s.productRelationService.Invalidate(ctx)
+	s.homeService.Invalidate(ctx)
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
+	if after, err := s.productRepository.GetByID(ctx, productID); err == nil {
+		s.wishlistService.NotifyPriceDrop(ctx, after, before.Price)
+	}
+	return nil
+}
+
+func (s *productRevisionServiceImpl) productSnapshot(ctx context.Context, productID string) (any, error) {
+	return s.productRepository.GetByID(ctx, productID)
+}
+
+// productRevisionState keeps only the product fields so bookkeeping columns never show up in a diff.
+func productRevisionState(revision *model.ProductRevision) helper.M {
+	return helper.M{
+		"name":              revision.Name,
+		"slug":              revision.Slug,
+		"description":       revision.Description,
+		"short_description": revision.ShortDescription,
+		"price":             revision.Price,
+		"quantity":          revision.Quantity,
+		"category_id":       revision.CategoryID,
+		"brand_id":          revision.BrandID,
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type productSchedulerServiceImpl struct {
+	productRepository      domain.ProductRepository
+	productRelationService domain.ProductRelationService
+	homeService            domain.HomeService
+	cacheService           domain.CacheService
+	auditService           domain.AuditService
+	logger                 *slog.Logger
+	cfg                    *config.Config
+}
+
+func NewProductSchedulerService(
+	productRepository domain.ProductRepository,
+	productRelationService domain.ProductRelationService,
+	homeService domain.HomeService,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.ProductSchedulerService {
+	return &productSchedulerServiceImpl{
+		productRepository:      productRepository,
+		productRelationService: productRelationService,
+		homeService:            homeService,
+		cacheService:           cacheService,
+		auditService:           auditService,
+		logger:                 logger,
+		cfg:                    cfg,
+	}
+}
+
+// Tick publishes the drafts and archives the published products whose schedule has come due.
+// Every transition is audited without an actor since nobody triggered it by hand.
+func (s *productSchedulerServiceImpl) Tick(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	transitions := []struct {
+		action string
+		status string
+		apply  func(ctx context.Context) ([]string, error)
+	}{
+		{model.AuditActionProductPublished, model.ProductStatusPublished, s.productRepository.PublishDue},
+		{model.AuditActionProductUnpublished, model.ProductStatusArchived, s.productRepository.UnpublishDue},
+	}
+	for _, t := range transitions {
+		productIDs, err := t.apply(ctx)
+		if err != nil {
+			s.logger.Error("failed to apply product schedule", "action", t.action, "error", err)
+			return err
+		}
+		tags := []string{cacheTagProducts}
+		for _, productID := range productIDs {
+			tags = append(tags, productCacheTag(productID))
+			_ = s.auditService.Record(ctx, &entity.AuditLogCreateRequest{
+				Action:     t.action,
+				EntityType: model.AuditEntityProduct,
+				EntityID:   productID,
+				After:      helper.M{"status": t.status},
+			})
+		}
+		if len(productIDs) > 0 {
+			s.logger.Info("applied product schedule", "action", t.action, "products", len(productIDs))
+			s.productRelationService.Invalidate(ctx)
+			s.homeService.Invalidate(ctx)
+			s.cacheService.InvalidateTags(ctx, tags...)
+		}
+	}
+	return nil
+}
+
+// Run ticks every PollInterval and blocks until ctx is cancelled.
+func (s *productSchedulerServiceImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Publication.PollInterval)
+	defer ticker.Stop()
+	s.logger.Info("product scheduler started", "interval", s.cfg.Publication.PollInterval)
+	for {
+		_ = s.Tick(ctx)
+		select {
+		case <-ctx.Done():
+			s.logger.Info("product scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+type productViewServiceImpl struct {
+	productRepository domain.ProductRepository
+	pricingService    domain.PricingService
+	wishlistService   domain.WishlistService
+	redisDB           *redis.Client
+	logger            *slog.Logger
+	cfg               *config.Config
+}
+
+func NewProductViewService(
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	redisDB *redis.Client,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.ProductViewService {
+	return &productViewServiceImpl{
+		productRepository: productRepository,
+		pricingService:    pricingService,
+		wishlistService:   wishlistService,
+		redisDB:           redisDB,
+		logger:            logger,
+		cfg:               cfg,
+	}
+}
+
+// Record adds the product to the recently viewed list of the viewer. The first view of a product also counts it as
+// viewed together with the last few products of that list, in both directions. Views are best effort, failures are
+// only logged.
+func (s *productViewServiceImpl) Record(ctx context.Context, productID string) {
+	viewer := productViewer(ctx)
+	if viewer == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	recentKey := recentlyViewedKey(viewer)
+	recent, err := s.redisDB.ZRevRange(ctx, recentKey, 0, int64(s.cfg.Recommendation.RecentLimit-1)).Result()
+	if err != nil {
+		s.logger.Warn("failed to read recently viewed products", "error", err)
+		return
+	}
+	ttl := s.cfg.Recommendation.ViewTTL
+	_, err = s.redisDB.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, recentKey, redis.Z{Score: float64(time.Now().UnixMilli()), Member: productID})
+		pipe.ZRemRangeByRank(ctx, recentKey, 0, int64(-s.cfg.Recommendation.RecentLimit-1))
+		pipe.Expire(ctx, recentKey, ttl)
+		if slices.Contains(recent, productID) {
+			return nil
+		}
+		for _, otherID := range recent[:min(len(recent), s.cfg.Recommendation.CoViewWindow)] {
+			s.countCoView(ctx, pipe, productID, otherID)
+			s.countCoView(ctx, pipe, otherID, productID)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("failed to record product view", "error", err)
+	}
+}
+
+// countCoView bumps otherID in the also viewed ranking of productID and drops the weakest entries past the limit.
+func (s *productViewServiceImpl) countCoView(ctx context.Context, pipe redis.Pipeliner, productID, otherID string) {
+	key := alsoViewedKey(productID)
+	pipe.ZIncrBy(ctx, key, 1, otherID)
+	pipe.ZRemRangeByRank(ctx, key, 0, int64(-s.cfg.Recommendation.CoViewLimit-1))
+	pipe.Expire(ctx, key, s.cfg.Recommendation.ViewTTL)
+}
+
+// GetRecentlyViewed lists what the current user, or the anonymous device, viewed last. Products that were unpublished
+// or trashed since are left out.
+func (s *productViewServiceImpl) GetRecentlyViewed(ctx context.Context, limit int) ([]model.Products, error) {
+	viewer := productViewer(ctx)
+	if viewer == "" {
+		return make([]model.Products, 0), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	ids, err := s.redisDB.ZRevRange(ctx, recentlyViewedKey(viewer), 0, int64(limit-1)).Result()
+	if err != nil {
+		s.logger.Error("failed to get recently viewed products", "error", err)
+		return nil, err
+	}
+	products, err := publishedProductsInOrder(ctx, s.productRepository, ids)
+	if err != nil {
+		s.logger.Error("failed to get recently viewed products", "error", err)
+		return nil, err
+	}
+	presentProducts(ctx, s.pricingService, s.wishlistService, s.cfg, products)
+	return products, nil
+}
+
+// GetAlsoViewed ranks the products most often viewed together with the given one. Products with too few co-views are
+// topped up with the best rated products of their category.
+func (s *productViewServiceImpl) GetAlsoViewed(ctx context.Context, productID string, limit int) ([]model.Products, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	product, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product for also viewed", "error", err)
+		return nil, err
+	}
+	if product.Status != model.ProductStatusPublished {
+		return nil, sql.ErrNoRows
+	}
+	ids, err := s.redisDB.ZRevRange(ctx, alsoViewedKey(productID), 0, int64(limit-1)).Result()
+	if err != nil {
+		s.logger.Warn("failed to get also viewed products", "error", err)
+	}
+	products, err := publishedProductsInOrder(ctx, s.productRepository, ids)
+	if err != nil {
+		s.logger.Error("failed to get also viewed products", "error", err)
+		return nil, err
+	}
+	if len(products) < limit && product.CategoryID != nil {
+		exclude := []string{productID}
+		for _, p := range products {
+			exclude = append(exclude, p.ID)
+		}
+		fallback, err := s.productRepository.GetPopularByCategory(ctx, *product.CategoryID, exclude, limit-len(products))
+		if err != nil {
+			s.logger.Error("failed to get category products for also viewed", "error", err)
+			return nil, err
+		}
+		products = append(products, fallback...)
+	}
+	presentProducts(ctx, s.pricingService, s.wishlistService, s.cfg, products)
+	return products, nil
+}
+
+// publishedProductsInOrder loads the published products among ids, keeping the order of ids.
+func publishedProductsInOrder(ctx context.Context, productRepository domain.ProductRepository, ids []string) ([]model.Products, error) {
+	products := make([]model.Products, 0, len(ids))
+	if len(ids) == 0 {
+		return products, nil
+	}
+	found, err := productRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]model.Products, len(found))
+	for _, p := range found {
+		if p.Status == model.ProductStatusPublished {
+			byID[p.ID] = p
+		}
+	}
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+// presentProducts prices a storefront list, flags what the current user wishlisted and resolves the image urls.
+func presentProducts(
+	ctx context.Context,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	cfg *config.Config,
+	products []model.Products,
+) {
+	_ = pricingService.PriceProducts(ctx, products)
+	wishlistService.MarkProducts(ctx, products)
+	for i := range products {
+		if products[i].MainImage != nil {
+			products[i].MainImage = helper.BuildMediaURL(cfg, products[i].MainImage)
+		}
+	}
+}
+
+// productViewer keys views by user when logged in and by device otherwise, so a login starts a fresh history.
+func productViewer(ctx context.Context) string {
+	if userID, _ := ctx.Value(helper.CtxUserID).(string); userID != "" {
+		return "user:" + userID
+	}
+	if deviceID, _ := ctx.Value(helper.CtxDeviceID).(string); deviceID != "" {
+		return "device:" + deviceID
+	}
+	return ""
+}
+
+func recentlyViewedKey(viewer string) string {
+	return "recently_viewed:" + viewer
+}
+
+func alsoViewedKey(productID string) string {
+	return "also_viewed:" + productID
+}
@@ -2,10 +2,10 @@ package service
 
 import (
 	"log/slog"
-	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/config"
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -17,12 +17,57 @@ type serviceImpl struct {
 	productImageRepository       domain.ProductImageRepository
 	productCommentRepository     domain.ProductCommentRepository
 	productCommentLikeRepository domain.ProductCommentLikeRepository
+	notificationRepository       domain.NotificationRepository
+	notificationService          domain.NotificationService
+	userNotificationService      domain.UserNotificationService
+	userAddressRepository        domain.UserAddressRepository
+	auditRepository              domain.AuditRepository
+	productRevisionRepository    domain.ProductRevisionRepository
+	discountRepository           domain.DiscountRepository
+	couponRepository             domain.CouponRepository
+	stockMovementRepository      domain.StockMovementRepository
+	stockSubscriptionRepository  domain.StockSubscriptionRepository
+	wishlistRepository           domain.WishlistRepository
+	productRelationRepository    domain.ProductRelationRepository
+	brandRepository              domain.BrandRepository
+	tagRepository                domain.TagRepository
+	collectionRepository         domain.CollectionRepository
+	homeRepository               domain.HomeRepository
+	cacheService                 domain.CacheService
+	eventBus                     domain.EventBus
 	redisDB                      *redis.Client
 	logger                       *slog.Logger
 	cfg                          *config.Config
 }
 
 func NewService(repositories domain.Repository, redisDB *redis.Client, logger *slog.Logger, cfg *config.Config) domain.Service {
+	notificationService := NewNotificationService(
+		repositories.Notification(),
+		NewSmsService(logger, cfg),
+		NewEmailService(logger, cfg),
+		NewAuditService(repositories.Audit(), logger),
+		logger,
+		cfg,
+	)
+	userNotificationService := NewUserNotificationService(
+		repositories.User(),
+		repositories.Notification(),
+		notificationService,
+		logger,
+	)
+	eventBus := NewEventBus(logger)
+	eventBus.Subscribe(model.EventAccountCreated, userNotificationService.HandleEvent)
+	eventBus.Subscribe(model.EventCommentReplied, userNotificationService.HandleEvent)
+	eventBus.Subscribe(model.EventProductPriceDropped, userNotificationService.HandleEvent)
+	eventBus.Subscribe(model.EventProductLowStock, userNotificationService.HandleEvent)
+	stockSubscriptionService := NewStockSubscriptionService(
+		repositories.StockSubscription(),
+		repositories.Product(),
+		NewSmsService(logger, cfg),
+		logger,
+		cfg,
+	)
+	eventBus.Subscribe(model.EventProductRestocked, stockSubscriptionService.HandleEvent)
 	return &serviceImpl{
 		userRepository:               repositories.User(),
 		categoryRepository:           repositories.Category(),
@@ -31,6 +76,24 @@ func NewService(repositories domain.Repository, redisDB *redis.Client, logger *s
 		productImageRepository:       repositories.ProductImage(),
 		productCommentRepository:     repositories.ProductComment(),
 		productCommentLikeRepository: repositories.ProductCommentLike(),
+		notificationRepository:       repositories.Notification(),
+		notificationService:          notificationService,
+		userNotificationService:      userNotificationService,
+		userAddressRepository:        repositories.UserAddress(),
+		auditRepository:              repositories.Audit(),
+		productRevisionRepository:    repositories.ProductRevision(),
+		discountRepository:           repositories.Discount(),
+		couponRepository:             repositories.Coupon(),
+		stockMovementRepository:      repositories.StockMovement(),
+		stockSubscriptionRepository:  repositories.StockSubscription(),
+		wishlistRepository:           repositories.Wishlist(),
+		productRelationRepository:    repositories.ProductRelation(),
+		brandRepository:              repositories.Brand(),
+		tagRepository:                repositories.Tag(),
+		collectionRepository:         repositories.Collection(),
+		homeRepository:               repositories.Home(),
+		cacheService:                 NewCacheService(redisDB, logger, cfg),
+		eventBus:                     eventBus,
 		redisDB:                      redisDB,
 		logger:                       logger,
 		cfg:                          cfg,
@@ -38,35 +101,49 @@ func NewService(repositories domain.Repository, redisDB *redis.Client, logger *s
 }
 
 func (s *serviceImpl) User() domain.UserService {
-	return NewUserService(s.userRepository, s.redisDB, s.logger, s.cfg)
+	return NewUserService(
+		s.userRepository,
+		s.OTP(),
+		s.notificationService,
+		s.Session(),
+		s.Audit(),
+		s.eventBus,
+		s.redisDB,
+		s.logger,
+		s.cfg,
+	)
 }
 
 func (s *serviceImpl) OTP() domain.OTPService {
-	return NewUserOTPService(s.redisDB, time.Minute*2)
+	return NewUserOTPService(s.redisDB, domain.OTPExpiration)
 }
 
 func (s *serviceImpl) Sms() domain.SmsService {
 	return NewSmsService(s.logger, s.cfg)
 }
 
+func (s *serviceImpl) Email() domain.EmailService {
+	return NewEmailService(s.logger, s.cfg)
+}
+
 func (s *serviceImpl) Category() domain.CategoryService {
-	return NewCategoryService(s.categoryRepository, s.logger)
+	return NewCategoryService(s.categoryRepository, s.productRepository, s.Pricing(), s.Wishlist(), s.Home(), s.Cache(), s.Audit(), s.logger, s.cfg)
 }
 
 func (s *serviceImpl) Product() domain.ProductService {
-	return NewProductService(s.productRepository, s.logger, s.cfg)
+	return NewProductService(s.productRepository, s.Pricing(), s.Wishlist(), s.ProductView(), s.ProductRelation(), s.Home(), s.Cache(), s.Audit(), s.logger, s.cfg)
 }
 
 func (s *serviceImpl) ProductRating() domain.ProductRatingService {
-	return NewProductRatingService(s.productRatingRepository, s.logger)
+	return NewProductRatingService(s.productRatingRepository, s.Cache(), s.logger)
 }
 
 func (s *serviceImpl) ProductImage() domain.ProductImageService {
-	return NewProductImageService(s.productImageRepository, s.logger)
+	return NewProductImageService(s.productImageRepository, s.productRepository, s.Cache(), s.Audit(), s.logger)
 }
 
 func (s *serviceImpl) ProductComment() domain.ProductCommentService {
-	return NewProductCommentService(s.productCommentRepository, s.logger)
+	return NewProductCommentService(s.productCommentRepository, s.productRepository, s.Audit(), s.eventBus, s.logger)
 }
 
 func (s *serviceImpl) ProductCommentLike() domain.ProductCommentLikeService {
@@ -76,3 +153,91 @@ func (s *serviceImpl) ProductCommentLike() domain.ProductCommentLikeService {
 func (s *serviceImpl) S3() domain.S3Service {
 	return NewS3Service(s.cfg, s.logger)
 }
+
+func (s *serviceImpl) Notification() domain.NotificationService {
+	return s.notificationService
+}
+
+func (s *serviceImpl) UserNotification() domain.UserNotificationService {
+	return s.userNotificationService
+}
+
+func (s *serviceImpl) Events() domain.EventBus {
+	return s.eventBus
+}
+
+func (s *serviceImpl) UserAddress() domain.UserAddressService {
+	return NewUserAddressService(s.userAddressRepository, s.logger)
+}
+
+func (s *serviceImpl) Session() domain.SessionService {
+	return NewSessionService(s.userRepository, s.redisDB, s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Audit() domain.AuditService {
+	return NewAuditService(s.auditRepository, s.logger)
+}
+
+func (s *serviceImpl) Trash() domain.TrashService {
+	return NewTrashService(s.categoryRepository, s.productRepository, s.productCommentRepository, s.logger, s.cfg)
+}
+
+func (s *serviceImpl) ProductRevision() domain.ProductRevisionService {
+	return NewProductRevisionService(s.productRevisionRepository, s.productRepository, s.Wishlist(), s.ProductRelation(), s.Home(), s.Cache(), s.Audit(), s.logger)
+}
+
+func (s *serviceImpl) ProductScheduler() domain.ProductSchedulerService {
+	return NewProductSchedulerService(s.productRepository, s.ProductRelation(), s.Home(), s.Cache(), s.Audit(), s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Discount() domain.DiscountService {
+	return NewDiscountService(s.discountRepository, s.Audit(), s.logger)
+}
+
+func (s *serviceImpl) Pricing() domain.PricingService {
+	return NewPricingService(s.discountRepository, s.categoryRepository, s.logger)
+}
+
+func (s *serviceImpl) Coupon() domain.CouponService {
+	return NewCouponService(s.couponRepository, s.productRepository, s.categoryRepository, s.Pricing(), s.Audit(), s.logger)
+}
+
+func (s *serviceImpl) StockMovement() domain.StockMovementService {
+	return NewStockMovementService(s.stockMovementRepository, s.productRepository, s.userRepository, s.Cache(), s.Audit(), s.eventBus, s.logger, s.cfg)
+}
+
+func (s *serviceImpl) StockSubscription() domain.StockSubscriptionService {
+	return NewStockSubscriptionService(s.stockSubscriptionRepository, s.productRepository, s.Sms(), s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Wishlist() domain.WishlistService {
+	return NewWishlistService(s.wishlistRepository, s.productRepository, s.Pricing(), s.eventBus, s.logger, s.cfg)
+}
+
+func (s *serviceImpl) ProductView() domain.ProductViewService {
+	return NewProductViewService(s.productRepository, s.Pricing(), s.Wishlist(), s.redisDB, s.logger, s.cfg)
+}
+
+func (s *serviceImpl) ProductRelation() domain.ProductRelationService {
+	return NewProductRelationService(s.productRelationRepository, s.productRepository, s.Pricing(), s.Wishlist(), s.Audit(), s.redisDB, s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Brand() domain.BrandService {
+	return NewBrandService(s.brandRepository, s.productRepository, s.Pricing(), s.Wishlist(), s.Cache(), s.Audit(), s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Tag() domain.TagService {
+	return NewTagService(s.tagRepository, s.productRepository, s.Pricing(), s.Wishlist(), s.Cache(), s.Audit(), s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Collection() domain.CollectionService {
+	return NewCollectionService(s.collectionRepository, s.productRepository, s.Pricing(), s.Wishlist(), s.Home(), s.Audit(), s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Home() domain.HomeService {
+	return NewHomeService(s.homeRepository, s.collectionRepository, s.categoryRepository, s.productRepository, s.Pricing(), s.Audit(), s.redisDB, s.logger, s.cfg)
+}
+
+func (s *serviceImpl) Cache() domain.CacheService {
+	return s.cacheService
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// bannedSessionVersion is cached in place of the version of a banned user.
+const bannedSessionVersion = "banned"
+
+type sessionServiceImpl struct {
+	userRepository domain.UserRepository
+	redisDB        *redis.Client
+	logger         *slog.Logger
+	cfg            *config.Config
+}
+
+func NewSessionService(userRepository domain.UserRepository, redisDB *redis.Client, logger *slog.Logger, cfg *config.Config) domain.SessionService {
+	return &sessionServiceImpl{
+		userRepository: userRepository,
+		redisDB:        redisDB,
+		logger:         logger,
+		cfg:            cfg,
+	}
+}
+
+// Version returns the session version a token must carry to be accepted, or domain.ErrUserBanned.
+// The value lives on the user row and is cached in redis so authenticated requests do not hit postgres.
+// A miss fills the cache with SETNX and a short TTL, so a reader that loaded the row before a change never
+// overwrites the value Refresh wrote after it.
+func (s *sessionServiceImpl) Version(ctx context.Context, userID string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	cached, err := s.redisDB.Get(ctx, sessionVersionKey(userID)).Result()
+	if err == nil {
+		if cached == bannedSessionVersion {
+			return 0, domain.ErrUserBanned
+		}
+		if version, err := strconv.Atoi(cached); err == nil {
+			return version, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		s.logger.Warn("failed to read cached session version", "error", err)
+	}
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.redisDB.SetNX(ctx, sessionVersionKey(userID), sessionVersionValue(user), s.cfg.Jwt.SessionCacheTTL).Err(); err != nil {
+		s.logger.Warn("failed to cache session version", "error", err)
+	}
+	if user.IsBanned {
+		return 0, domain.ErrUserBanned
+	}
+	return user.SessionVersion, nil
+}
+
+// Revoke invalidates every token issued to the user so far.
+func (s *sessionServiceImpl) Revoke(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.userRepository.IncrementSessionVersion(ctx, userID); err != nil {
+		s.logger.Error("failed to revoke user sessions", "error", err)
+		return err
+	}
+	return s.Refresh(ctx, userID)
+}
+
+// Refresh writes the current version, or the ban, to the cache after it was changed in the database by another
+// statement. It overwrites instead of deleting, so a concurrent miss cannot put the old version back. If the row
+// cannot be read the entry is dropped and the next request reloads it.
+func (s *sessionServiceImpl) Refresh(ctx context.Context, userID string) error {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user with id", "error", err)
+		if err := s.redisDB.Del(ctx, sessionVersionKey(userID)).Err(); err != nil {
+			s.logger.Error("failed to drop cached session version", "error", err)
+		}
+		return err
+	}
+	if err := s.redisDB.Set(ctx, sessionVersionKey(userID), sessionVersionValue(user), s.cfg.Jwt.SessionCacheTTL).Err(); err != nil {
+		s.logger.Error("failed to cache session version", "error", err)
+		return err
+	}
+	return nil
+}
+
+func sessionVersionKey(userID string) string {
+	return "session_version:" + userID
+}
+
+// sessionVersionValue is what the cache holds for the user, the version or bannedSessionVersion.
+func sessionVersionValue(user *model.User) string {
+	if user.IsBanned {
+		return bannedSessionVersion
+	}
+	return strconv.Itoa(user.SessionVersion)
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -22,13 +24,19 @@ func NewSmsService(logger *slog.Logger, cfg *config.Config) domain.SmsService {
 		cfg:    cfg,
 	}
 }
-func (s *smsServiceImpl) Send(msg, phone string) error {
+func (s *smsServiceImpl) Send(ctx context.Context, msg, phone string) error {
+	return s.SendBatch(ctx, msg, []string{phone})
+}
+
+// SendBatch sends one message to many phones in a single provider request. The request is abandoned once ctx is
+// done, so a hung provider cannot hold up the caller.
+func (s *smsServiceImpl) SendBatch(ctx context.Context, msg string, phones []string) error {
 	if s.cfg.App.Debug {
-		s.logger.Info("debug mode is enabled", "code", msg)
+		s.logger.Info("debug mode is enabled", "code", msg, "recipients", len(phones))
 		return nil
 	}
 	smsReq := entity.SmsRequest{
-		Recipient: []string{phone},
+		Recipient: phones,
 		Sender:    s.cfg.Sms.Sender,
 		Message:   msg,
 	}
@@ -37,7 +45,7 @@ func (s *smsServiceImpl) Send(msg, phone string) error {
 		s.logger.Error("failed to marshal sms request", "error", err)
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, s.cfg.Sms.Service, bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Sms.Service, bytes.NewBuffer(payload))
 	if err != nil {
 		s.logger.Error("failed to create sms request", "error", err)
 		return err
@@ -52,7 +60,7 @@ func (s *smsServiceImpl) Send(msg, phone string) error {
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		s.logger.Error("failed to send sms", "status_code", resp.StatusCode)
-		return err
+		return fmt.Errorf("sms provider responded with status %d", resp.StatusCode)
 	}
 	return nil
 }
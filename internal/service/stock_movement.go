@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type stockMovementServiceImpl struct {
+	stockMovementRepository domain.StockMovementRepository
+	productRepository       domain.ProductRepository
+	userRepository          domain.UserRepository
+	cacheService            domain.CacheService
+	auditService            domain.AuditService
+	eventBus                domain.EventBus
+	logger                  *slog.Logger
+	cfg                     *config.Config
+}
+
+func NewStockMovementService(
+	stockMovementRepository domain.StockMovementRepository,
+	productRepository domain.ProductRepository,
+	userRepository domain.UserRepository,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	eventBus domain.EventBus,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.StockMovementService {
+	return &stockMovementServiceImpl{
+		stockMovementRepository: stockMovementRepository,
+		productRepository:       productRepository,
+		userRepository:          userRepository,
+		cacheService:            cacheService,
+		auditService:            auditService,
+		eventBus:                eventBus,
+		logger:                  logger,
+		cfg:                     cfg,
+	}
+}
+
+func (s *stockMovementServiceImpl) GetStockMovements(ctx context.Context, productID string, limit, offset int) ([]model.StockMovement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	movements, err := s.stockMovementRepository.GetAll(ctx, productID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get stock movements", "error", err)
+		return nil, err
+	}
+	return movements, nil
+}
+
+func (s *stockMovementServiceImpl) GetLowStockProducts(ctx context.Context, limit, offset int) ([]model.LowStockProduct, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	products, err := s.stockMovementRepository.GetLowStock(ctx, s.cfg.Inventory.LowStockThreshold, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get low stock products", "error", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+func (s *stockMovementServiceImpl) MoveStock(ctx context.Context, productID string, movement *entity.StockMovementRequest) (*model.StockMovement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	actorID, _ := ctx.Value(helper.CtxUserID).(string)
+	var saved *model.StockMovement
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductStockMoved, model.AuditEntityProduct, productID, s.productSnapshot,
+		func(ctx context.Context) (string, error) {
+			var err error
+			saved, err = s.stockMovementRepository.Create(ctx, productID, actorID, movement)
+			return productID, err
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to move stock", "error", err)
+		return nil, err
+	}
+	s.cacheService.InvalidateTags(ctx, cacheTagProducts, productCacheTag(productID))
+	if crossedLowStock(saved, s.cfg.Inventory.LowStockThreshold) {
+		s.alertLowStock(ctx, saved)
+	}
+	// stock only changes through movements, so this is the one place a product comes back from zero
+	if saved.QuantityAfter > 0 && saved.QuantityAfter == saved.Delta {
+		s.eventBus.Publish(ctx, &model.Event{
+			Name:    model.EventProductRestocked,
+			Payload: map[string]string{"product_id": saved.ProductID},
+		})
+	}
+	return saved, nil
+}
+
+// alertLowStock notifies every admin through the event bus. A failure is logged, the movement already happened.
+func (s *stockMovementServiceImpl) alertLowStock(ctx context.Context, movement *model.StockMovement) {
+	product, err := s.productRepository.GetByID(ctx, movement.ProductID)
+	if err != nil {
+		s.logger.Error("failed to get low stock product", "error", err)
+		return
+	}
+	adminIDs, err := s.userRepository.GetAdminIDs(ctx)
+	if err != nil {
+		s.logger.Error("failed to get admins for low stock alert", "error", err)
+		return
+	}
+	for _, adminID := range adminIDs {
+		s.eventBus.Publish(ctx, &model.Event{
+			Name:   model.EventProductLowStock,
+			UserID: adminID,
+			Payload: map[string]string{
+				"product_id":   product.ID,
+				"product_name": product.Name,
+				"quantity":     strconv.Itoa(movement.QuantityAfter),
+			},
+		})
+	}
+}
+
+func (s *stockMovementServiceImpl) productSnapshot(ctx context.Context, productID string) (any, error) {
+	return s.productRepository.GetByID(ctx, productID)
+}
+
+// crossedLowStock reports whether the movement took the quantity from above the threshold to or below it, so an
+// alert goes out once per drop and not on every sale while the stock stays low.
+func crossedLowStock(movement *model.StockMovement, threshold int) bool {
+	before := movement.QuantityAfter - movement.Delta
+	return before > threshold && movement.QuantityAfter <= threshold
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type stockSubscriptionServiceImpl struct {
+	stockSubscriptionRepository domain.StockSubscriptionRepository
+	productRepository           domain.ProductRepository
+	smsService                  domain.SmsService
+	logger                      *slog.Logger
+	cfg                         *config.Config
+}
+
+func NewStockSubscriptionService(
+	stockSubscriptionRepository domain.StockSubscriptionRepository,
+	productRepository domain.ProductRepository,
+	smsService domain.SmsService,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.StockSubscriptionService {
+	return &stockSubscriptionServiceImpl{
+		stockSubscriptionRepository: stockSubscriptionRepository,
+		productRepository:           productRepository,
+		smsService:                  smsService,
+		logger:                      logger,
+		cfg:                         cfg,
+	}
+}
+
+// Subscribe only accepts published products that are out of stock.
+func (s *stockSubscriptionServiceImpl) Subscribe(ctx context.Context, userID, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	product, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product for stock subscription", "error", err)
+		return err
+	}
+	if product.Status != model.ProductStatusPublished {
+		return sql.ErrNoRows
+	}
+	if product.Quantity > 0 {
+		return domain.ErrInStock
+	}
+	if err := s.stockSubscriptionRepository.Create(ctx, userID, productID); err != nil {
+		s.logger.Error("failed to create stock subscription", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *stockSubscriptionServiceImpl) Unsubscribe(ctx context.Context, userID, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.stockSubscriptionRepository.Delete(ctx, userID, productID); err != nil {
+		s.logger.Error("failed to delete stock subscription", "error", err)
+		return err
+	}
+	return nil
+}
+
+// HandleEvent texts the subscribers of a restocked product in batches and clears each batch once it is sent.
+// The user asked for this message, so it goes out regardless of the notification preferences. When a batch
+// fails the remaining subscriptions are kept for the next restock.
+func (s *stockSubscriptionServiceImpl) HandleEvent(ctx context.Context, event *model.Event) error {
+	productID := event.Payload["product_id"]
+	lookupCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	product, err := s.productRepository.GetByID(lookupCtx, productID)
+	cancel()
+	if err != nil {
+		s.logger.Error("failed to get restocked product", "product_id", productID, "error", err)
+		return err
+	}
+	msg := fmt.Sprintf("«%s» دوباره موجود شد.\nفروشگاه اینترنتی اسکویید شاپ", product.Name)
+	batchSize := s.cfg.Inventory.RestockBatchSize
+	for {
+		batchCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		subscribers, err := s.stockSubscriptionRepository.GetSubscribers(batchCtx, productID, batchSize)
+		cancel()
+		if err != nil {
+			s.logger.Error("failed to get stock subscribers", "product_id", productID, "error", err)
+			return err
+		}
+		if len(subscribers) == 0 {
+			return nil
+		}
+		phones := make([]string, 0, len(subscribers))
+		subscriptionIDs := make([]string, 0, len(subscribers))
+		for _, subscriber := range subscribers {
+			phones = append(phones, subscriber.Phone)
+			subscriptionIDs = append(subscriptionIDs, subscriber.SubscriptionID)
+		}
+		batchCtx, cancel = context.WithTimeout(ctx, 3*time.Second)
+		err = s.smsService.SendBatch(batchCtx, msg, phones)
+		cancel()
+		if err != nil {
+			s.logger.Error("failed to send back in stock sms", "product_id", productID, "error", err)
+			return err
+		}
+		batchCtx, cancel = context.WithTimeout(ctx, 3*time.Second)
+		err = s.stockSubscriptionRepository.DeleteByIDs(batchCtx, subscriptionIDs)
+		cancel()
+		if err != nil {
+			s.logger.Error("failed to clear stock subscriptions", "product_id", productID, "error", err)
+			return err
+		}
+		if len(subscribers) < batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type tagServiceImpl struct {
+	tagRepository     domain.TagRepository
+	productRepository domain.ProductRepository
+	pricingService    domain.PricingService
+	wishlistService   domain.WishlistService
+	cacheService      domain.CacheService
+	auditService      domain.AuditService
+	logger            *slog.Logger
+	cfg               *config.Config
+}
+
+func NewTagService(
+	tagRepository domain.TagRepository,
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	wishlistService domain.WishlistService,
+	cacheService domain.CacheService,
+	auditService domain.AuditService,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.TagService {
+	return &tagServiceImpl{
+		tagRepository:     tagRepository,
+		productRepository: productRepository,
+		pricingService:    pricingService,
+		wishlistService:   wishlistService,
+		cacheService:      cacheService,
+		auditService:      auditService,
+		logger:            logger,
+		cfg:               cfg,
+	}
+}
+
+func (s *tagServiceImpl) GetAllTags(ctx context.Context) ([]model.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	tags, err := s.tagRepository.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("failed to get all tags", "error", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetTagBySlug returns the storefront page of a tag with one page of its published products.
+func (s *tagServiceImpl) GetTagBySlug(ctx context.Context, tagSlug string, limit, offset int) (*model.TagDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	tag, err := s.tagRepository.GetBySlug(ctx, tagSlug)
+	if err != nil {
+		s.logger.Error("failed to get tag by slug", "error", err)
+		return nil, err
+	}
+	products, err := s.productRepository.GetByTag(ctx, tag.ID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get tag products", "error", err)
+		return nil, err
+	}
+	presentProducts(ctx, s.pricingService, s.wishlistService, s.cfg, products)
+	return &model.TagDetail{Tag: *tag, Products: products}, nil
+}
+
+func (s *tagServiceImpl) CreateTag(ctx context.Context, tag *entity.TagRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionTagCreated, model.AuditEntityTag, "", s.tagSnapshot,
+		func(ctx context.Context) (string, error) {
+			return s.tagRepository.Create(ctx, tag)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to create tag", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *tagServiceImpl) UpdateTag(ctx context.Context, tagID string, tag *entity.TagRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionTagUpdated, model.AuditEntityTag, tagID, s.tagSnapshot,
+		func(ctx context.Context) (string, error) {
+			return tagID, s.tagRepository.Update(ctx, tagID, tag)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update tag", "error", err)
+		return err
+	}
+	s.cacheService.InvalidateTags(ctx, cacheTagProductDetails)
+	return nil
+}
+
+func (s *tagServiceImpl) DeleteTag(ctx context.Context, tagID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionTagDeleted, model.AuditEntityTag, tagID, s.tagSnapshot,
+		func(ctx context.Context) (string, error) {
+			return tagID, s.tagRepository.Delete(ctx, tagID)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to delete tag", "error", err)
+		return err
+	}
+	s.cacheService.InvalidateTags(ctx, cacheTagProductDetails)
+	return nil
+}
+
+// SetProductTags replaces the tags of a product, an empty list removes them all.
+func (s *tagServiceImpl) SetProductTags(ctx context.Context, productID string, tags *entity.ProductTagRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := s.auditService.Track(
+		ctx, model.AuditActionProductTagsSet, model.AuditEntityProduct, productID, s.productTagsSnapshot,
+		func(ctx context.Context) (string, error) {
+			return productID, s.tagRepository.ReplaceProductTags(ctx, productID, tags.TagIDs)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to set product tags", "error", err)
+		return err
+	}
+	s.cacheService.InvalidateTags(ctx, productCacheTag(productID))
+	return nil
+}
+
+func (s *tagServiceImpl) tagSnapshot(ctx context.Context, tagID string) (any, error) {
+	return s.tagRepository.GetByID(ctx, tagID)
+}
+
+func (s *tagServiceImpl) productTagsSnapshot(ctx context.Context, productID string) (any, error) {
+	tagIDs, err := s.tagRepository.GetProductTagIDs(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return helper.M{"tag_ids": tagIDs}, nil
+}
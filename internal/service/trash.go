@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+)
+
+type trashServiceImpl struct {
+	categoryRepository       domain.CategoryRepository
+	productRepository        domain.ProductRepository
+	productCommentRepository domain.ProductCommentRepository
+	logger                   *slog.Logger
+	cfg                      *config.Config
+}
+
+func NewTrashService(
+	categoryRepository domain.CategoryRepository,
+	productRepository domain.ProductRepository,
+	productCommentRepository domain.ProductCommentRepository,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.TrashService {
+	return &trashServiceImpl{
+		categoryRepository:       categoryRepository,
+		productRepository:        productRepository,
+		productCommentRepository: productCommentRepository,
+		logger:                   logger,
+		cfg:                      cfg,
+	}
+}
+
+// Purge hard deletes everything that has been in the trash longer than the retention period.
+// Comments go first and categories last so each step only removes what is left after the cascades of the previous one.
+func (s *trashServiceImpl) Purge(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	before := time.Now().Add(-s.cfg.Trash.Retention)
+	purges := []struct {
+		name  string
+		purge func(ctx context.Context, before time.Time) (int64, error)
+	}{
+		{"product_comments", s.productCommentRepository.Purge},
+		{"products", s.productRepository.Purge},
+		{"categories", s.categoryRepository.Purge},
+	}
+	for _, p := range purges {
+		purged, err := p.purge(ctx, before)
+		if err != nil {
+			s.logger.Error("failed to purge trash", "table", p.name, "error", err)
+			return err
+		}
+		if purged > 0 {
+			s.logger.Info("purged trash", "table", p.name, "rows", purged)
+		}
+	}
+	return nil
+}
+
+// Run purges the trash every PurgeInterval and blocks until ctx is cancelled.
+func (s *trashServiceImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Trash.PurgeInterval)
+	defer ticker.Stop()
+	s.logger.Info("trash purger started", "retention", s.cfg.Trash.Retention, "interval", s.cfg.Trash.PurgeInterval)
+	for {
+		_ = s.Purge(ctx)
+		select {
+		case <-ctx.Done():
+			s.logger.Info("trash purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
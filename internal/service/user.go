@@ -2,30 +2,55 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/arshamroshannejad/squidshop-backend/config"
 	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
 	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
 	"github.com/arshamroshannejad/squidshop-backend/internal/model"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 )
 
 type userServiceImpl struct {
-	userRepository domain.UserRepository
-	redisDB        *redis.Client
-	logger         *slog.Logger
-	cfg            *config.Config
+	userRepository      domain.UserRepository
+	otpService          domain.OTPService
+	notificationService domain.NotificationService
+	sessionService      domain.SessionService
+	auditService        domain.AuditService
+	eventBus            domain.EventBus
+	redisDB             *redis.Client
+	logger              *slog.Logger
+	cfg                 *config.Config
 }
 
-func NewUserService(userRepository domain.UserRepository, redisDB *redis.Client, logger *slog.Logger, cfg *config.Config) domain.UserService {
+func NewUserService(
+	userRepository domain.UserRepository,
+	otpService domain.OTPService,
+	notificationService domain.NotificationService,
+	sessionService domain.SessionService,
+	auditService domain.AuditService,
+	eventBus domain.EventBus,
+	redisDB *redis.Client,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.UserService {
 	return &userServiceImpl{
-		userRepository: userRepository,
-		redisDB:        redisDB,
-		logger:         logger,
-		cfg:            cfg,
+		userRepository:      userRepository,
+		otpService:          otpService,
+		notificationService: notificationService,
+		sessionService:      sessionService,
+		auditService:        auditService,
+		eventBus:            eventBus,
+		redisDB:             redisDB,
+		logger:              logger,
+		cfg:                 cfg,
 	}
 }
 
@@ -40,6 +65,17 @@ func (s *userServiceImpl) GetUserByID(ctx context.Context, userID string) (*mode
 	return user, nil
 }
 
+func (s *userServiceImpl) GetAllUsers(ctx context.Context, params *entity.UserQueryParamRequest) ([]model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	users, err := s.userRepository.GetAll(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to get all users", "error", err)
+		return nil, err
+	}
+	return users, nil
+}
+
 func (s *userServiceImpl) GetUserByPhone(ctx context.Context, phone string) (*model.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -54,10 +90,20 @@ func (s *userServiceImpl) GetUserByPhone(ctx context.Context, phone string) (*mo
 func (s *userServiceImpl) CreateUser(ctx context.Context, user *entity.UserAuthRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	if err := s.userRepository.Create(ctx, user); err != nil {
+	created, err := s.userRepository.Create(ctx, user)
+	if err != nil {
 		s.logger.Error("failed to create user", "error", err)
 		return err
 	}
+	if !created {
+		return nil
+	}
+	newUser, err := s.userRepository.GetByPhone(ctx, user.Phone)
+	if err != nil {
+		s.logger.Error("failed to get created user", "error", err)
+		return nil
+	}
+	s.eventBus.Publish(ctx, &model.Event{Name: model.EventAccountCreated, UserID: newUser.ID})
 	return nil
 }
 
@@ -67,6 +113,7 @@ func (s *userServiceImpl) GenerateUserJwtToken(ctx context.Context, user *model.
 		"user_id":  user.ID,
 		"phone":    user.Phone,
 		"is_admin": user.IsAdmin,
+		"sv":       user.SessionVersion,
 		"exp":      exp,
 	}
 	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.Jwt.Secret))
@@ -75,3 +122,309 @@ func (s *userServiceImpl) GenerateUserJwtToken(ctx context.Context, user *model.
 	}
 	return token, nil
 }
+
+func (s *userServiceImpl) UpdateUserNotificationPreference(ctx context.Context, userID string, preference *entity.UserNotificationPreferenceRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.userRepository.UpdateNotificationPreference(ctx, userID, preference); err != nil {
+		s.logger.Error("failed to update user notification preference", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *userServiceImpl) UpdateUserProfile(ctx context.Context, userID string, profile *entity.UserProfileUpdateRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.userRepository.UpdateProfile(ctx, userID, profile); err != nil {
+		s.logger.Error("failed to update user profile", "error", err)
+		return err
+	}
+	return nil
+}
+
+// RequestUserEmailChange keeps the new address aside and mails a code to it.
+// The address is only stored on the user after VerifyUserEmailChange succeeds.
+func (s *userServiceImpl) RequestUserEmailChange(ctx context.Context, userID, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	code, err := s.otpService.Generate(ctx, emailChangeOTPKey(userID))
+	if err != nil {
+		s.logger.Error("failed to generate email verification code", "error", err)
+		return err
+	}
+	if err := s.redisDB.Set(ctx, pendingEmailKey(userID), email, domain.OTPExpiration).Err(); err != nil {
+		s.logger.Error("failed to store pending email", "error", err)
+		return err
+	}
+	expiresAt := time.Now().Add(domain.OTPExpiration)
+	subject := "تایید ایمیل"
+	notification := entity.NotificationCreateRequest{
+		UserID:    &userID,
+		Channel:   model.NotificationChannelEmail,
+		Recipient: email,
+		Subject:   &subject,
+		Message:   fmt.Sprintf("کد تایید ایمیل شما : %s\nفروشگاه اینترنتی اسکویید شاپ", code),
+		Priority:  model.NotificationPriorityHigh,
+		ExpiresAt: &expiresAt,
+		Sensitive: true,
+	}
+	return s.notificationService.Enqueue(ctx, &notification)
+}
+
+func (s *userServiceImpl) VerifyUserEmailChange(ctx context.Context, userID, code string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	email, err := s.redisDB.Get(ctx, pendingEmailKey(userID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return domain.ErrInvalidCode
+		}
+		s.logger.Error("failed to get pending email", "error", err)
+		return err
+	}
+	isValid, err := s.otpService.Verify(ctx, emailChangeOTPKey(userID), code)
+	if err != nil {
+		s.logger.Error("failed to verify email code", "error", err)
+		return err
+	}
+	if !isValid {
+		return domain.ErrInvalidCode
+	}
+	if err := s.userRepository.UpdateEmail(ctx, userID, email); err != nil {
+		s.logger.Error("failed to update user email", "error", err)
+		return err
+	}
+	_ = s.redisDB.Del(ctx, pendingEmailKey(userID)).Err()
+	return nil
+}
+
+func (s *userServiceImpl) RequestUserDeletion(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user with id", "error", err)
+		return err
+	}
+	code, err := s.otpService.Generate(ctx, deletionOTPKey(user.Phone))
+	if err != nil {
+		s.logger.Error("failed to generate deletion code", "error", err)
+		return err
+	}
+	expiresAt := time.Now().Add(domain.OTPExpiration)
+	notification := entity.NotificationCreateRequest{
+		UserID:    &user.ID,
+		Channel:   model.NotificationChannelSms,
+		Recipient: user.Phone,
+		Message:   fmt.Sprintf("کد تایید حذف حساب کاربری : %s\nفروشگاه اینترنتی اسکویید شاپ", code),
+		Priority:  model.NotificationPriorityHigh,
+		ExpiresAt: &expiresAt,
+		Sensitive: true,
+	}
+	return s.notificationService.Enqueue(ctx, &notification)
+}
+
+func (s *userServiceImpl) DeleteUser(ctx context.Context, userID, code string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user with id", "error", err)
+		return err
+	}
+	isValid, err := s.otpService.Verify(ctx, deletionOTPKey(user.Phone), code)
+	if err != nil {
+		s.logger.Error("failed to verify deletion code", "error", err)
+		return err
+	}
+	if !isValid {
+		return domain.ErrInvalidCode
+	}
+	if err := s.userRepository.Delete(ctx, userID); err != nil {
+		s.logger.Error("failed to delete user", "error", err)
+		return err
+	}
+	return nil
+}
+
+// RequestUserPhoneChange sends a code to the new phone and, unless an admin overrides the check, to the current phone too.
+// The pending phone is kept aside until VerifyUserPhoneChange confirms the codes.
+func (s *userServiceImpl) RequestUserPhoneChange(ctx context.Context, userID, phone string, override bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user with id", "error", err)
+		return err
+	}
+	if user.Phone == phone {
+		return domain.ErrDuplicate
+	}
+	if _, err := s.userRepository.GetByPhone(ctx, phone); err == nil {
+		return domain.ErrDuplicate
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		s.logger.Error("failed to get user with phone", "error", err)
+		return err
+	}
+	_, err = s.redisDB.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, pendingPhoneKey(userID), "phone", phone, "override", strconv.FormatBool(override))
+		pipe.Expire(ctx, pendingPhoneKey(userID), domain.OTPExpiration)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to store pending phone", "error", err)
+		return err
+	}
+	if err := s.sendPhoneChangeCode(ctx, user.ID, phoneChangeNewOTPKey(userID), phone); err != nil {
+		return err
+	}
+	if override {
+		return nil
+	}
+	return s.sendPhoneChangeCode(ctx, user.ID, phoneChangeOldOTPKey(userID), user.Phone)
+}
+
+func (s *userServiceImpl) sendPhoneChangeCode(ctx context.Context, userID, otpKey, phone string) error {
+	code, err := s.otpService.Generate(ctx, otpKey)
+	if err != nil {
+		s.logger.Error("failed to generate phone change code", "error", err)
+		return err
+	}
+	expiresAt := time.Now().Add(domain.OTPExpiration)
+	notification := entity.NotificationCreateRequest{
+		UserID:    &userID,
+		Channel:   model.NotificationChannelSms,
+		Recipient: phone,
+		Message:   fmt.Sprintf("کد تایید تغییر شماره موبایل : %s\nفروشگاه اینترنتی اسکویید شاپ", code),
+		Priority:  model.NotificationPriorityHigh,
+		ExpiresAt: &expiresAt,
+		Sensitive: true,
+	}
+	return s.notificationService.Enqueue(ctx, &notification)
+}
+
+// VerifyUserPhoneChange swaps the phone once every required code is confirmed.
+// The swap revokes all sessions of the user, so the caller has to issue a new token afterwards.
+func (s *userServiceImpl) VerifyUserPhoneChange(ctx context.Context, actorID, userID string, codes *entity.UserPhoneChangeVerifyRequest, override bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	pending, err := s.redisDB.HGetAll(ctx, pendingPhoneKey(userID)).Result()
+	if err != nil {
+		s.logger.Error("failed to get pending phone", "error", err)
+		return err
+	}
+	if pending["phone"] == "" || pending["override"] != strconv.FormatBool(override) {
+		return domain.ErrInvalidCode
+	}
+	isValid, err := s.otpService.Verify(ctx, phoneChangeNewOTPKey(userID), codes.NewCode)
+	if err != nil {
+		s.logger.Error("failed to verify phone change code", "error", err)
+		return err
+	}
+	if !isValid {
+		return domain.ErrInvalidCode
+	}
+	if !override {
+		isValid, err := s.otpService.Verify(ctx, phoneChangeOldOTPKey(userID), codes.OldCode)
+		if err != nil {
+			s.logger.Error("failed to verify phone change code", "error", err)
+			return err
+		}
+		if !isValid {
+			return domain.ErrInvalidCode
+		}
+	}
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user with id", "error", err)
+		return err
+	}
+	if err := s.userRepository.UpdatePhone(ctx, userID, user.Phone, pending["phone"]); err != nil {
+		s.logger.Error("failed to update user phone", "error", err)
+		return err
+	}
+	_ = s.redisDB.Del(ctx, pendingPhoneKey(userID)).Err()
+	_ = s.sessionService.Refresh(ctx, userID)
+	_ = s.auditService.Record(ctx, &entity.AuditLogCreateRequest{
+		ActorID:    &actorID,
+		Action:     model.AuditActionUserPhoneChanged,
+		EntityType: model.AuditEntityUser,
+		EntityID:   userID,
+		Before:     helper.M{"phone": user.Phone},
+		After:      helper.M{"phone": pending["phone"], "override": override},
+	})
+	return nil
+}
+
+// SetUserBanned bans or unbans the user. A ban revokes every session, so RequireAuth rejects the user right away.
+func (s *userServiceImpl) SetUserBanned(ctx context.Context, userID string, banned bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	action := model.AuditActionUserUnbanned
+	if banned {
+		action = model.AuditActionUserBanned
+	}
+	err := s.auditService.Track(
+		ctx, action, model.AuditEntityUser, userID, s.userSnapshot,
+		func(ctx context.Context) (string, error) {
+			return userID, s.userRepository.SetBanned(ctx, userID, banned)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update user ban", "error", err)
+		return err
+	}
+	_ = s.sessionService.Refresh(ctx, userID)
+	return nil
+}
+
+// SetUserAdmin promotes or demotes the user. Issued tokens carry the role, so they are revoked as well.
+func (s *userServiceImpl) SetUserAdmin(ctx context.Context, userID string, isAdmin bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	action := model.AuditActionUserDemoted
+	if isAdmin {
+		action = model.AuditActionUserPromoted
+	}
+	err := s.auditService.Track(
+		ctx, action, model.AuditEntityUser, userID, s.userSnapshot,
+		func(ctx context.Context) (string, error) {
+			return userID, s.userRepository.SetAdmin(ctx, userID, isAdmin)
+		},
+	)
+	if err != nil {
+		s.logger.Error("failed to update user role", "error", err)
+		return err
+	}
+	_ = s.sessionService.Refresh(ctx, userID)
+	return nil
+}
+
+func (s *userServiceImpl) userSnapshot(ctx context.Context, userID string) (any, error) {
+	return s.userRepository.GetByID(ctx, userID)
+}
+
+func emailChangeOTPKey(userID string) string {
+	return "email:" + userID
+}
+
+func pendingEmailKey(userID string) string {
+	return "pending_email:" + userID
+}
+
+func deletionOTPKey(phone string) string {
+	return "delete:" + phone
+}
+
+func pendingPhoneKey(userID string) string {
+	return "pending_phone:" + userID
+}
+
+func phoneChangeNewOTPKey(userID string) string {
+	return "phone_new:" + userID
+}
+
+func phoneChangeOldOTPKey(userID string) string {
+	return "phone_old:" + userID
+}
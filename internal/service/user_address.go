@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type userAddressServiceImpl struct {
+	userAddressRepository domain.UserAddressRepository
+	logger                *slog.Logger
+}
+
+func NewUserAddressService(userAddressRepository domain.UserAddressRepository, logger *slog.Logger) domain.UserAddressService {
+	return &userAddressServiceImpl{
+		userAddressRepository: userAddressRepository,
+		logger:                logger,
+	}
+}
+
+func (s *userAddressServiceImpl) GetAllUserAddresses(ctx context.Context, userID string) ([]model.UserAddress, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	addresses, err := s.userAddressRepository.GetAll(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user addresses", "error", err)
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (s *userAddressServiceImpl) CreateUserAddress(ctx context.Context, userID string, address *entity.UserAddressRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.userAddressRepository.Create(ctx, userID, address); err != nil {
+		s.logger.Error("failed to create user address", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *userAddressServiceImpl) UpdateUserAddress(ctx context.Context, addressID, userID string, address *entity.UserAddressRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.userAddressRepository.Update(ctx, addressID, userID, address); err != nil {
+		s.logger.Error("failed to update user address", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *userAddressServiceImpl) DeleteUserAddress(ctx context.Context, addressID, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.userAddressRepository.Delete(ctx, addressID, userID); err != nil {
+		s.logger.Error("failed to delete user address", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"text/template"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/entity"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+)
+
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newNotificationTemplate(name, subject, body string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New(name + "_subject").Parse(subject)),
+		body:    template.Must(template.New(name + "_body").Parse(body)),
+	}
+}
+
+var userNotificationTemplates = map[string]notificationTemplate{
+	model.EventAccountCreated: newNotificationTemplate(
+		model.EventAccountCreated,
+		"به اسکویید شاپ خوش آمدید",
+		"سلام، حساب کاربری شما در فروشگاه اینترنتی اسکویید شاپ با موفقیت ساخته شد.\nفروشگاه اینترنتی اسکویید شاپ",
+	),
+	model.EventCommentReplied: newNotificationTemplate(
+		model.EventCommentReplied,
+		"پاسخ جدید به دیدگاه شما",
+		"به دیدگاه شما درباره «{{.product_name}}» پاسخ داده شد:\n«{{.reply}}»\nفروشگاه اینترنتی اسکویید شاپ",
+	),
+	model.EventProductPriceDropped: newNotificationTemplate(
+		model.EventProductPriceDropped,
+		"کاهش قیمت {{.product_name}}",
+		"قیمت «{{.product_name}}» از {{.old_price}} به {{.new_price}} تومان کاهش یافت.\nفروشگاه اینترنتی اسکویید شاپ",
+	),
+	model.EventProductLowStock: newNotificationTemplate(
+		model.EventProductLowStock,
+		"موجودی {{.product_name}} رو به اتمام است",
+		"موجودی «{{.product_name}}» به {{.quantity}} عدد رسید.\nفروشگاه اینترنتی اسکویید شاپ",
+	),
+}
+
+type userNotificationServiceImpl struct {
+	userRepository         domain.UserRepository
+	notificationRepository domain.NotificationRepository
+	notificationService    domain.NotificationService
+	logger                 *slog.Logger
+}
+
+func NewUserNotificationService(
+	userRepository domain.UserRepository,
+	notificationRepository domain.NotificationRepository,
+	notificationService domain.NotificationService,
+	logger *slog.Logger,
+) domain.UserNotificationService {
+	return &userNotificationServiceImpl{
+		userRepository:         userRepository,
+		notificationRepository: notificationRepository,
+		notificationService:    notificationService,
+		logger:                 logger,
+	}
+}
+
+// HandleEvent renders the event template and queues it on every channel the user opted in to.
+func (s *userNotificationServiceImpl) HandleEvent(ctx context.Context, event *model.Event) error {
+	tmpl, ok := userNotificationTemplates[event.Name]
+	if !ok {
+		return fmt.Errorf("no notification template for event %s", event.Name)
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	user, err := s.userRepository.GetByID(lookupCtx, event.UserID)
+	cancel()
+	if err != nil {
+		s.logger.Error("failed to get notification recipient", "user_id", event.UserID, "error", err)
+		return err
+	}
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, event.Payload); err != nil {
+		return err
+	}
+	if err := tmpl.body.Execute(&body, event.Payload); err != nil {
+		return err
+	}
+	subjectText := subject.String()
+	eventName := event.Name
+	notification := entity.NotificationCreateRequest{
+		UserID:   &user.ID,
+		Event:    &eventName,
+		Subject:  &subjectText,
+		Message:  body.String(),
+		Priority: model.NotificationPriorityNormal,
+	}
+	if user.NotifySms {
+		notification.Channel = model.NotificationChannelSms
+		notification.Recipient = user.Phone
+		if err := s.notificationService.Enqueue(ctx, &notification); err != nil {
+			return err
+		}
+	}
+	if user.NotifyEmail && user.Email != nil {
+		notification.Channel = model.NotificationChannelEmail
+		notification.Recipient = *user.Email
+		if err := s.notificationService.Enqueue(ctx, &notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *userNotificationServiceImpl) GetUserNotifications(ctx context.Context, userID string, limit, offset int) ([]model.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	params := entity.NotificationQueryParamRequest{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	}
+	notifications, err := s.notificationRepository.GetAll(ctx, &params)
+	if err != nil {
+		s.logger.Error("failed to get user notifications", "error", err)
+		return nil, err
+	}
+	return notifications, nil
+}
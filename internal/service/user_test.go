@@ -26,19 +26,69 @@ func (m *mockUserRepository) GetByID(ctx context.Context, userID string) (*model
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
+func (m *mockUserRepository) GetAll(ctx context.Context, params *entity.UserQueryParamRequest) ([]model.User, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]model.User), args.Error(1)
+}
+
 func (m *mockUserRepository) GetByPhone(ctx context.Context, phone string) (*model.User, error) {
 	args := m.Called(ctx, phone)
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
-func (m *mockUserRepository) Create(ctx context.Context, user *entity.UserAuthRequest) error {
+func (m *mockUserRepository) GetAdminIDs(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockUserRepository) Create(ctx context.Context, user *entity.UserAuthRequest) (bool, error) {
 	args := m.Called(ctx, user)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockUserRepository) UpdateNotificationPreference(ctx context.Context, userID string, preference *entity.UserNotificationPreferenceRequest) error {
+	args := m.Called(ctx, userID, preference)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) UpdateProfile(ctx context.Context, userID string, profile *entity.UserProfileUpdateRequest) error {
+	args := m.Called(ctx, userID, profile)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) UpdateEmail(ctx context.Context, userID, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) UpdatePhone(ctx context.Context, userID, oldPhone, newPhone string) error {
+	args := m.Called(ctx, userID, oldPhone, newPhone)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) IncrementSessionVersion(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) SetBanned(ctx context.Context, userID string, banned bool) error {
+	args := m.Called(ctx, userID, banned)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) SetAdmin(ctx context.Context, userID string, isAdmin bool) error {
+	args := m.Called(ctx, userID, isAdmin)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) Delete(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
 func newTestService(repo *mockUserRepository) domain.UserService {
 	cfg := &config.Config{
-		App: &config.App{
+		Jwt: &config.Jwt{
 			Secret:        "testsecret",
 			AccessHourTTL: time.Hour,
 		},
@@ -46,6 +96,7 @@ func newTestService(repo *mockUserRepository) domain.UserService {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	return &userServiceImpl{
 		userRepository: repo,
+		eventBus:       NewEventBus(logger),
 		logger:         logger,
 		cfg:            cfg,
 	}
@@ -77,7 +128,18 @@ func TestUserServiceImpl_Create(t *testing.T) {
 	repo := new(mockUserRepository)
 	svc := newTestService(repo)
 	req := &entity.UserAuthRequest{Phone: "1234"}
-	repo.On("Create", mock.Anything, req).Return(nil).Once()
+	repo.On("Create", mock.Anything, req).Return(false, nil).Once()
+	err := svc.CreateUser(context.Background(), req)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestUserServiceImpl_CreateNewUser(t *testing.T) {
+	repo := new(mockUserRepository)
+	svc := newTestService(repo)
+	req := &entity.UserAuthRequest{Phone: "1234"}
+	repo.On("Create", mock.Anything, req).Return(true, nil).Once()
+	repo.On("GetByPhone", mock.Anything, "1234").Return(&model.User{ID: "u1", Phone: "1234"}, nil).Once()
 	err := svc.CreateUser(context.Background(), req)
 	assert.NoError(t, err)
 	repo.AssertExpectations(t)
@@ -87,7 +149,7 @@ func TestUserServiceImpl_CreateError(t *testing.T) {
 	repo := new(mockUserRepository)
 	svc := newTestService(repo)
 	req := &entity.UserAuthRequest{Phone: "1234"}
-	repo.On("Create", mock.Anything, req).Return(errors.New("db error")).Once()
+	repo.On("Create", mock.Anything, req).Return(false, errors.New("db error")).Once()
 	err := svc.CreateUser(context.Background(), req)
 	assert.Error(t, err)
 	repo.AssertExpectations(t)
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/arshamroshannejad/squidshop-backend/config"
+	"github.com/arshamroshannejad/squidshop-backend/internal/domain"
+	"github.com/arshamroshannejad/squidshop-backend/internal/helper"
+	"github.com/arshamroshannejad/squidshop-backend/internal/model"
+	"github.com/arshamroshannejad/squidshop-backend/internal/money"
+)
+
+type wishlistServiceImpl struct {
+	wishlistRepository domain.WishlistRepository
+	productRepository  domain.ProductRepository
+	pricingService     domain.PricingService
+	eventBus           domain.EventBus
+	logger             *slog.Logger
+	cfg                *config.Config
+}
+
+func NewWishlistService(
+	wishlistRepository domain.WishlistRepository,
+	productRepository domain.ProductRepository,
+	pricingService domain.PricingService,
+	eventBus domain.EventBus,
+	logger *slog.Logger,
+	cfg *config.Config,
+) domain.WishlistService {
+	return &wishlistServiceImpl{
+		wishlistRepository: wishlistRepository,
+		productRepository:  productRepository,
+		pricingService:     pricingService,
+		eventBus:           eventBus,
+		logger:             logger,
+		cfg:                cfg,
+	}
+}
+
+func (s *wishlistServiceImpl) GetWishlist(ctx context.Context, userID string, limit, offset int) ([]model.Products, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	products, err := s.wishlistRepository.GetAll(ctx, userID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get wishlist", "error", err)
+		return nil, err
+	}
+	wishlisted := true
+	for i := range products {
+		products[i].Wishlisted = &wishlisted
+	}
+	s.prepare(ctx, products)
+	return products, nil
+}
+
+// GetSharedWishlist lists the wishlist behind a share link. The flags follow the reader, not the owner of the list.
+func (s *wishlistServiceImpl) GetSharedWishlist(ctx context.Context, token string, limit, offset int) ([]model.Products, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	products, err := s.wishlistRepository.GetShared(ctx, token, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get shared wishlist", "error", err)
+		return nil, err
+	}
+	s.prepare(ctx, products)
+	s.MarkProducts(ctx, products)
+	return products, nil
+}
+
+// AddToWishlist only accepts published products, adding one that is already in the wishlist is a no-op.
+func (s *wishlistServiceImpl) AddToWishlist(ctx context.Context, userID, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	product, err := s.productRepository.GetByID(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product for wishlist", "error", err)
+		return err
+	}
+	if product.Status != model.ProductStatusPublished {
+		return sql.ErrNoRows
+	}
+	if err := s.wishlistRepository.Create(ctx, userID, productID); err != nil {
+		s.logger.Error("failed to add product to wishlist", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *wishlistServiceImpl) RemoveFromWishlist(ctx context.Context, userID, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.wishlistRepository.Delete(ctx, userID, productID); err != nil {
+		s.logger.Error("failed to remove product from wishlist", "error", err)
+		return err
+	}
+	return nil
+}
+
+// ShareWishlist returns the share link of the wishlist, creating it on first use.
+func (s *wishlistServiceImpl) ShareWishlist(ctx context.Context, userID string) (*model.WishlistShare, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	token, err := newShareToken()
+	if err != nil {
+		s.logger.Error("failed to generate wishlist share token", "error", err)
+		return nil, err
+	}
+	share, err := s.wishlistRepository.CreateShare(ctx, userID, token)
+	if err != nil {
+		s.logger.Error("failed to share wishlist", "error", err)
+		return nil, err
+	}
+	share.URL = s.cfg.App.BaseAPI + "/wishlist/shared/" + share.Token
+	return share, nil
+}
+
+// UnshareWishlist revokes the share link, sharing again afterwards yields a new one.
+func (s *wishlistServiceImpl) UnshareWishlist(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if err := s.wishlistRepository.DeleteShare(ctx, userID); err != nil {
+		s.logger.Error("failed to unshare wishlist", "error", err)
+		return err
+	}
+	return nil
+}
+
+// MarkProducts sets the wishlisted flag of every product for the current user. Anonymous requests leave it unset.
+func (s *wishlistServiceImpl) MarkProducts(ctx context.Context, products []model.Products) {
+	ids := make([]string, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+	wishlisted := s.wishlisted(ctx, ids)
+	if wishlisted == nil {
+		return
+	}
+	for i := range products {
+		flag := wishlisted[products[i].ID]
+		products[i].Wishlisted = &flag
+	}
+}
+
+func (s *wishlistServiceImpl) MarkProduct(ctx context.Context, product *model.Product) {
+	wishlisted := s.wishlisted(ctx, []string{product.ID})
+	if wishlisted == nil {
+		return
+	}
+	flag := wishlisted[product.ID]
+	product.Wishlisted = &flag
+}
+
+// wishlisted returns the set of the given products the current user has wishlisted, or nil when there is no user or
+// the lookup fails.
+func (s *wishlistServiceImpl) wishlisted(ctx context.Context, productIDs []string) map[string]bool {
+	userID, _ := ctx.Value(helper.CtxUserID).(string)
+	if userID == "" || len(productIDs) == 0 {
+		return nil
+	}
+	ids, err := s.wishlistRepository.GetWishlistedIDs(ctx, userID, productIDs)
+	if err != nil {
+		s.logger.Error("failed to get wishlisted products", "error", err)
+		return nil
+	}
+	wishlisted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wishlisted[id] = true
+	}
+	return wishlisted
+}
+
+func (s *wishlistServiceImpl) prepare(ctx context.Context, products []model.Products) {
+	_ = s.pricingService.PriceProducts(ctx, products)
+	for i := range products {
+		if products[i].MainImage != nil {
+			products[i].MainImage = helper.BuildMediaURL(s.cfg, products[i].MainImage)
+		}
+	}
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// NotifyPriceDrop tells everyone who wishlisted a published product that its list price fell below oldPrice.
+// Discounts are left out, they end on their own. A failure is logged, the price change already happened.
+func (s *wishlistServiceImpl) NotifyPriceDrop(ctx context.Context, product *model.Product, oldPrice money.Money) {
+	if product.Status != model.ProductStatusPublished || product.Price >= oldPrice {
+		return
+	}
+	userIDs, err := s.wishlistRepository.GetUserIDs(ctx, product.ID)
+	if err != nil {
+		s.logger.Error("failed to get wishlist users for price drop", "error", err)
+		return
+	}
+	for _, userID := range userIDs {
+		s.eventBus.Publish(ctx, &model.Event{
+			Name:   model.EventProductPriceDropped,
+			UserID: userID,
+			Payload: map[string]string{
+				"product_id":   product.ID,
+				"product_name": product.Name,
+				"old_price":    strconv.FormatInt(oldPrice.Toman(), 10),
+				"new_price":    strconv.FormatInt(product.Price.Toman(), 10),
+			},
+		})
+	}
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_notifications_created_at;
+DROP INDEX IF EXISTS idx_notifications_recipient;
+DROP INDEX IF EXISTS idx_notifications_dispatch;
+DROP TABLE IF EXISTS notifications;
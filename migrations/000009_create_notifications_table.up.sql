@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id              SERIAL PRIMARY KEY,
+    channel         VARCHAR(20)  NOT NULL DEFAULT 'sms',
+    recipient       VARCHAR(255) NOT NULL,
+    message         TEXT         NOT NULL,
+    priority        SMALLINT     NOT NULL DEFAULT 0,
+    status          VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    max_attempts    INTEGER      NOT NULL DEFAULT 5,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at      TIMESTAMP,
+    sent_at         TIMESTAMP,
+    created_at      TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('pending', 'processing', 'sent', 'dead')),
+    CHECK (attempts >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_dispatch ON notifications (status, priority DESC, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications (recipient);
+CREATE INDEX IF NOT EXISTS idx_notifications_created_at ON notifications (created_at);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_notifications_user_id;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS subject,
+    DROP COLUMN IF EXISTS event,
+    DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS notify_email,
+    DROP COLUMN IF EXISTS notify_sms,
+    DROP COLUMN IF EXISTS email;
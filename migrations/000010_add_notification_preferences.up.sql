@@ -0,0 +1,11 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email        VARCHAR(255) UNIQUE,
+    ADD COLUMN IF NOT EXISTS notify_sms   BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS notify_email BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS user_id INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS event   VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS subject VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id, created_at DESC);
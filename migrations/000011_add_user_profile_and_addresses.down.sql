@@ -0,0 +1,25 @@
+DELETE FROM product_ratings WHERE user_id IS NULL;
+
+ALTER TABLE product_ratings
+    DROP CONSTRAINT IF EXISTS product_ratings_product_id_user_id_key,
+    DROP CONSTRAINT IF EXISTS product_ratings_pkey,
+    DROP COLUMN IF EXISTS id,
+    ALTER COLUMN user_id SET NOT NULL,
+    ADD PRIMARY KEY (product_id, user_id);
+
+DELETE FROM product_comments WHERE user_id IS NULL;
+
+ALTER TABLE product_comments
+    DROP CONSTRAINT IF EXISTS product_comments_user_id_fkey,
+    ADD CONSTRAINT product_comments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    ALTER COLUMN user_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_user_addresses_default;
+DROP INDEX IF EXISTS idx_user_addresses_user_id;
+DROP TABLE IF EXISTS user_addresses;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS national_id,
+    DROP COLUMN IF EXISTS birthday,
+    DROP COLUMN IF EXISTS last_name,
+    DROP COLUMN IF EXISTS first_name;
@@ -0,0 +1,35 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS first_name  VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS last_name   VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS birthday    DATE,
+    ADD COLUMN IF NOT EXISTS national_id VARCHAR(10) UNIQUE;
+
+CREATE TABLE IF NOT EXISTS user_addresses
+(
+    id             SERIAL PRIMARY KEY,
+    user_id        INTEGER      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    title          VARCHAR(100) NOT NULL,
+    receiver_name  VARCHAR(200) NOT NULL,
+    receiver_phone VARCHAR(20)  NOT NULL,
+    province       VARCHAR(100) NOT NULL,
+    city           VARCHAR(100) NOT NULL,
+    postal_code    VARCHAR(10)  NOT NULL,
+    address        TEXT         NOT NULL,
+    is_default     BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP             DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_addresses_user_id ON user_addresses (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_addresses_default ON user_addresses (user_id) WHERE is_default;
+
+ALTER TABLE product_comments
+    ALTER COLUMN user_id DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS product_comments_user_id_fkey,
+    ADD CONSTRAINT product_comments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE product_ratings
+    DROP CONSTRAINT IF EXISTS product_ratings_pkey,
+    ADD COLUMN IF NOT EXISTS id SERIAL PRIMARY KEY,
+    ALTER COLUMN user_id DROP NOT NULL,
+    ADD CONSTRAINT product_ratings_product_id_user_id_key UNIQUE (product_id, user_id);
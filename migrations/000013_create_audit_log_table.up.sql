@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id          BIGSERIAL PRIMARY KEY,
+    actor_id    INTEGER,
+    action      VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50)  NOT NULL,
+    entity_id   VARCHAR(50)  NOT NULL,
+    before      JSONB,
+    after       JSONB,
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log (actor_id, created_at DESC);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_phone_pattern;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS banned_at,
+    DROP COLUMN IF EXISTS is_banned;
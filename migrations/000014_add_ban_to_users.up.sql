@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS is_banned BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_phone_pattern ON users (phone varchar_pattern_ops);
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_immutable();
+
+DROP INDEX IF EXISTS idx_audit_log_created_at;
+DROP INDEX IF EXISTS idx_audit_log_action;
+
+ALTER TABLE audit_log
+    DROP COLUMN IF EXISTS request_id,
+    DROP COLUMN IF EXISTS ip;
@@ -0,0 +1,27 @@
+ALTER TABLE audit_log
+    ADD COLUMN IF NOT EXISTS ip         VARCHAR(45),
+    ADD COLUMN IF NOT EXISTS request_id VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log (action, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
+
+CREATE OR REPLACE FUNCTION audit_log_immutable() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+CREATE TRIGGER audit_log_no_update
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE FUNCTION audit_log_immutable();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE
+    ON audit_log
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_log_immutable();
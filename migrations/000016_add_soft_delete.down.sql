@@ -0,0 +1,23 @@
+DELETE FROM product_comments WHERE deleted_at IS NOT NULL;
+DELETE FROM products WHERE deleted_at IS NOT NULL;
+DELETE FROM categories WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_product_comments_deleted_at;
+DROP INDEX IF EXISTS idx_products_deleted_at;
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+DROP INDEX IF EXISTS idx_products_slug_live;
+DROP INDEX IF EXISTS idx_categories_slug_live;
+DROP INDEX IF EXISTS idx_categories_name_live;
+
+ALTER TABLE products
+    ADD CONSTRAINT products_slug_key UNIQUE (slug);
+ALTER TABLE categories
+    ADD CONSTRAINT categories_name_key UNIQUE (name),
+    ADD CONSTRAINT categories_slug_key UNIQUE (slug);
+
+ALTER TABLE product_comments
+    DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS deleted_at;
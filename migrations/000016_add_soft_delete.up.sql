@@ -0,0 +1,21 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE product_comments
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- trashed rows must not block reusing their name or slug
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS categories_name_key,
+    DROP CONSTRAINT IF EXISTS categories_slug_key;
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_slug_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name_live ON categories (name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_slug_live ON categories (slug) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_slug_live ON products (slug) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_product_comments_deleted_at ON product_comments (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_price_history_product_changed_at;
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS product_revisions;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS product_revisions
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    product_id         INTEGER        NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    revision           INTEGER        NOT NULL,
+    name               VARCHAR(255)   NOT NULL,
+    slug               VARCHAR(255)   NOT NULL,
+    description        TEXT,
+    short_description  VARCHAR(255),
+    price              DECIMAL(10, 2) NOT NULL,
+    quantity           INTEGER        NOT NULL,
+    category_id        INTEGER,
+    editor_id          INTEGER,
+    rolled_back_from   INTEGER,
+    created_at         TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS price_history
+(
+    id         BIGSERIAL PRIMARY KEY,
+    product_id INTEGER        NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price      DECIMAL(10, 2) NOT NULL,
+    changed_at TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_history_product_changed_at ON price_history (product_id, changed_at DESC);
+
+-- existing products start their history from their current state
+INSERT INTO product_revisions (product_id, revision, name, slug, description, short_description, price, quantity, category_id, created_at)
+SELECT id, 1, name, slug, description, short_description, price, quantity, category_id, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+FROM products;
+
+INSERT INTO price_history (product_id, price, changed_at)
+SELECT id, price, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+FROM products;
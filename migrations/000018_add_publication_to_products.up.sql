@@ -0,0 +1,16 @@
+-- products that already exist stay visible, new ones start as drafts
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status       VARCHAR(16) NOT NULL DEFAULT 'published',
+    ADD COLUMN IF NOT EXISTS publish_at   TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP;
+
+UPDATE products SET published_at = created_at WHERE published_at IS NULL;
+
+ALTER TABLE products
+    ALTER COLUMN status SET DEFAULT 'draft',
+    ADD CONSTRAINT check_products_status CHECK (status IN ('draft', 'published', 'archived'));
+
+CREATE INDEX IF NOT EXISTS idx_products_status ON products (status);
+CREATE INDEX IF NOT EXISTS idx_products_publish_at ON products (publish_at) WHERE status = 'draft';
+CREATE INDEX IF NOT EXISTS idx_products_unpublish_at ON products (unpublish_at) WHERE status = 'published';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_discounts_category_id;
+DROP INDEX IF EXISTS idx_discounts_product_id;
+DROP INDEX IF EXISTS idx_discounts_window;
+DROP TABLE IF EXISTS discounts;
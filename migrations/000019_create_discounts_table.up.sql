@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS discounts
+(
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(100)   NOT NULL,
+    kind        VARCHAR(16)    NOT NULL,
+    value       DECIMAL(10, 2) NOT NULL,
+    scope       VARCHAR(16)    NOT NULL,
+    product_id  INTEGER REFERENCES products (id) ON DELETE CASCADE,
+    category_id INTEGER REFERENCES categories (id) ON DELETE CASCADE,
+    starts_at   TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at     TIMESTAMP,
+    created_at  TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_discounts_kind CHECK (kind IN ('percentage', 'fixed', 'price')),
+    CONSTRAINT check_discounts_value CHECK (value > 0 AND (kind <> 'percentage' OR value <= 100)),
+    CONSTRAINT check_discounts_scope CHECK (
+        (scope = 'product' AND product_id IS NOT NULL AND category_id IS NULL)
+            OR (scope = 'category' AND category_id IS NOT NULL AND product_id IS NULL)
+            OR (scope = 'catalog' AND product_id IS NULL AND category_id IS NULL)
+        ),
+    CONSTRAINT check_discounts_sale_price CHECK (kind <> 'price' OR scope = 'product'),
+    CONSTRAINT check_discounts_window CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_discounts_window ON discounts (starts_at, ends_at);
+CREATE INDEX IF NOT EXISTS idx_discounts_product_id ON discounts (product_id);
+CREATE INDEX IF NOT EXISTS idx_discounts_category_id ON discounts (category_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_coupon_redemptions_user_id;
+DROP INDEX IF EXISTS idx_coupon_redemptions_coupon_id_user_id;
+DROP TABLE IF EXISTS coupon_redemptions;
+DROP INDEX IF EXISTS idx_coupons_code;
+DROP TABLE IF EXISTS coupons;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS coupons
+(
+    id               SERIAL PRIMARY KEY,
+    code             VARCHAR(32)    NOT NULL,
+    kind             VARCHAR(16)    NOT NULL,
+    value            DECIMAL(10, 2) NOT NULL,
+    max_discount     DECIMAL(10, 2),
+    min_basket       DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    scope            VARCHAR(16)    NOT NULL,
+    product_id       INTEGER REFERENCES products (id) ON DELETE CASCADE,
+    category_id      INTEGER REFERENCES categories (id) ON DELETE CASCADE,
+    usage_limit      INTEGER,
+    per_user_limit   INTEGER,
+    first_order_only BOOLEAN        NOT NULL DEFAULT FALSE,
+    redemption_count INTEGER        NOT NULL DEFAULT 0,
+    starts_at        TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at          TIMESTAMP,
+    created_at       TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_coupons_kind CHECK (kind IN ('percentage', 'fixed')),
+    CONSTRAINT check_coupons_value CHECK (value > 0 AND (kind <> 'percentage' OR value <= 100)),
+    CONSTRAINT check_coupons_max_discount CHECK (max_discount IS NULL OR max_discount > 0),
+    CONSTRAINT check_coupons_min_basket CHECK (min_basket >= 0),
+    CONSTRAINT check_coupons_scope CHECK (
+        (scope = 'product' AND product_id IS NOT NULL AND category_id IS NULL)
+            OR (scope = 'category' AND category_id IS NOT NULL AND product_id IS NULL)
+            OR (scope = 'catalog' AND product_id IS NULL AND category_id IS NULL)
+        ),
+    CONSTRAINT check_coupons_limits CHECK ((usage_limit IS NULL OR usage_limit > 0) AND (per_user_limit IS NULL OR per_user_limit > 0)),
+    CONSTRAINT check_coupons_redemption_count CHECK (redemption_count >= 0),
+    CONSTRAINT check_coupons_window CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_coupons_code ON coupons (UPPER(code));
+
+CREATE TABLE IF NOT EXISTS coupon_redemptions
+(
+    id         SERIAL PRIMARY KEY,
+    coupon_id  INTEGER        NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    user_id    INTEGER        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    discount   DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_coupon_id_user_id ON coupon_redemptions (coupon_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_user_id ON coupon_redemptions (user_id);
@@ -0,0 +1,26 @@
+ALTER TABLE coupon_redemptions ALTER COLUMN discount TYPE DECIMAL(10, 2) USING discount / 10.0;
+ALTER TABLE coupons
+    ALTER COLUMN min_basket TYPE DECIMAL(10, 2) USING min_basket / 10.0,
+    ALTER COLUMN max_discount TYPE DECIMAL(10, 2) USING max_discount / 10.0,
+    DROP CONSTRAINT IF EXISTS check_coupons_value,
+    ADD COLUMN value DECIMAL(10, 2);
+UPDATE coupons SET value = COALESCE(percent, amount / 10.0);
+ALTER TABLE coupons
+    ALTER COLUMN value SET NOT NULL,
+    DROP COLUMN amount,
+    DROP COLUMN percent,
+    ADD CONSTRAINT check_coupons_value CHECK (value > 0 AND (kind <> 'percentage' OR value <= 100));
+
+ALTER TABLE discounts
+    DROP CONSTRAINT IF EXISTS check_discounts_value,
+    ADD COLUMN value DECIMAL(10, 2);
+UPDATE discounts SET value = COALESCE(percent, amount / 10.0);
+ALTER TABLE discounts
+    ALTER COLUMN value SET NOT NULL,
+    DROP COLUMN amount,
+    DROP COLUMN percent,
+    ADD CONSTRAINT check_discounts_value CHECK (value > 0 AND (kind <> 'percentage' OR value <= 100));
+
+ALTER TABLE price_history ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 10.0;
+ALTER TABLE product_revisions ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 10.0;
+ALTER TABLE products ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 10.0;
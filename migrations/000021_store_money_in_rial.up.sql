@@ -0,0 +1,34 @@
+-- Amounts used to be toman with two decimals, they are now whole rials.
+ALTER TABLE products ALTER COLUMN price TYPE BIGINT USING ROUND(price * 10);
+ALTER TABLE product_revisions ALTER COLUMN price TYPE BIGINT USING ROUND(price * 10);
+ALTER TABLE price_history ALTER COLUMN price TYPE BIGINT USING ROUND(price * 10);
+
+-- A percentage and an amount of money are different things, so value is split into percent and amount.
+ALTER TABLE discounts
+    ADD COLUMN percent DECIMAL(5, 2),
+    ADD COLUMN amount  BIGINT;
+UPDATE discounts SET percent = value WHERE kind = 'percentage';
+UPDATE discounts SET amount = ROUND(value * 10) WHERE kind <> 'percentage';
+ALTER TABLE discounts
+    DROP CONSTRAINT IF EXISTS check_discounts_value,
+    DROP COLUMN value,
+    ADD CONSTRAINT check_discounts_value CHECK (
+        (kind = 'percentage' AND percent > 0 AND percent <= 100 AND amount IS NULL)
+            OR (kind <> 'percentage' AND amount > 0 AND percent IS NULL)
+        );
+
+ALTER TABLE coupons
+    ADD COLUMN percent DECIMAL(5, 2),
+    ADD COLUMN amount  BIGINT;
+UPDATE coupons SET percent = value WHERE kind = 'percentage';
+UPDATE coupons SET amount = ROUND(value * 10) WHERE kind <> 'percentage';
+ALTER TABLE coupons
+    DROP CONSTRAINT IF EXISTS check_coupons_value,
+    DROP COLUMN value,
+    ADD CONSTRAINT check_coupons_value CHECK (
+        (kind = 'percentage' AND percent > 0 AND percent <= 100 AND amount IS NULL)
+            OR (kind <> 'percentage' AND amount > 0 AND percent IS NULL)
+        ),
+    ALTER COLUMN max_discount TYPE BIGINT USING ROUND(max_discount * 10),
+    ALTER COLUMN min_basket TYPE BIGINT USING ROUND(min_basket * 10);
+ALTER TABLE coupon_redemptions ALTER COLUMN discount TYPE BIGINT USING ROUND(discount * 10);
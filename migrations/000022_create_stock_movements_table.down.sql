@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_stock_movements_product_id_created_at;
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS stock_movements
+(
+    id             SERIAL PRIMARY KEY,
+    product_id     INTEGER     NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    kind           VARCHAR(16) NOT NULL,
+    delta          INTEGER     NOT NULL,
+    quantity_after INTEGER     NOT NULL,
+    reason         VARCHAR(255),
+    actor_id       INTEGER REFERENCES users (id) ON DELETE SET NULL,
+    created_at     TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_stock_movements_kind CHECK (kind IN ('restock', 'sale', 'return', 'adjustment')),
+    CONSTRAINT check_stock_movements_delta CHECK (
+        delta <> 0
+            AND (kind NOT IN ('restock', 'return') OR delta > 0)
+            AND (kind <> 'sale' OR delta < 0)
+        ),
+    CONSTRAINT check_stock_movements_quantity_after CHECK (quantity_after >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_id_created_at ON stock_movements (product_id, created_at DESC);
+
+-- products.quantity is now the running balance of the ledger, open it with the stock on hand.
+INSERT INTO stock_movements (product_id, kind, delta, quantity_after, reason, created_at)
+SELECT id, 'adjustment', quantity, quantity, 'opening balance', CURRENT_TIMESTAMP
+FROM products
+WHERE quantity > 0;
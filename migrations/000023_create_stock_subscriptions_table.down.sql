@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_stock_subscriptions_product_id;
+DROP TABLE IF EXISTS stock_subscriptions;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS stock_subscriptions
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    product_id INTEGER   NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_stock_subscriptions_user_product UNIQUE (user_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_subscriptions_product_id ON stock_subscriptions (product_id, id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wishlist_shares;
+DROP INDEX IF EXISTS idx_wishlists_user_id_created_at;
+DROP TABLE IF EXISTS wishlists;
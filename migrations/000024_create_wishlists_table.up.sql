@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS wishlists
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    product_id INTEGER   NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_wishlists_user_product UNIQUE (user_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wishlists_user_id_created_at ON wishlists (user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS wishlist_shares
+(
+    user_id    INTEGER PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    token      VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
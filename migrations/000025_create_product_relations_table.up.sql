@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS product_relations
+(
+    product_id INTEGER   NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    related_id INTEGER   NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    position   INTEGER   NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, related_id),
+    CONSTRAINT check_product_relations_not_self CHECK (product_id <> related_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_relations_related_id ON product_relations (related_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_categories_parent_id_position;
+DROP INDEX IF EXISTS idx_categories_path;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS path,
+    DROP COLUMN IF EXISTS position;
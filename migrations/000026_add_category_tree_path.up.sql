@@ -0,0 +1,41 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS path     TEXT;
+
+-- Categories caught in a parent cycle cannot be reached from the top level, they are moved there.
+WITH RECURSIVE walk AS (
+    SELECT id AS start, parent_id AS current, 1 AS depth
+    FROM categories
+    WHERE parent_id IS NOT NULL
+    UNION ALL
+    SELECT w.start, c.parent_id, w.depth + 1
+    FROM walk w
+             JOIN categories c ON c.id = w.current
+    WHERE w.current <> w.start
+      AND c.parent_id IS NOT NULL
+      AND w.depth < 1000
+)
+UPDATE categories
+SET parent_id = NULL
+WHERE id IN (SELECT start FROM walk WHERE current = start);
+
+-- path lists the ids from the top level down to the category itself, e.g. /1/4/9/.
+WITH RECURSIVE tree AS (
+    SELECT id, '/' || id || '/' AS path
+    FROM categories
+    WHERE parent_id IS NULL
+    UNION ALL
+    SELECT c.id, t.path || c.id || '/'
+    FROM categories c
+             JOIN tree t ON c.parent_id = t.id
+)
+UPDATE categories c
+SET path = tree.path
+FROM tree
+WHERE c.id = tree.id;
+
+ALTER TABLE categories
+    ALTER COLUMN path SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories (path text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id_position ON categories (parent_id, position);
@@ -0,0 +1,5 @@
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS seo_title,
+    DROP COLUMN IF EXISTS banner_image,
+    DROP COLUMN IF EXISTS description;
@@ -0,0 +1,5 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS description      TEXT,
+    ADD COLUMN IF NOT EXISTS banner_image     VARCHAR(500),
+    ADD COLUMN IF NOT EXISTS seo_title        VARCHAR(70),
+    ADD COLUMN IF NOT EXISTS meta_description VARCHAR(160);
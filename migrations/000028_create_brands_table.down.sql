@@ -0,0 +1,9 @@
+ALTER TABLE product_revisions
+    DROP COLUMN IF EXISTS brand_id;
+
+DROP INDEX IF EXISTS idx_products_brand_id;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS brand_id;
+
+DROP TABLE IF EXISTS brands;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS brands
+(
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL UNIQUE,
+    slug        VARCHAR(100) NOT NULL UNIQUE,
+    logo        VARCHAR(500),
+    description TEXT,
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS brand_id INTEGER REFERENCES brands (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_brand_id ON products (brand_id);
+
+ALTER TABLE product_revisions
+    ADD COLUMN IF NOT EXISTS brand_id INTEGER;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS collection_products;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS product_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS tags
+(
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL UNIQUE,
+    slug       VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS product_tags
+(
+    product_id INTEGER NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    tag_id     INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_tags_tag_id ON product_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS collections
+(
+    id               SERIAL PRIMARY KEY,
+    name             VARCHAR(100) NOT NULL UNIQUE,
+    slug             VARCHAR(100) NOT NULL UNIQUE,
+    description      TEXT,
+    mode             VARCHAR(10)  NOT NULL DEFAULT 'manual',
+    rule_category_id INTEGER REFERENCES categories (id) ON DELETE SET NULL,
+    rule_brand_id    INTEGER REFERENCES brands (id) ON DELETE SET NULL,
+    rule_tag_id      INTEGER REFERENCES tags (id) ON DELETE SET NULL,
+    rule_min_price   BIGINT,
+    rule_max_price   BIGINT,
+    created_at       TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_collections_mode CHECK (mode IN ('manual', 'rule'))
+);
+
+CREATE TABLE IF NOT EXISTS collection_products
+(
+    collection_id INTEGER NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    product_id    INTEGER NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    position      INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (collection_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_products_product_id ON collection_products (product_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS home_banners;
+DROP TABLE IF EXISTS home_sections;
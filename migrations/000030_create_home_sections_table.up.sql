@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS home_sections
+(
+    id            SERIAL PRIMARY KEY,
+    kind          VARCHAR(20)  NOT NULL,
+    title         VARCHAR(100) NOT NULL DEFAULT '',
+    position      INTEGER      NOT NULL DEFAULT 0,
+    hidden        BOOLEAN      NOT NULL DEFAULT false,
+    collection_id INTEGER REFERENCES collections (id) ON DELETE SET NULL,
+    category_ids  INTEGER[]    NOT NULL DEFAULT '{}',
+    item_limit    INTEGER      NOT NULL DEFAULT 8,
+    created_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_home_sections_kind CHECK (kind IN ('banner', 'collection', 'newest', 'best_rated', 'categories'))
+);
+
+CREATE TABLE IF NOT EXISTS home_banners
+(
+    id         SERIAL PRIMARY KEY,
+    section_id INTEGER      NOT NULL REFERENCES home_sections (id) ON DELETE CASCADE,
+    image      VARCHAR(500) NOT NULL,
+    link       VARCHAR(500),
+    alt        VARCHAR(255),
+    position   INTEGER      NOT NULL DEFAULT 0,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_home_banners_section_id ON home_banners (section_id);
@@ -0,0 +1,4 @@
+ALTER TABLE collections
+    DROP COLUMN IF EXISTS rule_has_tag,
+    DROP COLUMN IF EXISTS rule_has_brand,
+    DROP COLUMN IF EXISTS rule_has_category;
@@ -0,0 +1,9 @@
+ALTER TABLE collections
+    ADD COLUMN IF NOT EXISTS rule_has_category BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS rule_has_brand    BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS rule_has_tag      BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE collections
+SET rule_has_category = rule_category_id IS NOT NULL,
+    rule_has_brand    = rule_brand_id IS NOT NULL,
+    rule_has_tag      = rule_tag_id IS NOT NULL;
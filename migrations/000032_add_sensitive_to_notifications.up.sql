@@ -0,0 +1,6 @@
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS sensitive BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE notifications
+SET sensitive = TRUE
+WHERE expires_at IS NOT NULL;